@@ -9,6 +9,8 @@ import (
 	eventsapi "github.com/salesforceanton/events-api"
 	"github.com/salesforceanton/events-api/config"
 	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/mailer"
+	"github.com/salesforceanton/events-api/pkg/notifier"
 	"github.com/salesforceanton/events-api/pkg/repository"
 	"github.com/salesforceanton/events-api/pkg/service"
 	handler "github.com/salesforceanton/events-api/pkg/transport/rest"
@@ -36,6 +38,11 @@ func main() {
 		return
 	}
 
+	if err := service.ValidateSigningKeysConfig(cfg); err != nil {
+		logger.LogExecutionIssue(err)
+		return
+	}
+
 	// Connect to DB
 	db, err := repository.NewPostgresDB(cfg)
 	if err != nil {
@@ -44,14 +51,40 @@ func main() {
 	}
 
 	// Init dependenties
+	mailer, err := mailer.NewMailer(cfg)
+	if err != nil {
+		logger.LogExecutionIssue(err)
+		return
+	}
+
+	notifier, err := notifier.NewNotifier(cfg, mailer)
+	if err != nil {
+		logger.LogExecutionIssue(err)
+		return
+	}
+
 	repos := repository.NewRepository(db)
-	services := service.NewService(repos, cfg)
+	services := service.NewService(repos, mailer, notifier, cfg)
 	handler := handler.NewHandler(services)
 
+	// Run background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	go services.SigningKeys.RunRotation(jobsCtx)
+	go services.Reminders.RunScheduler(jobsCtx)
+	go services.Digests.RunScheduler(jobsCtx)
+
+	routes, err := handler.InitRoutes(cfg.TrustedProxies)
+	if err != nil {
+		logger.LogExecutionIssue(err)
+		return
+	}
+
 	// Run server
 	server := new(eventsapi.Server)
 	go func() {
-		if err := server.Run(cfg.Port, handler.InitRoutes()); err != nil {
+		if err := server.Run(cfg.Port, routes); err != nil {
 			logger.LogExecutionIssue(err)
 			return
 		}
@@ -62,6 +95,8 @@ func main() {
 	signal.Notify(exit, syscall.SIGTERM, syscall.SIGINT)
 	<-exit
 
+	stopJobs()
+
 	if err := server.Shutdown(context.Background()); err != nil {
 		logger.LogExecutionIssue(err)
 		return
@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -18,6 +19,61 @@ type Config struct {
 	PasswordHashSalt string `envconfig:"PASSWORD_HASH_SALT"`
 	TokenSecret      string `envconfig:"TOKEN_SECRET"`
 	Port             string `envconfig:"PORT"`
+
+	// Client IP is taken from X-Forwarded-For only for requests coming from these proxies,
+	// by default forwarded headers are ignored and connection address is used
+	TrustedProxies []string `envconfig:"TRUSTED_PROXIES"`
+
+	// Token signing algorithm is one of: HS256, RS256, EdDSA.
+	// Asymmetric keys are rotated by schedule and published via JWKS endpoint
+	TokenSigningAlg     string        `envconfig:"TOKEN_SIGNING_ALG" default:"HS256"`
+	KeyRotationInterval time.Duration `envconfig:"KEY_ROTATION_INTERVAL" default:"720h"`
+	KeyPublishAhead     time.Duration `envconfig:"KEY_PUBLISH_AHEAD" default:"24h"`
+	RetiredKeyTTL       time.Duration `envconfig:"RETIRED_KEY_TTL" default:"24h"`
+	AcceptLegacyTokens  bool          `envconfig:"ACCEPT_LEGACY_TOKENS" default:"true"`
+
+	// Links in outgoing emails point to the client application
+	AppUrl                string        `envconfig:"APP_URL"`
+	RequireVerifiedEmail  bool          `envconfig:"REQUIRE_VERIFIED_EMAIL"`
+	VerifyEmailTokenTTL   time.Duration `envconfig:"VERIFY_EMAIL_TOKEN_TTL" default:"24h"`
+	ResetPasswordTokenTTL time.Duration `envconfig:"RESET_PASSWORD_TOKEN_TTL" default:"1h"`
+
+	MfaIssuer       string        `envconfig:"MFA_ISSUER" default:"Events API"`
+	MfaChallengeTTL time.Duration `envconfig:"MFA_CHALLENGE_TTL" default:"5m"`
+
+	// Sign-in brute-force protection
+	LoginMaxFailures     int           `envconfig:"LOGIN_MAX_FAILURES" default:"5"`
+	LoginMaxIpFailures   int           `envconfig:"LOGIN_MAX_IP_FAILURES" default:"50"`
+	LoginFailureWindow   time.Duration `envconfig:"LOGIN_FAILURE_WINDOW" default:"15m"`
+	LoginLockoutDuration time.Duration `envconfig:"LOGIN_LOCKOUT_DURATION" default:"15m"`
+
+	// Requests to public API per client IP
+	PublicRateLimit  int           `envconfig:"PUBLIC_RATE_LIMIT" default:"60"`
+	PublicRateWindow time.Duration `envconfig:"PUBLIC_RATE_WINDOW" default:"1m"`
+
+	// OpenID Connect login is enabled when issuer is set
+	OidcIssuer        string   `envconfig:"OIDC_ISSUER"`
+	OidcClientId      string   `envconfig:"OIDC_CLIENT_ID"`
+	OidcClientSecret  string   `envconfig:"OIDC_CLIENT_SECRET"`
+	OidcRedirectUrl   string   `envconfig:"OIDC_REDIRECT_URL"`
+	OidcScopes        []string `envconfig:"OIDC_SCOPES" default:"openid,email,profile"`
+	OidcAutoProvision bool     `envconfig:"OIDC_AUTO_PROVISION" default:"true"`
+
+	// Mailer type is one of: smtp, log
+	MailerType   string `envconfig:"MAILER_TYPE" default:"log"`
+	MailFrom     string `envconfig:"MAIL_FROM"`
+	MailLogFile  string `envconfig:"MAIL_LOG_FILE"`
+	SMTPHost     string `envconfig:"SMTP_HOST"`
+	SMTPPort     string `envconfig:"SMTP_PORT"`
+	SMTPUsername string `envconfig:"SMTP_USERNAME"`
+	SMTPPassword string `envconfig:"SMTP_PASSWORD"`
+
+	// Reminders are delivered by notifier of type: email, webhook, log.
+	// Scheduler checks due reminders with the interval, it is disabled on the server when interval is 0
+	NotifierType          string        `envconfig:"NOTIFIER_TYPE" default:"log"`
+	NotifierWebhookUrl    string        `envconfig:"NOTIFIER_WEBHOOK_URL"`
+	NotifierWebhookSecret string        `envconfig:"NOTIFIER_WEBHOOK_SECRET"`
+	ReminderCheckInterval time.Duration `envconfig:"REMINDER_CHECK_INTERVAL" default:"30s"`
 }
 
 // Recieve configuration values from env variables
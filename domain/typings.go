@@ -1,19 +1,129 @@
 package domain
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
 type User struct {
-	Id       int    `json:"-" db:"id"`
-	Email    string `json:"email" db:"email" binding:"required"`
-	Username string `json:"username" db:"username" binding:"required"`
-	Password string `json:"password" db:"password_hash" binding:"required"`
+	Id            int    `json:"-" db:"id"`
+	Email         string `json:"email" db:"email" binding:"required"`
+	Username      string `json:"username" db:"username" binding:"required"`
+	Password      string `json:"password" db:"password_hash" binding:"required"`
+	EmailVerified bool   `json:"-" db:"email_verified"`
+	MfaEnabled    bool   `json:"-" db:"mfa_enabled"`
+	MfaRequired   bool   `json:"-" db:"mfa_required"`
+	MfaSecret     string `json:"-" db:"mfa_secret"`
+	MfaLastStep   int64  `json:"-" db:"mfa_last_step"`
+	Role          string `json:"-" db:"role"`
 }
 
-type Event struct {
+// User record as it is shown to administrators
+type UserInfo struct {
 	Id            int    `json:"id" db:"id"`
-	Title         string `json:"title" db:"title" binding:"required"`
-	StartDatetime string `json:"startDatetime" db:"startdatetime" binding:"required"`
-	TimezoneId    string `json:"timezoneId" db:"timezoneid"`
-	OrganizerId   int    `json:"organizerId" db:"organizerid"`
-	Description   string `json:"description" db:"description"`
+	Email         string `json:"email" db:"email"`
+	Username      string `json:"username" db:"username"`
+	Role          string `json:"role" db:"role"`
+	EmailVerified bool   `json:"emailVerified" db:"email_verified"`
+	MfaEnabled    bool   `json:"mfaEnabled" db:"mfa_enabled"`
+	MfaRequired   bool   `json:"mfaRequired" db:"mfa_required"`
+}
+
+const (
+	ROLE_USER      = "user"
+	ROLE_MODERATOR = "moderator"
+	ROLE_ADMIN     = "admin"
+)
+
+// Permissions on records of other users, organizer always has full access to own events
+const (
+	PERMISSION_EVENTS_READ_ANY   = "events:read:any"
+	PERMISSION_EVENTS_WRITE_ANY  = "events:write:any"
+	PERMISSION_EVENTS_DELETE_ANY = "events:delete:any"
+	PERMISSION_USERS_READ        = "users:read"
+	PERMISSION_USERS_MANAGE      = "users:manage"
+)
+
+var RolePermissions = map[string][]string{
+	ROLE_USER: {},
+	ROLE_MODERATOR: {
+		PERMISSION_EVENTS_READ_ANY,
+		PERMISSION_EVENTS_DELETE_ANY,
+		PERMISSION_USERS_READ,
+	},
+	ROLE_ADMIN: {
+		PERMISSION_EVENTS_READ_ANY,
+		PERMISSION_EVENTS_WRITE_ANY,
+		PERMISSION_EVENTS_DELETE_ANY,
+		PERMISSION_USERS_READ,
+		PERMISSION_USERS_MANAGE,
+	},
+}
+
+type SetRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type SetMfaRequiredRequest struct {
+	Required bool `json:"required"`
+}
+
+type SignInResult struct {
+	Token                 string `json:"token,omitempty"`
+	MfaRequired           bool   `json:"mfaRequired,omitempty"`
+	MfaEnrollmentRequired bool   `json:"mfaEnrollmentRequired,omitempty"`
+	ChallengeToken        string `json:"challengeToken,omitempty"`
+}
+
+type MfaEnrollment struct {
+	Secret        string   `json:"secret"`
+	OtpauthUri    string   `json:"otpauthUri"`
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type UserToken struct {
+	UserId    int       `db:"user_id"`
+	Purpose   string    `db:"purpose"`
+	TokenHash string    `db:"token_hash"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+type Event struct {
+	Id             int    `json:"id" db:"id"`
+	Title          string `json:"title" db:"title" binding:"required"`
+	StartDatetime  string `json:"startDatetime" db:"startdatetime" binding:"required"`
+	EndDatetime    string `json:"endDatetime" db:"enddatetime"`
+	TimezoneId     string `json:"timezoneId" db:"timezoneid"`
+	OrganizerId    int    `json:"organizerId" db:"organizerid"`
+	OrganizationId int    `json:"organizationId" db:"organization_id"`
+	Description    string `json:"description" db:"description"`
+	CalendarId     int    `json:"calendarId" db:"calendar_id"`
+	Visibility     string `json:"visibility" db:"visibility"`
+	Slug           string `json:"slug,omitempty" db:"slug"`
+	Status         string `json:"status" db:"status"`
+	// Set for cancelled events, attendees see why the event does not take place
+	CancellationReason string `json:"cancellationReason,omitempty" db:"cancellation_reason"`
+	EventLocation
+	// Distance from the point of nearby search
+	DistanceKm *float64 `json:"distanceKm,omitempty" db:"distance_km"`
+	// Attendees are loaded for a single event only
+	AttendeeIds []int `json:"attendeeIds,omitempty" db:"-"`
+	// Resources reserved by the event, loaded for a single event only
+	ResourceIds []int `json:"resourceIds,omitempty" db:"-"`
+	// Tags of the event, loaded by service
+	Tags []Tag `json:"tags,omitempty" db:"-"`
+	// Minutes before start when participants are reminded, loaded for a single event only
+	Reminders []int `json:"reminders,omitempty" db:"-"`
+	// Values of custom fields of the organization by field key
+	CustomFields CustomValues `json:"customFields,omitempty" db:"custom_fields"`
+	// Attendees invited outside of their working hours or during out-of-office, set on update
+	Warnings []AvailabilityWarning `json:"warnings,omitempty" db:"-"`
+	// Effective permission of current user
+	Permission string `json:"permission,omitempty" db:"permission"`
+	// Revision of the event in calendar messages sent to attendees
+	Sequence int `json:"-" db:"sequence"`
 }
 
 type SaveEventRequest struct {
@@ -21,4 +131,726 @@ type SaveEventRequest struct {
 	StartDatetime string `json:"startDatetime" db:"startdatetime" binding:"required"`
 	TimezoneId    string `json:"timezoneId" db:"timezoneid"`
 	Description   string `json:"description" db:"description"`
+	// Default calendar of the organizer is used when calendar is not set
+	CalendarId int `json:"calendarId" db:"calendar_id"`
+	// Private by default, current visibility is kept on update when it is not set
+	Visibility string `json:"visibility" db:"visibility"`
+	// Assigned by service to events which are not private
+	Slug string `json:"-" db:"slug"`
+	// New event is published unless it is created as draft, then status is changed with transitions
+	Status string `json:"status" db:"status"`
+	// Wall time in event timezone like start, event lasts one hour by default
+	EndDatetime string `json:"endDatetime" db:"enddatetime"`
+	// Members of the organization invited to the event, attendees are kept on update when it is not set
+	AttendeeIds []int `json:"attendeeIds"`
+	// Rooms and equipment reserved for the time of the event, reservations are kept on update when it is not set
+	ResourceIds []int `json:"resourceIds"`
+	// Tags of the organization or of the organizer, tags are kept on update when it is not set
+	TagIds []int `json:"tagIds"`
+	// Values by field key, validated by schema of the organization. Values are kept on update when it is not set
+	CustomFields CustomValues `json:"customFields"`
+	// Minutes before start when participants are reminded instead of their defaults, reminders are kept on update
+	// when it is not set
+	Reminders []int `json:"reminders" binding:"max=5,dive,min=0,max=10080"`
+	EventLocation
+	// Instants of start and end, computed by service from wall time and timezone
+	StartsAt time.Time `json:"-"`
+	EndsAt   time.Time `json:"-"`
+}
+
+// Venue of the event with optional coordinates for nearby search, online events have url.
+// Latitude and longitude are set together
+type EventLocation struct {
+	Address   string   `json:"address,omitempty" db:"address" binding:"max=500"`
+	Latitude  *float64 `json:"latitude,omitempty" db:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude,omitempty" db:"longitude" binding:"omitempty,min=-180,max=180"`
+	OnlineUrl string   `json:"onlineUrl,omitempty" db:"online_url" binding:"omitempty,url,max=2048"`
+}
+
+// Point and radius in kilometers of nearby search, radius is 10 km by default
+type NearbyFilter struct {
+	Lat    *float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng    *float64 `form:"lng" binding:"required,min=-180,max=180"`
+	Radius float64  `form:"radius" binding:"omitempty,gt=0,max=500"`
+}
+
+// Range of coordinates which contains the search circle, it is checked before distance
+type GeoBox struct {
+	MinLat float64
+	MaxLat float64
+	MinLng float64
+	MaxLng float64
+}
+
+// Conflicts are checked for the organizer, with CheckAttendees for attendees too
+type ConflictCheck struct {
+	AllowConflicts bool `form:"allowConflicts"`
+	CheckAttendees bool `form:"checkAttendees"`
+}
+
+// Event of a participant overlapping the saved event. Events of other users are not disclosed, only busy time
+type EventConflict struct {
+	UserId   int       `json:"userId" db:"user_id"`
+	EventId  int       `json:"eventId,omitempty" db:"event_id"`
+	StartsAt time.Time `json:"startsAt" db:"starts_at"`
+	EndsAt   time.Time `json:"endsAt" db:"ends_at"`
+}
+
+// Two events of the user which overlap, with the overlapping time
+type EventOverlap struct {
+	EventId            int       `json:"eventId" db:"event_id"`
+	ConflictingEventId int       `json:"conflictingEventId" db:"conflicting_event_id"`
+	StartsAt           time.Time `json:"startsAt" db:"starts_at"`
+	EndsAt             time.Time `json:"endsAt" db:"ends_at"`
+}
+
+// Busy time of users in the window. Events are not disclosed, only merged busy intervals are returned
+type FreeBusyRequest struct {
+	UserIds []int     `json:"userIds" binding:"required,min=1"`
+	TimeMin time.Time `json:"timeMin" binding:"required"`
+	TimeMax time.Time `json:"timeMax" binding:"required"`
+}
+
+type BusyInterval struct {
+	Start time.Time `json:"start" db:"starts_at"`
+	End   time.Time `json:"end" db:"ends_at"`
+}
+
+// Busy time of users who do not share free/busy is not returned, they are marked as private
+type UserFreeBusy struct {
+	UserId  int            `json:"userId"`
+	Busy    []BusyInterval `json:"busy"`
+	Private bool           `json:"private,omitempty"`
+}
+
+// Slots when required participants are free, optional participants are those not listed in requiredIds.
+// All participants are required when requiredIds is empty. Working hours are wall time in the timezone
+type SuggestSlotsRequest struct {
+	ParticipantIds  []int        `json:"participantIds" binding:"required,min=1"`
+	RequiredIds     []int        `json:"requiredIds"`
+	DurationMinutes int          `json:"durationMinutes" binding:"required,min=5,max=1440"`
+	TimeMin         time.Time    `json:"timeMin" binding:"required"`
+	TimeMax         time.Time    `json:"timeMax" binding:"required"`
+	TimezoneId      string       `json:"timezoneId"`
+	WorkingHours    WorkingHours `json:"workingHours"`
+	IncludeWeekends bool         `json:"includeWeekends"`
+	Limit           int          `json:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type WorkingHours struct {
+	Start string `json:"start" example:"09:00"`
+	End   string `json:"end" example:"17:00"`
+}
+
+// Slots with more free optional participants go first, then earlier ones
+type SuggestedSlot struct {
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+	UnavailableIds []int     `json:"unavailableIds"`
+}
+
+// Drafts are visible to organizer and editors only, cancelled events stay visible with cancellation reason.
+// Transitions: draft -> published (publish), published -> cancelled (cancel), cancelled -> published (reopen)
+const (
+	EVENT_STATUS_DRAFT     = "draft"
+	EVENT_STATUS_PUBLISHED = "published"
+	EVENT_STATUS_CANCELLED = "cancelled"
+)
+
+type CancelEventRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+const (
+	EVENT_VISIBILITY_PRIVATE  = "private"
+	EVENT_VISIBILITY_UNLISTED = "unlisted"
+	EVENT_VISIBILITY_PUBLIC   = "public"
+)
+
+// Event data which is available without authentication, organizer and organization are not disclosed
+type PublicEvent struct {
+	Slug               string `json:"slug" db:"slug"`
+	Title              string `json:"title" db:"title"`
+	StartDatetime      string `json:"startDatetime" db:"startdatetime"`
+	TimezoneId         string `json:"timezoneId" db:"timezoneid"`
+	Description        string `json:"description" db:"description"`
+	Status             string `json:"status" db:"status"`
+	CancellationReason string `json:"cancellationReason,omitempty" db:"cancellation_reason"`
+}
+
+// Optional filters of events list, empty filter returns all accessible events
+type EventsFilter struct {
+	CalendarIds []int
+	TagIds      []int
+	// Events with any of the tags or with all of them, any by default
+	TagMatch string
+	// Values of custom fields as they are given in query, all of them should match
+	CustomFields map[string]string
+	// Typed values of custom fields, set by service
+	CustomValues CustomValues
+}
+
+const (
+	TAG_MATCH_ANY = "any"
+	TAG_MATCH_ALL = "all"
+)
+
+type LoginThrottle struct {
+	Key           string    `db:"key"`
+	Failures      int       `db:"failures"`
+	LastFailureAt time.Time `db:"last_failure_at"`
+	LockedUntil   time.Time `db:"locked_until"`
+}
+
+// Scopes of personal access tokens, session tokens are not limited by scopes
+const (
+	SCOPE_EVENTS_READ  = "events:read"
+	SCOPE_EVENTS_WRITE = "events:write"
+)
+
+var AccessTokenScopes = []string{SCOPE_EVENTS_READ, SCOPE_EVENTS_WRITE}
+
+type AccessToken struct {
+	Id             int        `json:"id" db:"id"`
+	UserId         int        `json:"-" db:"user_id"`
+	OrganizationId int        `json:"organizationId" db:"organization_id"`
+	Name           string     `json:"name" db:"name"`
+	Prefix         string     `json:"prefix" db:"token_prefix"`
+	Scopes         []string   `json:"scopes" db:"-"`
+	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty" db:"expires_at"`
+	LastUsedAt     *time.Time `json:"lastUsedAt,omitempty" db:"last_used_at"`
+}
+
+type CreateAccessTokenRequest struct {
+	Name          string   `json:"name" binding:"required"`
+	Scopes        []string `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays int      `json:"expiresInDays" binding:"min=0"`
+}
+
+// Plain token value is returned only once on creation
+type CreatedAccessToken struct {
+	AccessToken
+	Token string `json:"token"`
+}
+
+// Key pair for token signing, retired keys are not used for signing but stay valid for verification until expiration
+type SigningKey struct {
+	Kid        string     `db:"kid"`
+	Algorithm  string     `db:"algorithm"`
+	PrivateKey []byte     `db:"private_key"`
+	PublicKey  []byte     `db:"public_key"`
+	CreatedAt   time.Time  `db:"created_at"`
+	ActivatesAt time.Time  `db:"activates_at"`
+	RetiredAt   *time.Time `db:"retired_at"`
+	ExpiresAt   *time.Time `db:"expires_at"`
+}
+
+type OidcLoginState struct {
+	State        string    `db:"state"`
+	Nonce        string    `db:"nonce"`
+	CodeVerifier string    `db:"code_verifier"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
+
+// Account of external identity provider linked to the user
+type UserIdentity struct {
+	UserId  int    `db:"user_id"`
+	Issuer  string `db:"issuer"`
+	Subject string `db:"subject"`
+	Email   string `db:"email"`
+}
+
+// Authenticated caller with active organization, all events operations are scoped by the organization
+type Actor struct {
+	UserId         int
+	OrganizationId int
+	// Set when the caller acts on behalf of another user via calendar delegation
+	PrincipalId     int
+	DelegatedAccess string
+}
+
+// User whose calendar is used for the operation - the principal in delegation or the caller
+func (a Actor) EffectiveUserId() int {
+	if a.PrincipalId != 0 {
+		return a.PrincipalId
+	}
+
+	return a.UserId
+}
+
+const (
+	ORG_ROLE_OWNER  = "owner"
+	ORG_ROLE_ADMIN  = "admin"
+	ORG_ROLE_MEMBER = "member"
+)
+
+// Organization with the role of current user in it
+type Organization struct {
+	Id        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Role      string    `json:"role" db:"role"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+type OrganizationMember struct {
+	UserId   int    `json:"userId" db:"user_id"`
+	Email    string `json:"email" db:"email"`
+	Username string `json:"username" db:"username"`
+	Role     string `json:"role" db:"role"`
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type AddMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role"`
+}
+
+// Event permissions granted to users or groups, each permission includes the lower ones
+const (
+	EVENT_PERMISSION_VIEWER       = "viewer"
+	EVENT_PERMISSION_EDITOR       = "editor"
+	EVENT_PERMISSION_CO_ORGANIZER = "co-organizer"
+	// Implicit permission of the organizer, it can not be granted
+	EVENT_PERMISSION_ORGANIZER = "organizer"
+)
+
+// Grant of event permission to a user or to all members of a group
+type EventGrant struct {
+	Id         int       `json:"id" db:"id"`
+	EventId    int       `json:"eventId" db:"event_id"`
+	UserId     *int      `json:"userId,omitempty" db:"user_id"`
+	GroupId    *int      `json:"groupId,omitempty" db:"group_id"`
+	Permission string    `json:"permission" db:"permission"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at"`
+}
+
+type SaveGrantRequest struct {
+	UserId     *int   `json:"userId"`
+	GroupId    *int   `json:"groupId"`
+	Permission string `json:"permission" binding:"required"`
+}
+
+type Group struct {
+	Id             int       `json:"id" db:"id"`
+	OrganizationId int       `json:"organizationId" db:"organization_id"`
+	Name           string    `json:"name" db:"name"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+}
+
+type GroupMember struct {
+	UserId   int    `json:"userId" db:"user_id"`
+	Email    string `json:"email" db:"email"`
+	Username string `json:"username" db:"username"`
+}
+
+type CreateGroupRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type GroupMemberRequest struct {
+	UserId int `json:"userId" binding:"required"`
+}
+
+const (
+	DELEGATION_ACCESS_READ = "read"
+	DELEGATION_ACCESS_FULL = "full"
+)
+
+// Access to the whole calendar of principal given to delegate, e.g. an assistant
+type Delegation struct {
+	Id             int       `json:"id" db:"id"`
+	OrganizationId int       `json:"organizationId" db:"organization_id"`
+	PrincipalId    int       `json:"principalId" db:"principal_id"`
+	DelegateId     int       `json:"delegateId" db:"delegate_id"`
+	Access         string    `json:"access" db:"access"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+}
+
+type SaveDelegationRequest struct {
+	DelegateId int    `json:"delegateId" binding:"required"`
+	Access     string `json:"access" binding:"required"`
+}
+
+// Record of events operation, actor and principal differ when operation is done by a delegate
+type AuditEntry struct {
+	Id             int64     `json:"id" db:"id"`
+	OrganizationId int       `json:"organizationId" db:"organization_id"`
+	ActorId        int       `json:"actorId" db:"actor_id"`
+	PrincipalId    int       `json:"principalId" db:"principal_id"`
+	Action         string    `json:"action" db:"action"`
+	EventId        int       `json:"eventId,omitempty" db:"event_id"`
+	Outcome        string    `json:"outcome" db:"outcome"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+}
+
+const (
+	DEFAULT_CALENDAR_NAME  = "Personal"
+	DEFAULT_CALENDAR_COLOR = "#3174ad"
+)
+
+type Calendar struct {
+	Id             int       `json:"id" db:"id"`
+	OrganizationId int       `json:"organizationId" db:"organization_id"`
+	OwnerId        int       `json:"ownerId" db:"owner_id"`
+	Name           string    `json:"name" db:"name"`
+	Color          string    `json:"color" db:"color"`
+	TimezoneId     string    `json:"timezoneId" db:"timezone_id"`
+	IsDefault      bool      `json:"isDefault" db:"is_default"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+}
+
+type SaveCalendarRequest struct {
+	Name       string `json:"name" binding:"required"`
+	Color      string `json:"color"`
+	TimezoneId string `json:"timezoneId"`
+}
+
+const (
+	AVAILABILITY_WARNING_OUTSIDE_WORKING_HOURS = "outside_working_hours"
+	AVAILABILITY_WARNING_OUT_OF_OFFICE         = "out_of_office"
+)
+
+// Users without working hours are treated as available at any time
+type Availability struct {
+	UserId         int            `json:"-" db:"id"`
+	TimezoneId     string         `json:"timezoneId" db:"timezone_id"`
+	FreeBusyShared bool           `json:"freeBusyShared" db:"freebusy_shared"`
+	WorkingHours   []WeekdayHours `json:"workingHours"`
+	OutOfOffice    []OutOfOffice  `json:"outOfOffice"`
+}
+
+// Weekday is 0 for Sunday, working hours are wall time in timezone of the user
+type WeekdayHours struct {
+	UserId  int    `json:"-" db:"user_id"`
+	Weekday int    `json:"weekday" db:"weekday" binding:"min=0,max=6"`
+	Start   string `json:"start" db:"start_time" binding:"required" example:"09:00"`
+	End     string `json:"end" db:"end_time" binding:"required" example:"17:00"`
+}
+
+// Working hours are replaced, days which are not listed are days off.
+// Free/busy sharing is kept when it is not set
+type SaveAvailabilityRequest struct {
+	TimezoneId     string         `json:"timezoneId"`
+	FreeBusyShared *bool          `json:"freeBusyShared"`
+	WorkingHours   []WeekdayHours `json:"workingHours" binding:"dive"`
+}
+
+type OutOfOffice struct {
+	Id        int       `json:"id" db:"id"`
+	UserId    int       `json:"-" db:"user_id"`
+	StartsAt  time.Time `json:"startsAt" db:"starts_at"`
+	EndsAt    time.Time `json:"endsAt" db:"ends_at"`
+	Reason    string    `json:"reason" db:"reason"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+type SaveOutOfOfficeRequest struct {
+	StartsAt time.Time `json:"startsAt" binding:"required"`
+	EndsAt   time.Time `json:"endsAt" binding:"required"`
+	Reason   string    `json:"reason"`
+}
+
+// Attendee is invited to the event when they do not work
+type AvailabilityWarning struct {
+	UserId int    `json:"userId"`
+	Reason string `json:"reason"`
+}
+
+// Booking page of a host, guests book slots in availability windows without authentication.
+// Buffers are free time kept before and after booked slot, max per day 0 means no limit
+type BookingPage struct {
+	Id                  int             `json:"id" db:"id"`
+	OrganizationId      int             `json:"organizationId" db:"organization_id"`
+	HostId              int             `json:"hostId" db:"host_id"`
+	CalendarId          int             `json:"calendarId" db:"calendar_id"`
+	Slug                string          `json:"slug" db:"slug"`
+	Title               string          `json:"title" db:"title"`
+	Description         string          `json:"description" db:"description"`
+	DurationMinutes     int             `json:"durationMinutes" db:"duration_minutes"`
+	BufferBeforeMinutes int             `json:"bufferBeforeMinutes" db:"buffer_before_minutes"`
+	BufferAfterMinutes  int             `json:"bufferAfterMinutes" db:"buffer_after_minutes"`
+	MaxPerDay           int             `json:"maxPerDay" db:"max_per_day"`
+	TimezoneId          string          `json:"timezoneId" db:"timezone_id"`
+	IsActive            bool            `json:"isActive" db:"is_active"`
+	Windows             []BookingWindow `json:"windows" db:"-"`
+	CreatedAt           time.Time       `json:"createdAt" db:"created_at"`
+}
+
+// Weekday is 0 for Sunday, window is wall time in timezone of the page
+type BookingWindow struct {
+	BookingPageId int    `json:"-" db:"booking_page_id"`
+	Weekday       int    `json:"weekday" db:"weekday" binding:"min=0,max=6"`
+	Start         string `json:"start" db:"start_time" binding:"required" example:"09:00"`
+	End           string `json:"end" db:"end_time" binding:"required" example:"17:00"`
+}
+
+// Default calendar of the host and timezone of the calendar are used when they are not set,
+// page is active when it is not set on create
+type SaveBookingPageRequest struct {
+	Title               string          `json:"title" binding:"required"`
+	Description         string          `json:"description"`
+	CalendarId          int             `json:"calendarId"`
+	DurationMinutes     int             `json:"durationMinutes" binding:"required,min=5,max=480"`
+	BufferBeforeMinutes int             `json:"bufferBeforeMinutes" binding:"min=0,max=240"`
+	BufferAfterMinutes  int             `json:"bufferAfterMinutes" binding:"min=0,max=240"`
+	MaxPerDay           int             `json:"maxPerDay" binding:"min=0"`
+	TimezoneId          string          `json:"timezoneId"`
+	IsActive            *bool           `json:"isActive"`
+	Windows             []BookingWindow `json:"windows" binding:"required,min=1,dive"`
+}
+
+// Booking page as guests see it
+type PublicBookingPage struct {
+	Slug            string `json:"slug" db:"slug"`
+	Title           string `json:"title" db:"title"`
+	Description     string `json:"description" db:"description"`
+	DurationMinutes int    `json:"durationMinutes" db:"duration_minutes"`
+	TimezoneId      string `json:"timezoneId" db:"timezone_id"`
+}
+
+// Slots are listed for a week from now when range is not set
+type BookingSlotsFilter struct {
+	From time.Time `form:"from"`
+	To   time.Time `form:"to"`
+}
+
+type BookingSlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+type BookingRequest struct {
+	Name  string    `json:"name" binding:"required"`
+	Email string    `json:"email" binding:"required,email"`
+	Start time.Time `json:"start" binding:"required"`
+}
+
+type Booking struct {
+	Id            int       `json:"id" db:"id"`
+	BookingPageId int       `json:"-" db:"booking_page_id"`
+	EventId       int       `json:"-" db:"event_id"`
+	GuestName     string    `json:"name" db:"guest_name"`
+	GuestEmail    string    `json:"email" db:"guest_email"`
+	StartsAt      time.Time `json:"start" db:"starts_at"`
+	EndsAt        time.Time `json:"end" db:"ends_at"`
+	CreatedAt     time.Time `json:"createdAt" db:"created_at"`
+}
+
+// Meeting room or equipment of the organization which events reserve
+type Resource struct {
+	Id             int       `json:"id" db:"id"`
+	OrganizationId int       `json:"-" db:"organization_id"`
+	Name           string    `json:"name" db:"name"`
+	Capacity       int       `json:"capacity" db:"capacity"`
+	Location       string    `json:"location" db:"location"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+}
+
+type SaveResourceRequest struct {
+	Name string `json:"name" binding:"required,max=255"`
+	// Number of seats, zero for equipment
+	Capacity int    `json:"capacity" binding:"min=0"`
+	Location string `json:"location" binding:"max=255"`
+}
+
+type TimeRangeFilter struct {
+	From time.Time `form:"from" binding:"required"`
+	To   time.Time `form:"to" binding:"required"`
+}
+
+// Reserved intervals of the resource, events are not disclosed
+type ResourceAvailability struct {
+	ResourceId int            `json:"resourceId"`
+	Busy       []BusyInterval `json:"busy"`
+}
+
+// Share of the time range when the resource is reserved
+type ResourceUtilization struct {
+	ResourceId      int     `json:"resourceId" db:"resource_id"`
+	Name            string  `json:"name" db:"name"`
+	ReservedMinutes int     `json:"reservedMinutes" db:"reserved_minutes"`
+	Utilization     float64 `json:"utilization" db:"-"`
+}
+
+const (
+	TAG_SCOPE_USER         = "user"
+	TAG_SCOPE_ORGANIZATION = "organization"
+)
+
+// Label of events, organization tags are managed by organization owners and admins, personal tags by their owner
+type Tag struct {
+	Id             int    `json:"id" db:"id"`
+	OrganizationId int    `json:"-" db:"organization_id"`
+	OwnerId        int    `json:"-" db:"owner_id"`
+	Name           string `json:"name" db:"name"`
+	Scope          string `json:"scope" db:"scope"`
+	// Set when tags are loaded for events
+	EventId int `json:"-" db:"event_id"`
+}
+
+// Personal tag is created by default, scope of existing tag is kept on update
+type SaveTagRequest struct {
+	Name  string `json:"name" binding:"required,max=64"`
+	Scope string `json:"scope" binding:"omitempty,oneof=user organization"`
+}
+
+const (
+	CUSTOM_FIELD_TYPE_STRING  = "string"
+	CUSTOM_FIELD_TYPE_NUMBER  = "number"
+	CUSTOM_FIELD_TYPE_BOOLEAN = "boolean"
+	CUSTOM_FIELD_TYPE_ENUM    = "enum"
+)
+
+// Field of extra event data defined by organization, enum values are one of the options
+type CustomField struct {
+	Id             int        `json:"id" db:"id"`
+	OrganizationId int        `json:"-" db:"organization_id"`
+	Key            string     `json:"key" db:"key"`
+	Label          string     `json:"label" db:"label"`
+	Type           string     `json:"type" db:"type"`
+	Required       bool       `json:"required" db:"required"`
+	Options        StringList `json:"options" db:"options"`
+	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// Key and type of existing field are kept on update
+type SaveCustomFieldRequest struct {
+	Key      string   `json:"key" binding:"required,max=64"`
+	Label    string   `json:"label" binding:"max=255"`
+	Type     string   `json:"type" binding:"required,oneof=string number boolean enum"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options" binding:"dive,required,max=255"`
+}
+
+// Values of custom fields stored as JSON object
+type CustomValues map[string]interface{}
+
+func (v CustomValues) Value() (driver.Value, error) {
+	if v == nil {
+		return "{}", nil
+	}
+
+	result, err := json.Marshal(v)
+
+	return string(result), err
+}
+
+func (v *CustomValues) Scan(src interface{}) error {
+	return scanJSON(src, v)
+}
+
+const (
+	REMINDER_MAX_MINUTES = 10080
+
+	REMINDER_STATUS_PENDING = "pending"
+	REMINDER_STATUS_SENT    = "sent"
+	REMINDER_STATUS_SKIPPED = "skipped"
+	REMINDER_STATUS_FAILED  = "failed"
+)
+
+// Default reminders of the user in minutes before start of events
+type ReminderDefaults struct {
+	Minutes []int `json:"minutes" binding:"max=5,dive,min=0,max=10080"`
+}
+
+// Reminder of the participant about the event. Job is valid while the event takes place at the same time
+// and the reminder is still set for the participant
+type ReminderJob struct {
+	Id            int64     `db:"id"`
+	EventId       int       `db:"event_id"`
+	UserId        int       `db:"user_id"`
+	MinutesBefore int       `db:"minutes_before"`
+	StartsAt      time.Time `db:"starts_at"`
+	RemindAt      time.Time `db:"remind_at"`
+	Status        string    `db:"status"`
+	Attempts      int       `db:"attempts"`
+	LastError     string    `db:"last_error"`
+	Title         string    `db:"title"`
+	TimezoneId    string    `db:"timezoneid"`
+	Email         string    `db:"email"`
+	Valid         bool      `db:"valid"`
+}
+
+const (
+	DIGEST_FREQUENCY_OFF    = "off"
+	DIGEST_FREQUENCY_DAILY  = "daily"
+	DIGEST_FREQUENCY_WEEKLY = "weekly"
+)
+
+// Agenda digest settings of the user, send time is wall time in timezone of the user.
+// Weekly digest is sent on the weekday, 0 is for Sunday
+type DigestPreferences struct {
+	UserId     int        `json:"-" db:"user_id"`
+	Frequency  string     `json:"frequency" db:"frequency"`
+	SendTime   string     `json:"sendTime" db:"send_time" example:"08:00"`
+	Weekday    int        `json:"weekday" db:"weekday"`
+	TimezoneId string     `json:"timezoneId" db:"timezone_id"`
+	NextSendAt *time.Time `json:"nextSendAt,omitempty" db:"next_send_at"`
+	LastSentAt *time.Time `json:"lastSentAt,omitempty" db:"last_sent_at"`
+	Email      string     `json:"-" db:"email"`
+}
+
+// Digest is sent at 08:00 on Monday by default, weekday is kept when it is not set
+type SaveDigestRequest struct {
+	Frequency string `json:"frequency" binding:"required,oneof=off daily weekly"`
+	SendTime  string `json:"sendTime" example:"08:00"`
+	Weekday   *int   `json:"weekday" binding:"omitempty,min=0,max=6"`
+}
+
+// Published event of the user in agenda digest
+type AgendaItem struct {
+	EventId   int       `db:"id"`
+	Title     string    `db:"title"`
+	StartsAt  time.Time `db:"starts_at"`
+	EndsAt    time.Time `db:"ends_at"`
+	Address   string    `db:"address"`
+	OnlineUrl string    `db:"online_url"`
+}
+
+// Email notifications of attendees about changes of events, invitations are sent with updates.
+// Both are enabled by default
+type NotificationPreferences struct {
+	UserId             int  `json:"-" db:"user_id"`
+	EventUpdates       bool `json:"eventUpdates" db:"event_updates"`
+	EventCancellations bool `json:"eventCancellations" db:"event_cancellations"`
+}
+
+type NotificationRecipient struct {
+	NotificationPreferences
+	Email    string `db:"email"`
+	Username string `db:"username"`
+}
+
+// List of strings stored as JSON array
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+
+	result, err := json.Marshal(l)
+
+	return string(result), err
+}
+
+func (l *StringList) Scan(src interface{}) error {
+	return scanJSON(src, l)
+}
+
+func scanJSON(src interface{}, dest interface{}) error {
+	switch value := src.(type) {
+	case []byte:
+		return json.Unmarshal(value, dest)
+	case string:
+		return json.Unmarshal([]byte(value), dest)
+	case nil:
+		return nil
+	}
+
+	return fmt.Errorf("Unsupported JSON column type %T", src)
 }
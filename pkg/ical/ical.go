@@ -0,0 +1,127 @@
+package ical
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	METHOD_REQUEST = "REQUEST"
+	METHOD_CANCEL  = "CANCEL"
+
+	PRODUCT_ID = "-//Events API//EN"
+	// Content lines longer than this are folded, length is counted in octets
+	MAX_LINE_LENGTH = 75
+
+	dateTimeLayout = "20060102T150405Z"
+)
+
+type Attendee struct {
+	Name  string
+	Email string
+}
+
+// Event is identified by UID in calendar clients, they apply only messages with the same or greater sequence
+type Event struct {
+	UID         string
+	Sequence    int
+	Summary     string
+	Description string
+	Location    string
+	Url         string
+	StartsAt    time.Time
+	EndsAt      time.Time
+	Organizer   Attendee
+	Attendees   []Attendee
+}
+
+// Calendar object with a single event for iMIP message (RFC 6047), cancel method marks the event cancelled
+func Build(method string, event Event, stamp time.Time) string {
+	status := "CONFIRMED"
+	if method == METHOD_CANCEL {
+		status = "CANCELLED"
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"PRODID:" + PRODUCT_ID,
+		"VERSION:2.0",
+		"CALSCALE:GREGORIAN",
+		"METHOD:" + method,
+		"BEGIN:VEVENT",
+		"UID:" + escape(event.UID),
+		"DTSTAMP:" + stamp.UTC().Format(dateTimeLayout),
+		"DTSTART:" + event.StartsAt.UTC().Format(dateTimeLayout),
+		"DTEND:" + event.EndsAt.UTC().Format(dateTimeLayout),
+		fmt.Sprintf("SEQUENCE:%d", event.Sequence),
+		"SUMMARY:" + escape(event.Summary),
+		"STATUS:" + status,
+	}
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escape(event.Description))
+	}
+	if event.Location != "" {
+		lines = append(lines, "LOCATION:"+escape(event.Location))
+	}
+	if event.Url != "" {
+		lines = append(lines, "URL:"+event.Url)
+	}
+
+	lines = append(lines, fmt.Sprintf("ORGANIZER;CN=%s:mailto:%s", param(event.Organizer.Name), event.Organizer.Email))
+	for _, attendee := range event.Attendees {
+		lines = append(lines, fmt.Sprintf(
+			"ATTENDEE;CN=%s;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=FALSE:mailto:%s",
+			param(attendee.Name), attendee.Email,
+		))
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var result strings.Builder
+	for _, line := range lines {
+		result.WriteString(fold(line))
+		result.WriteString("\r\n")
+	}
+
+	return result.String()
+}
+
+// Escape text value, line breaks are kept as \n
+func escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`,
+	).Replace(value)
+}
+
+// Parameter value is quoted when it contains delimiters, quotes are not allowed in it
+func param(value string) string {
+	value = strings.NewReplacer(`"`, "", "\r", " ", "\n", " ").Replace(value)
+	if strings.ContainsAny(value, ":;,") {
+		return `"` + value + `"`
+	}
+
+	return value
+}
+
+// Split long line into continuation lines starting with a space, multi-byte characters are not split
+func fold(line string) string {
+	var result strings.Builder
+
+	limit := MAX_LINE_LENGTH
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		result.WriteString(line[:cut])
+		result.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation line starts with a space which is counted in its length
+		limit = MAX_LINE_LENGTH - 1
+	}
+	result.WriteString(line)
+
+	return result.String()
+}
@@ -0,0 +1,51 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	event := Event{
+		UID:       "event-7@events.example.com",
+		Sequence:  2,
+		Summary:   "Planning; Q3, budget",
+		Location:  "Room 1",
+		StartsAt:  time.Date(2024, 3, 4, 9, 0, 0, 0, time.FixedZone("CET", 3600)),
+		EndsAt:    time.Date(2024, 3, 4, 10, 0, 0, 0, time.FixedZone("CET", 3600)),
+		Organizer: Attendee{Name: "Anna", Email: "anna@example.com"},
+		Attendees: []Attendee{{Name: "Smith, John", Email: "john@example.com"}},
+	}
+
+	result := Build(METHOD_REQUEST, event, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	assert.True(t, strings.HasPrefix(result, "BEGIN:VCALENDAR\r\n"))
+	assert.Contains(t, result, "METHOD:REQUEST\r\n")
+	assert.Contains(t, result, "DTSTART:20240304T080000Z\r\n")
+	assert.Contains(t, result, "DTEND:20240304T090000Z\r\n")
+	assert.Contains(t, result, "SEQUENCE:2\r\n")
+	assert.Contains(t, result, `SUMMARY:Planning\; Q3\, budget`+"\r\n")
+	assert.Contains(t, result, "STATUS:CONFIRMED\r\n")
+	assert.Contains(t, result, "ORGANIZER;CN=Anna:mailto:anna@example.com\r\n")
+	// Attendee line is longer than 75 octets, it is folded
+	assert.Contains(t, strings.ReplaceAll(result, "\r\n ", ""), "ATTENDEE;CN=\"Smith, John\";ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=FALSE:mailto:")
+	assert.True(t, strings.HasSuffix(result, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+
+	cancelled := Build(METHOD_CANCEL, event, time.Now())
+	assert.Contains(t, cancelled, "METHOD:CANCEL\r\n")
+	assert.Contains(t, cancelled, "STATUS:CANCELLED\r\n")
+}
+
+func TestFold(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("ü", 80)
+
+	result := fold(line)
+
+	for _, part := range strings.Split(result, "\r\n") {
+		assert.LessOrEqual(t, len(part), MAX_LINE_LENGTH)
+	}
+	assert.Equal(t, line, strings.ReplaceAll(result, "\r\n ", ""))
+}
@@ -19,3 +19,19 @@ func LogExecutionIssue(err error) {
 		"problem": fmt.Sprintf("Error during application running: %s", err.Error()),
 	}).Error(err)
 }
+
+func LogServiceIssue(service string, err error) {
+	logrus.WithFields(logrus.Fields{
+		"service": service,
+		"problem": fmt.Sprintf("Error appeared in service [%s]: %s", service, err.Error()),
+	}).Error(err)
+}
+
+// Security events are logged with common fields so alerts on credential stuffing can be built on them
+func LogSecurityEvent(event, username, ip, details string) {
+	logrus.WithFields(logrus.Fields{
+		"security_event": event,
+		"username":       username,
+		"ip":             ip,
+	}).Warn(details)
+}
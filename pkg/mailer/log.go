@@ -0,0 +1,51 @@
+package mailer
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Mailer for local development and tests - writes messages to a file or to the app log instead of sending them
+type LogMailer struct {
+	path string
+	from string
+	mu   sync.Mutex
+}
+
+func NewLogMailer(path, from string) *LogMailer {
+	return &LogMailer{path: path, from: from}
+}
+
+func (m *LogMailer) Send(msg Message) error {
+	if m.path == "" {
+		logrus.WithFields(logrus.Fields{
+			"mailer":  "log",
+			"to":      strings.Join(msg.To, ", "),
+			"subject": msg.Subject,
+		}).Info(msg.Text)
+		return nil
+	}
+
+	body, err := buildMIME(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(body, []byte("\r\n.\r\n")...)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/salesforceanton/events-api/config"
+)
+
+const (
+	SMTP_MAILER_TYPE = "smtp"
+	LOG_MAILER_TYPE  = "log"
+)
+
+type Message struct {
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+	// iCalendar object with its method, calendar clients apply it to the event (iMIP)
+	Calendar       string
+	CalendarMethod string
+}
+
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// Build mailer implementation according with configured mailer type
+func NewMailer(cfg *config.Config) (Mailer, error) {
+	switch cfg.MailerType {
+	case SMTP_MAILER_TYPE:
+		if cfg.SMTPHost == "" || cfg.MailFrom == "" {
+			return nil, errors.New("SMTP mailer requires host and sender address")
+		}
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom), nil
+	case LOG_MAILER_TYPE, "":
+		return NewLogMailer(cfg.MailLogFile, cfg.MailFrom), nil
+	default:
+		return nil, fmt.Errorf("Unknown mailer type: %s", cfg.MailerType)
+	}
+}
@@ -0,0 +1,29 @@
+package notifier
+
+import (
+	"errors"
+
+	"github.com/salesforceanton/events-api/pkg/mailer"
+)
+
+// Sends notifications to email address of the user with configured mailer
+type EmailNotifier struct {
+	mailer mailer.Mailer
+}
+
+func NewEmailNotifier(mailer mailer.Mailer) *EmailNotifier {
+	return &EmailNotifier{mailer: mailer}
+}
+
+func (n *EmailNotifier) Notify(notification Notification) error {
+	if notification.Email == "" {
+		return errors.New("User has no email address")
+	}
+
+	return n.mailer.Send(mailer.Message{
+		To:      []string{notification.Email},
+		Subject: notification.Subject,
+		Text:    notification.Text,
+		HTML:    notification.HTML,
+	})
+}
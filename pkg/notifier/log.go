@@ -0,0 +1,22 @@
+package notifier
+
+import "github.com/sirupsen/logrus"
+
+// Notifier for local development - writes notifications to the app log
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(notification Notification) error {
+	logrus.WithFields(logrus.Fields{
+		"notifier": "log",
+		"type":     notification.Type,
+		"user":     notification.UserId,
+		"event":    notification.EventId,
+		"subject":  notification.Subject,
+	}).Info(notification.Text)
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package notifier
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/salesforceanton/events-api/config"
+	"github.com/salesforceanton/events-api/pkg/mailer"
+)
+
+const (
+	EMAIL_NOTIFIER_TYPE   = "email"
+	WEBHOOK_NOTIFIER_TYPE = "webhook"
+	LOG_NOTIFIER_TYPE     = "log"
+)
+
+// Notification of the user about the event, webhook receives it as JSON
+type Notification struct {
+	Type     string    `json:"type"`
+	UserId   int       `json:"userId"`
+	Email    string    `json:"email"`
+	EventId  int       `json:"eventId"`
+	Title    string    `json:"title"`
+	StartsAt time.Time `json:"startsAt"`
+	Subject  string    `json:"subject"`
+	Text     string    `json:"text"`
+	HTML     string    `json:"-"`
+}
+
+type Notifier interface {
+	Notify(notification Notification) error
+}
+
+// Build notifier implementation according with configured notifier type
+func NewNotifier(cfg *config.Config, mailer mailer.Mailer) (Notifier, error) {
+	switch cfg.NotifierType {
+	case EMAIL_NOTIFIER_TYPE:
+		return NewEmailNotifier(mailer), nil
+	case WEBHOOK_NOTIFIER_TYPE:
+		if cfg.NotifierWebhookUrl == "" {
+			return nil, errors.New("Webhook notifier requires url")
+		}
+		return NewWebhookNotifier(cfg.NotifierWebhookUrl, cfg.NotifierWebhookSecret), nil
+	case LOG_NOTIFIER_TYPE, "":
+		return NewLogNotifier(), nil
+	default:
+		return nil, fmt.Errorf("Unknown notifier type: %s", cfg.NotifierType)
+	}
+}
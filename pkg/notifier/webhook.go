@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	WEBHOOK_TIMEOUT          = 10 * time.Second
+	WEBHOOK_SIGNATURE_HEADER = "X-Events-Signature"
+)
+
+// Posts notifications as JSON. When secret is set body is signed with HMAC-SHA256,
+// so receiver can check that the request is sent by the server
+type WebhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: WEBHOOK_TIMEOUT},
+	}
+}
+
+func (n *WebhookNotifier) Notify(notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	if n.secret != "" {
+		request.Header.Set(WEBHOOK_SIGNATURE_HEADER, "sha256="+sign(n.secret, body))
+	}
+
+	response, err := n.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("Webhook responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+// Hex encoded HMAC-SHA256 of the body
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
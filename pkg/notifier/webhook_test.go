@@ -0,0 +1,46 @@
+package notifier
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var body []byte
+	var signature string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(WEBHOOK_SIGNATURE_HEADER)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL, "secret").Notify(Notification{
+		Type: "reminder", UserId: 1, EventId: 2, Subject: "Reminder: Demo", HTML: "<p>Demo</p>",
+	})
+	assert.NoError(t, err)
+
+	var received map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &received))
+	assert.Equal(t, "reminder", received["type"])
+	assert.Equal(t, float64(2), received["eventId"])
+	assert.NotContains(t, received, "HTML")
+	assert.Equal(t, "sha256="+sign("secret", body), signature)
+}
+
+func TestWebhookNotifier_unsigned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get(WEBHOOK_SIGNATURE_HEADER))
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL, "").Notify(Notification{Type: "reminder"})
+	assert.EqualError(t, err, "Webhook responded with status 502")
+}
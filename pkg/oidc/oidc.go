@@ -0,0 +1,350 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/salesforceanton/events-api/pkg/signing"
+)
+
+const (
+	DISCOVERY_PATH = "/.well-known/openid-configuration"
+	HTTP_TIMEOUT   = 10 * time.Second
+	// Allowed clock difference with identity provider
+	CLOCK_SKEW = time.Minute
+)
+
+var ErrInvalidIDToken = errors.New("ID token is invalid")
+
+type Config struct {
+	Issuer       string
+	ClientId     string
+	ClientSecret string
+	RedirectUrl  string
+	Scopes       []string
+}
+
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+}
+
+// Claims of ID token which are used to link or provision users
+type Claims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// OpenID Connect relying party - authorization code flow with PKCE.
+// Provider metadata and keys are discovered lazily and cached
+type Client struct {
+	cfg  Config
+	http *http.Client
+
+	mu       sync.Mutex
+	metadata *Metadata
+	keys     map[string]crypto.PublicKey
+}
+
+func NewClient(cfg Config) *Client {
+	return &Client{
+		cfg:  cfg,
+		http: &http.Client{Timeout: HTTP_TIMEOUT},
+		keys: map[string]crypto.PublicKey{},
+	}
+}
+
+func (c *Client) Issuer() string {
+	return c.cfg.Issuer
+}
+
+// Random value for state, nonce and PKCE verifier
+func RandomString() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// PKCE S256 code challenge
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	metadata, err := c.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", c.cfg.ClientId)
+	params.Set("redirect_uri", c.cfg.RedirectUrl)
+	params.Set("scope", strings.Join(c.cfg.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", CodeChallenge(verifier))
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return metadata.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange authorization code for tokens and return verified ID token claims
+func (c *Client) Exchange(ctx context.Context, code, verifier, nonce string) (Claims, error) {
+	metadata, err := c.Discover(ctx)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.cfg.RedirectUrl)
+	form.Set("client_id", c.cfg.ClientId)
+	form.Set("code_verifier", verifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Claims{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.cfg.ClientId), url.QueryEscape(c.cfg.ClientSecret))
+	}
+
+	var tokens TokenResponse
+	if err := c.do(req, &tokens); err != nil {
+		return Claims{}, fmt.Errorf("Error with code exchange: %w", err)
+	}
+
+	if tokens.IDToken == "" {
+		return Claims{}, errors.New("Token response has no ID token")
+	}
+
+	return c.VerifyIDToken(ctx, tokens.IDToken, nonce)
+}
+
+func (c *Client) VerifyIDToken(ctx context.Context, idToken, nonce string) (Claims, error) {
+	metadata, err := c.Discover(ctx)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	parser := jwt.Parser{SkipClaimsValidation: true}
+	token, err := parser.Parse(idToken, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.Alg() {
+		case signing.ALG_RS256, signing.ALG_EDDSA, "ES256":
+		default:
+			return nil, fmt.Errorf("Unsupported ID token algorithm: %s", t.Method.Alg())
+		}
+
+		kid, _ := t.Header["kid"].(string)
+		return c.key(ctx, kid)
+	})
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrInvalidIDToken, err.Error())
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return Claims{}, ErrInvalidIDToken
+	}
+
+	if err := c.validateClaims(claims, metadata.Issuer, nonce); err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrInvalidIDToken, err.Error())
+	}
+
+	result := Claims{}
+	result.Subject, _ = claims["sub"].(string)
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+	result.PreferredUsername, _ = claims["preferred_username"].(string)
+	result.EmailVerified = isTrue(claims["email_verified"])
+
+	if result.Subject == "" {
+		return Claims{}, fmt.Errorf("%w: subject is empty", ErrInvalidIDToken)
+	}
+
+	return result, nil
+}
+
+func (c *Client) validateClaims(claims jwt.MapClaims, issuer, nonce string) error {
+	now := time.Now()
+
+	if iss, _ := claims["iss"].(string); iss != issuer {
+		return errors.New("issuer does not match")
+	}
+
+	audiences := []string{}
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = append(audiences, aud)
+	case []interface{}:
+		for _, value := range aud {
+			if str, ok := value.(string); ok {
+				audiences = append(audiences, str)
+			}
+		}
+	}
+	if !contains(audiences, c.cfg.ClientId) {
+		return errors.New("audience does not match")
+	}
+	if azp, ok := claims["azp"].(string); ok && azp != c.cfg.ClientId {
+		return errors.New("authorized party does not match")
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.Add(-CLOCK_SKEW).After(time.Unix(int64(exp), 0)) {
+		return errors.New("token is expired")
+	}
+	if iat, ok := claims["iat"].(float64); ok && time.Unix(int64(iat), 0).After(now.Add(CLOCK_SKEW)) {
+		return errors.New("token is issued in the future")
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return errors.New("nonce does not match")
+	}
+
+	return nil
+}
+
+func (c *Client) Discover(ctx context.Context) (*Metadata, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.metadata != nil {
+		return c.metadata, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(c.cfg.Issuer, "/")+DISCOVERY_PATH, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata Metadata
+	if err := c.do(req, &metadata); err != nil {
+		return nil, fmt.Errorf("Error with provider discovery: %w", err)
+	}
+
+	if metadata.Issuer != c.cfg.Issuer {
+		return nil, errors.New("Provider issuer does not match configured issuer")
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JwksUri == "" {
+		return nil, errors.New("Provider metadata is incomplete")
+	}
+
+	c.metadata = &metadata
+
+	return c.metadata, nil
+}
+
+// Provider key by id, keys are refetched when unknown key id appears after rotation
+func (c *Client) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	metadata, err := c.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key, ok := c.keys[kid]; ok {
+		return key, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadata.JwksUri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var set signing.JWKSet
+	if err := c.do(req, &set); err != nil {
+		return nil, fmt.Errorf("Error with provider keys fetching: %w", err)
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if key, err := signing.FromJWK(jwk); err == nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	c.keys = keys
+
+	key, ok := c.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("Unknown provider key: %s", kid)
+	}
+
+	return key, nil
+}
+
+func (c *Client) do(req *http.Request, result interface{}) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("provider responded with status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func isTrue(value interface{}) bool {
+	switch typed := value.(type) {
+	case bool:
+		return typed
+	case string:
+		return typed == "true"
+	default:
+		return false
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,89 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/salesforceanton/events-api/pkg/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_authorizationCodeFlow(t *testing.T) {
+	provider := oidctest.NewServer("events-api", oidctest.User{
+		Subject:       "subject-1",
+		Email:         "test@mockmail.com",
+		EmailVerified: true,
+		Name:          "Test User",
+	})
+	defer provider.Close()
+
+	client := NewClient(Config{
+		Issuer:      provider.Issuer(),
+		ClientId:    "events-api",
+		RedirectUrl: "http://localhost/auth/oidc/callback",
+		Scopes:      []string{"openid", "email"},
+	})
+
+	tests := []struct {
+		name          string
+		tamper        func(code, verifier, nonce string) (string, string, string)
+		expectedError bool
+	}{
+		{
+			name:   "Ok",
+			tamper: func(code, verifier, nonce string) (string, string, string) { return code, verifier, nonce },
+		},
+		{
+			name:          "Wrong Code Verifier",
+			tamper:        func(code, verifier, nonce string) (string, string, string) { return code, "wrong", nonce },
+			expectedError: true,
+		},
+		{
+			name:          "Wrong Nonce",
+			tamper:        func(code, verifier, nonce string) (string, string, string) { return code, verifier, "wrong" },
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			state, _ := RandomString()
+			nonce, _ := RandomString()
+			verifier, _ := RandomString()
+
+			authUrl, err := client.AuthCodeURL(ctx, state, nonce, verifier)
+			assert.NoError(t, err)
+
+			// Follow authorization request as a browser until redirect back to the app
+			httpClient := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			}}
+			resp, err := httpClient.Get(authUrl)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusFound, resp.StatusCode)
+
+			callback, err := url.Parse(resp.Header.Get("Location"))
+			assert.NoError(t, err)
+			assert.Equal(t, state, callback.Query().Get("state"))
+
+			code, verifier, nonce := test.tamper(callback.Query().Get("code"), verifier, nonce)
+			claims, err := client.Exchange(ctx, code, verifier, nonce)
+
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, Claims{
+				Subject:       "subject-1",
+				Email:         "test@mockmail.com",
+				EmailVerified: true,
+				Name:          "Test User",
+			}, claims)
+		})
+	}
+}
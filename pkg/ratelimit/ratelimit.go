@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+type window struct {
+	count   int
+	resetAt time.Time
+}
+
+// Fixed window limiter of requests per key (e.g. client IP).
+// State is kept in memory, so every instance of the server limits requests on its own
+type Limiter struct {
+	mu      sync.Mutex
+	limit   int
+	period  time.Duration
+	windows map[string]*window
+	sweepAt time.Time
+	now     func() time.Time
+}
+
+func New(limit int, period time.Duration) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		period:  period,
+		windows: make(map[string]*window),
+		now:     time.Now,
+	}
+}
+
+// Return time client has to wait before the next request, zero means request is allowed
+func (l *Limiter) Allow(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	current, ok := l.windows[key]
+	if !ok || !now.Before(current.resetAt) {
+		current = &window{resetAt: now.Add(l.period)}
+		l.windows[key] = current
+	}
+
+	if current.count >= l.limit {
+		return current.resetAt.Sub(now)
+	}
+
+	current.count++
+
+	return 0
+}
+
+// Expired windows are removed once per period so memory does not grow with number of clients
+func (l *Limiter) sweep(now time.Time) {
+	if now.Before(l.sweepAt) {
+		return
+	}
+
+	for key, current := range l.windows {
+		if !now.Before(current.resetAt) {
+			delete(l.windows, key)
+		}
+	}
+
+	l.sweepAt = now.Add(l.period)
+}
@@ -0,0 +1,41 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	limiter := New(2, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), limiter.Allow("10.0.0.1"))
+	assert.Equal(t, time.Duration(0), limiter.Allow("10.0.0.1"))
+
+	now = now.Add(20 * time.Second)
+	assert.Equal(t, 40*time.Second, limiter.Allow("10.0.0.1"))
+	// Other clients have own windows
+	assert.Equal(t, time.Duration(0), limiter.Allow("10.0.0.2"))
+
+	now = now.Add(40 * time.Second)
+	assert.Equal(t, time.Duration(0), limiter.Allow("10.0.0.1"))
+}
+
+func TestSweep(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	limiter := New(1, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	limiter.Allow("10.0.0.1")
+	limiter.Allow("10.0.0.2")
+	assert.Len(t, limiter.windows, 2)
+
+	now = now.Add(2 * time.Minute)
+	limiter.Allow("10.0.0.3")
+	assert.Len(t, limiter.windows, 1)
+}
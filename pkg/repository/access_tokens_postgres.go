@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+const accessTokenColumns = "id, user_id, organization_id, name, token_prefix, scopes, created_at, expires_at, last_used_at"
+
+type AccessTokensPostgres struct {
+	db *sqlx.DB
+}
+
+type accessTokenRow struct {
+	domain.AccessToken
+	Scopes pq.StringArray `db:"scopes"`
+}
+
+func (r accessTokenRow) toDomain() domain.AccessToken {
+	result := r.AccessToken
+	result.Scopes = []string(r.Scopes)
+
+	return result
+}
+
+func NewAccessTokensPostgres(db *sqlx.DB) *AccessTokensPostgres {
+	return &AccessTokensPostgres{db: db}
+}
+
+func (r *AccessTokensPostgres) Create(token domain.AccessToken, tokenHash string) (domain.AccessToken, error) {
+	var result accessTokenRow
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (user_id, organization_id, name, token_prefix, token_hash, scopes, expires_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 RETURNING %s`,
+		ACCESS_TOKENS_TABLE, accessTokenColumns,
+	)
+	err := r.db.Get(
+		&result,
+		query,
+		token.UserId, token.OrganizationId, token.Name, token.Prefix, tokenHash, pq.StringArray(token.Scopes), token.ExpiresAt,
+	)
+
+	return result.toDomain(), err
+}
+
+func (r *AccessTokensPostgres) GetAll(userId int) ([]domain.AccessToken, error) {
+	var rows []accessTokenRow
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE user_id=$1 AND revoked_at IS NULL ORDER BY id",
+		accessTokenColumns, ACCESS_TOKENS_TABLE,
+	)
+	if err := r.db.Select(&rows, query, userId); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.AccessToken, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, row.toDomain())
+	}
+
+	return result, nil
+}
+
+// Find active token by hash and remember usage time.
+// Token stops working when the user leaves organization of the token
+func (r *AccessTokensPostgres) Use(tokenHash string) (domain.AccessToken, error) {
+	var result accessTokenRow
+
+	query := fmt.Sprintf(
+		`UPDATE %[1]s SET last_used_at=now()
+		 WHERE token_hash=$1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > now())
+		 AND EXISTS (
+			SELECT 1 FROM %[2]s m WHERE m.organization_id=%[1]s.organization_id AND m.user_id=%[1]s.user_id
+		 )
+		 RETURNING %[3]s`,
+		ACCESS_TOKENS_TABLE, ORGANIZATION_MEMBERS_TABLE, accessTokenColumns,
+	)
+	err := r.db.Get(&result, query, tokenHash)
+
+	return result.toDomain(), err
+}
+
+func (r *AccessTokensPostgres) Revoke(userId, tokenId int) (bool, error) {
+	query := fmt.Sprintf(
+		"UPDATE %s SET revoked_at=now() WHERE user_id=$1 AND id=$2 AND revoked_at IS NULL",
+		ACCESS_TOKENS_TABLE,
+	)
+	res, err := r.db.Exec(query, userId, tokenId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+type AuditPostgres struct {
+	db *sqlx.DB
+}
+
+func NewAuditPostgres(db *sqlx.DB) *AuditPostgres {
+	return &AuditPostgres{db: db}
+}
+
+func (r *AuditPostgres) Record(entry domain.AuditEntry) error {
+	query := fmt.Sprintf(
+		`INSERT INTO %s (organization_id, actor_id, principal_id, action, event_id, outcome)
+		 VALUES ($1, $2, NULLIF($3, 0), $4, NULLIF($5, 0), $6)`,
+		AUDIT_LOG_TABLE,
+	)
+	_, err := r.db.Exec(
+		query,
+		entry.OrganizationId, entry.ActorId, entry.PrincipalId, entry.Action, entry.EventId, entry.Outcome,
+	)
+
+	return err
+}
+
+// The latest operations done by the user or on behalf of the user
+func (r *AuditPostgres) GetByUser(organizationId, userId, limit int) ([]domain.AuditEntry, error) {
+	var result []domain.AuditEntry
+
+	query := fmt.Sprintf(
+		`SELECT id, organization_id, COALESCE(actor_id, 0) AS actor_id, COALESCE(principal_id, 0) AS principal_id,
+			action, COALESCE(event_id, 0) AS event_id, outcome, created_at
+		 FROM %s WHERE organization_id=$1 AND (actor_id=$2 OR principal_id=$2)
+		 ORDER BY id DESC LIMIT $3`,
+		AUDIT_LOG_TABLE,
+	)
+	err := r.db.Select(&result, query, organizationId, userId, limit)
+
+	return result, err
+}
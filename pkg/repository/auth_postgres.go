@@ -7,6 +7,9 @@ import (
 	"github.com/salesforceanton/events-api/domain"
 )
 
+const userColumns = `id, email, username, email_verified, mfa_enabled, mfa_required,
+	COALESCE(mfa_secret, '') AS mfa_secret, mfa_last_step, role`
+
 type AuthPostgres struct {
 	db *sqlx.DB
 }
@@ -15,24 +18,116 @@ func NewAuthPostgres(db *sqlx.DB) *AuthPostgres {
 	return &AuthPostgres{db: db}
 }
 
+// User is created with personal organization so events can be created right after registration
 func (r *AuthPostgres) CreateUser(user domain.User) (int, error) {
 	var result int
 
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
 	query := fmt.Sprintf("INSERT INTO %s (email, username, password_hash) VALUES ($1, $2, $3) RETURNING id", USERS_TABLE)
-	row := r.db.QueryRow(query, user.Email, user.Username, user.Password)
+	row := tx.QueryRow(query, user.Email, user.Username, user.Password)
 
 	if err := row.Scan(&result); err != nil {
+		tx.Rollback()
 		return 0, err
 	}
 
-	return result, nil
+	if _, err := createOrganization(tx, user.Username, result); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return result, tx.Commit()
 }
 
 func (r *AuthPostgres) GetUser(username, password string) (domain.User, error) {
 	var result domain.User
 
-	query := fmt.Sprintf("SELECT id FROM %s WHERE username=$1 AND password_hash=$2", USERS_TABLE)
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE username=$1 AND password_hash=$2",
+		userColumns, USERS_TABLE,
+	)
 	err := r.db.Get(&result, query, username, password)
 
 	return result, err
 }
+
+func (r *AuthPostgres) GetUserById(userId int) (domain.User, error) {
+	var result domain.User
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id=$1", userColumns, USERS_TABLE)
+	err := r.db.Get(&result, query, userId)
+
+	return result, err
+}
+
+func (r *AuthPostgres) GetUserByEmail(email string) (domain.User, error) {
+	var result domain.User
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE lower(email)=lower($1) ORDER BY id LIMIT 1",
+		userColumns, USERS_TABLE,
+	)
+	err := r.db.Get(&result, query, email)
+
+	return result, err
+}
+
+func (r *AuthPostgres) UsernameExists(username string) (bool, error) {
+	var result bool
+
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE lower(username)=lower($1))", USERS_TABLE)
+	err := r.db.Get(&result, query, username)
+
+	return result, err
+}
+
+func (r *AuthPostgres) SetEmailVerified(userId int) error {
+	query := fmt.Sprintf("UPDATE %s SET email_verified=true WHERE id=$1", USERS_TABLE)
+	_, err := r.db.Exec(query, userId)
+
+	return err
+}
+
+func (r *AuthPostgres) UpdatePassword(userId int, passwordHash string) error {
+	query := fmt.Sprintf("UPDATE %s SET password_hash=$1 WHERE id=$2", USERS_TABLE)
+	_, err := r.db.Exec(query, passwordHash, userId)
+
+	return err
+}
+
+func (r *AuthPostgres) GetUsers() ([]domain.UserInfo, error) {
+	var result []domain.UserInfo
+
+	query := fmt.Sprintf(
+		"SELECT id, email, username, role, email_verified, mfa_enabled, mfa_required FROM %s ORDER BY id",
+		USERS_TABLE,
+	)
+	err := r.db.Select(&result, query)
+
+	return result, err
+}
+
+func (r *AuthPostgres) SetRole(userId int, role string) (bool, error) {
+	query := fmt.Sprintf("UPDATE %s SET role=$1 WHERE id=$2", USERS_TABLE)
+	return r.execUserUpdate(query, role, userId)
+}
+
+func (r *AuthPostgres) SetMfaRequired(userId int, required bool) (bool, error) {
+	query := fmt.Sprintf("UPDATE %s SET mfa_required=$1 WHERE id=$2", USERS_TABLE)
+	return r.execUserUpdate(query, required, userId)
+}
+
+func (r *AuthPostgres) execUserUpdate(query string, args ...interface{}) (bool, error) {
+	res, err := r.db.Exec(query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+const outOfOfficeColumns = "id, user_id, starts_at, ends_at, reason, created_at"
+
+type AvailabilityPostgres struct {
+	db *sqlx.DB
+}
+
+func NewAvailabilityPostgres(db *sqlx.DB) *AvailabilityPostgres {
+	return &AvailabilityPostgres{db: db}
+}
+
+// Timezone, free/busy sharing and working hours of the users, out-of-office ranges are not loaded
+func (r *AvailabilityPostgres) GetAvailability(userIds []int) ([]domain.Availability, error) {
+	var result []domain.Availability
+
+	query := fmt.Sprintf("SELECT id, timezone_id, freebusy_shared FROM %s WHERE id = ANY($1) ORDER BY id", USERS_TABLE)
+	if err := r.db.Select(&result, query, pq.Array(userIds)); err != nil {
+		return nil, err
+	}
+
+	var hours []domain.WeekdayHours
+
+	query = fmt.Sprintf(
+		`SELECT user_id, weekday, to_char(start_time, 'HH24:MI') AS start_time, to_char(end_time, 'HH24:MI') AS end_time
+		 FROM %s WHERE user_id = ANY($1) ORDER BY user_id, weekday`,
+		USER_WORKING_HOURS_TABLE,
+	)
+	if err := r.db.Select(&hours, query, pq.Array(userIds)); err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		result[i].WorkingHours = []domain.WeekdayHours{}
+		for _, day := range hours {
+			if day.UserId == result[i].UserId {
+				result[i].WorkingHours = append(result[i].WorkingHours, day)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Timezone and working hours are replaced in one transaction
+func (r *AvailabilityPostgres) SaveAvailability(userId int, request domain.SaveAvailabilityRequest) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET timezone_id=$1, freebusy_shared=COALESCE($2, freebusy_shared) WHERE id=$3",
+		USERS_TABLE,
+	)
+	if _, err := tx.Exec(query, request.TimezoneId, request.FreeBusyShared, userId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query = fmt.Sprintf("DELETE FROM %s WHERE user_id=$1", USER_WORKING_HOURS_TABLE)
+	if _, err := tx.Exec(query, userId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query = fmt.Sprintf(
+		"INSERT INTO %s (user_id, weekday, start_time, end_time) VALUES ($1, $2, $3, $4)",
+		USER_WORKING_HOURS_TABLE,
+	)
+	for _, day := range request.WorkingHours {
+		if _, err := tx.Exec(query, userId, day.Weekday, day.Start, day.End); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *AvailabilityPostgres) GetUpcomingOutOfOffice(userId int) ([]domain.OutOfOffice, error) {
+	var result []domain.OutOfOffice
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE user_id=$1 AND ends_at > now() ORDER BY starts_at, id",
+		outOfOfficeColumns, OUT_OF_OFFICE_TABLE,
+	)
+	err := r.db.Select(&result, query, userId)
+
+	return result, err
+}
+
+// Out-of-office ranges of the users overlapping the time range
+func (r *AvailabilityPostgres) GetOutOfOffice(userIds []int, startsAt, endsAt time.Time) ([]domain.OutOfOffice, error) {
+	var result []domain.OutOfOffice
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE user_id = ANY($1) AND starts_at < $3 AND ends_at > $2 ORDER BY starts_at, id",
+		outOfOfficeColumns, OUT_OF_OFFICE_TABLE,
+	)
+	err := r.db.Select(&result, query, pq.Array(userIds), startsAt, endsAt)
+
+	return result, err
+}
+
+func (r *AvailabilityPostgres) CreateOutOfOffice(userId int, request domain.SaveOutOfOfficeRequest) (int, error) {
+	var result int
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (user_id, starts_at, ends_at, reason) VALUES ($1, $2, $3, $4) RETURNING id",
+		OUT_OF_OFFICE_TABLE,
+	)
+	err := r.db.QueryRow(query, userId, request.StartsAt, request.EndsAt, request.Reason).Scan(&result)
+
+	return result, err
+}
+
+func (r *AvailabilityPostgres) DeleteOutOfOffice(userId, outOfOfficeId int) (bool, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id=$1 AND id=$2", OUT_OF_OFFICE_TABLE)
+	res, err := r.db.Exec(query, userId, outOfOfficeId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+const bookingPageColumns = `id, organization_id, host_id, calendar_id, slug, title, description, duration_minutes,
+	buffer_before_minutes, buffer_after_minutes, max_per_day, timezone_id, is_active, created_at`
+
+const bookingColumns = "id, booking_page_id, event_id, guest_name, guest_email, starts_at, ends_at, created_at"
+
+type BookingPagesPostgres struct {
+	db *sqlx.DB
+}
+
+func NewBookingPagesPostgres(db *sqlx.DB) *BookingPagesPostgres {
+	return &BookingPagesPostgres{db: db}
+}
+
+func (r *BookingPagesPostgres) GetByHost(organizationId, hostId int) ([]domain.BookingPage, error) {
+	var result []domain.BookingPage
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE organization_id=$1 AND host_id=$2 ORDER BY id",
+		bookingPageColumns, BOOKING_PAGES_TABLE,
+	)
+	if err := r.db.Select(&result, query, organizationId, hostId); err != nil {
+		return nil, err
+	}
+
+	return result, r.loadWindows(result)
+}
+
+func (r *BookingPagesPostgres) GetById(organizationId, hostId, pageId int) (domain.BookingPage, error) {
+	var result domain.BookingPage
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE organization_id=$1 AND host_id=$2 AND id=$3",
+		bookingPageColumns, BOOKING_PAGES_TABLE,
+	)
+	if err := r.db.Get(&result, query, organizationId, hostId, pageId); err != nil {
+		return result, err
+	}
+
+	pages := []domain.BookingPage{result}
+	err := r.loadWindows(pages)
+
+	return pages[0], err
+}
+
+// Only active pages are available for guests
+func (r *BookingPagesPostgres) GetBySlug(slug string) (domain.BookingPage, error) {
+	var result domain.BookingPage
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE slug=$1 AND is_active", bookingPageColumns, BOOKING_PAGES_TABLE)
+	if err := r.db.Get(&result, query, slug); err != nil {
+		return result, err
+	}
+
+	pages := []domain.BookingPage{result}
+	err := r.loadWindows(pages)
+
+	return pages[0], err
+}
+
+// Page and its windows are saved in one transaction
+func (r *BookingPagesPostgres) Create(page domain.BookingPage) (int, error) {
+	var result int
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (organization_id, host_id, calendar_id, slug, title, description, duration_minutes,
+			buffer_before_minutes, buffer_after_minutes, max_per_day, timezone_id, is_active)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		 RETURNING id`,
+		BOOKING_PAGES_TABLE,
+	)
+	row := tx.QueryRow(
+		query,
+		page.OrganizationId, page.HostId, page.CalendarId, page.Slug, page.Title, page.Description, page.DurationMinutes,
+		page.BufferBeforeMinutes, page.BufferAfterMinutes, page.MaxPerDay, page.TimezoneId, page.IsActive,
+	)
+	if err := row.Scan(&result); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := saveWindows(tx, result, page.Windows); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return result, tx.Commit()
+}
+
+// Windows are replaced, slug is kept
+func (r *BookingPagesPostgres) Update(page domain.BookingPage) (domain.BookingPage, error) {
+	var result domain.BookingPage
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return result, err
+	}
+
+	query := fmt.Sprintf(
+		`UPDATE %s SET calendar_id=$1, title=$2, description=$3, duration_minutes=$4, buffer_before_minutes=$5,
+			buffer_after_minutes=$6, max_per_day=$7, timezone_id=$8, is_active=$9
+		 WHERE organization_id=$10 AND host_id=$11 AND id=$12
+		 RETURNING %s`,
+		BOOKING_PAGES_TABLE, bookingPageColumns,
+	)
+	err = tx.Get(
+		&result,
+		query,
+		page.CalendarId, page.Title, page.Description, page.DurationMinutes, page.BufferBeforeMinutes,
+		page.BufferAfterMinutes, page.MaxPerDay, page.TimezoneId, page.IsActive, page.OrganizationId, page.HostId, page.Id,
+	)
+	if err != nil {
+		tx.Rollback()
+		return result, err
+	}
+
+	query = fmt.Sprintf("DELETE FROM %s WHERE booking_page_id=$1", BOOKING_PAGE_WINDOWS_TABLE)
+	if _, err := tx.Exec(query, page.Id); err != nil {
+		tx.Rollback()
+		return result, err
+	}
+
+	if err := saveWindows(tx, page.Id, page.Windows); err != nil {
+		tx.Rollback()
+		return result, err
+	}
+
+	result.Windows = page.Windows
+
+	return result, tx.Commit()
+}
+
+func (r *BookingPagesPostgres) Delete(organizationId, hostId, pageId int) (bool, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE organization_id=$1 AND host_id=$2 AND id=$3", BOOKING_PAGES_TABLE)
+	res, err := r.db.Exec(query, organizationId, hostId, pageId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
+
+// Bookings of the page starting in the time range, bookings with cancelled events are skipped
+func (r *BookingPagesPostgres) GetBookings(pageId int, from, to time.Time) ([]domain.Booking, error) {
+	var result []domain.Booking
+
+	query := fmt.Sprintf(
+		`SELECT %s FROM %s b WHERE b.booking_page_id=$1 AND b.starts_at >= $2 AND b.starts_at < $3
+			AND EXISTS (SELECT 1 FROM %s e WHERE e.id=b.event_id AND e.status <> $4)
+		 ORDER BY b.starts_at`,
+		bookingColumns, BOOKINGS_TABLE, EVENTS_TABLE,
+	)
+	err := r.db.Select(&result, query, pageId, from, to, domain.EVENT_STATUS_CANCELLED)
+
+	return result, err
+}
+
+// Bookings of a host are serialized with advisory lock, so the host is checked to be free and the event is created
+// atomically. Busy range includes buffers of the page, day range is used for max bookings per day.
+// False is returned when the slot is already taken
+func (r *BookingPagesPostgres) Book(
+	page domain.BookingPage, event domain.SaveEventRequest, booking domain.Booking, busyFrom, busyTo, dayFrom, dayTo time.Time,
+) (domain.Booking, bool, error) {
+	var result domain.Booking
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return result, false, err
+	}
+
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext($1), $2)", BOOKINGS_TABLE, page.HostId); err != nil {
+		tx.Rollback()
+		return result, false, err
+	}
+
+	var taken bool
+
+	query := fmt.Sprintf(
+		`SELECT EXISTS (
+			SELECT 1 FROM (%[2]s) h WHERE h.starts_at < $3 AND h.ends_at > $2
+		 ) OR EXISTS (
+			SELECT 1 FROM %[3]s WHERE user_id=$1 AND starts_at < $3 AND ends_at > $2
+		 ) OR ($4 > 0 AND (
+			SELECT count(*) FROM %[4]s b JOIN %[1]s e ON e.id=b.event_id
+			WHERE b.booking_page_id=$5 AND b.starts_at >= $6 AND b.starts_at < $7 AND e.status <> $8
+		 ) >= $4)`,
+		EVENTS_TABLE, userBusyEvents("$1"), OUT_OF_OFFICE_TABLE, BOOKINGS_TABLE,
+	)
+	err = tx.Get(
+		&taken,
+		query,
+		page.HostId, busyFrom, busyTo, page.MaxPerDay, page.Id, dayFrom, dayTo, domain.EVENT_STATUS_CANCELLED,
+	)
+	if err != nil || taken {
+		tx.Rollback()
+		return result, false, err
+	}
+
+	eventId, err := insertEvent(tx, page.OrganizationId, page.HostId, event)
+	if err != nil {
+		tx.Rollback()
+		return result, false, err
+	}
+
+	query = fmt.Sprintf(
+		`INSERT INTO %s (booking_page_id, event_id, guest_name, guest_email, starts_at, ends_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING %s`,
+		BOOKINGS_TABLE, bookingColumns,
+	)
+	err = tx.Get(
+		&result,
+		query,
+		page.Id, eventId, booking.GuestName, booking.GuestEmail, booking.StartsAt, booking.EndsAt,
+	)
+	if err != nil {
+		tx.Rollback()
+		return result, false, err
+	}
+
+	return result, true, tx.Commit()
+}
+
+func (r *BookingPagesPostgres) loadWindows(pages []domain.BookingPage) error {
+	if len(pages) == 0 {
+		return nil
+	}
+
+	pageIds := make([]int, 0, len(pages))
+	for _, page := range pages {
+		pageIds = append(pageIds, page.Id)
+	}
+
+	var windows []domain.BookingWindow
+
+	query := fmt.Sprintf(
+		`SELECT booking_page_id, weekday, to_char(start_time, 'HH24:MI') AS start_time,
+			to_char(end_time, 'HH24:MI') AS end_time
+		 FROM %s WHERE booking_page_id = ANY($1) ORDER BY booking_page_id, weekday, start_time`,
+		BOOKING_PAGE_WINDOWS_TABLE,
+	)
+	if err := r.db.Select(&windows, query, pq.Array(pageIds)); err != nil {
+		return err
+	}
+
+	for i := range pages {
+		pages[i].Windows = []domain.BookingWindow{}
+		for _, window := range windows {
+			if window.BookingPageId == pages[i].Id {
+				pages[i].Windows = append(pages[i].Windows, window)
+			}
+		}
+	}
+
+	return nil
+}
+
+func saveWindows(tx *sqlx.Tx, pageId int, windows []domain.BookingWindow) error {
+	query := fmt.Sprintf(
+		"INSERT INTO %s (booking_page_id, weekday, start_time, end_time) VALUES ($1, $2, $3, $4)",
+		BOOKING_PAGE_WINDOWS_TABLE,
+	)
+	for _, window := range windows {
+		if _, err := tx.Exec(query, pageId, window.Weekday, window.Start, window.End); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
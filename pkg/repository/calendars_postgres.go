@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+const calendarColumns = "id, organization_id, owner_id, name, color, timezone_id, is_default, created_at"
+
+type CalendarsPostgres struct {
+	db *sqlx.DB
+}
+
+func NewCalendarsPostgres(db *sqlx.DB) *CalendarsPostgres {
+	return &CalendarsPostgres{db: db}
+}
+
+func (r *CalendarsPostgres) GetByOwner(organizationId, ownerId int) ([]domain.Calendar, error) {
+	var result []domain.Calendar
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE organization_id=$1 AND owner_id=$2 ORDER BY is_default DESC, id",
+		calendarColumns, CALENDARS_TABLE,
+	)
+	err := r.db.Select(&result, query, organizationId, ownerId)
+
+	return result, err
+}
+
+func (r *CalendarsPostgres) GetById(organizationId, ownerId, calendarId int) (domain.Calendar, error) {
+	var result domain.Calendar
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE organization_id=$1 AND owner_id=$2 AND id=$3",
+		calendarColumns, CALENDARS_TABLE,
+	)
+	err := r.db.Get(&result, query, organizationId, ownerId, calendarId)
+
+	return result, err
+}
+
+// Default calendar is created on first use, e.g. for users who joined organization after calendars were introduced
+func (r *CalendarsPostgres) GetDefault(organizationId, ownerId int) (domain.Calendar, error) {
+	var result domain.Calendar
+
+	query := fmt.Sprintf(
+		`WITH created AS (
+			INSERT INTO %[1]s (organization_id, owner_id, name, color, is_default) VALUES ($1, $2, $3, $4, true)
+			ON CONFLICT (organization_id, owner_id) WHERE is_default DO NOTHING
+			RETURNING %[2]s
+		 )
+		 SELECT %[2]s FROM created
+		 UNION ALL
+		 SELECT %[2]s FROM %[1]s WHERE organization_id=$1 AND owner_id=$2 AND is_default
+		 LIMIT 1`,
+		CALENDARS_TABLE, calendarColumns,
+	)
+	err := r.db.Get(&result, query, organizationId, ownerId, domain.DEFAULT_CALENDAR_NAME, domain.DEFAULT_CALENDAR_COLOR)
+
+	return result, err
+}
+
+func (r *CalendarsPostgres) Create(calendar domain.Calendar) (int, error) {
+	var result int
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (organization_id, owner_id, name, color, timezone_id) VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id`,
+		CALENDARS_TABLE,
+	)
+	err := r.db.QueryRow(
+		query,
+		calendar.OrganizationId, calendar.OwnerId, calendar.Name, calendar.Color, calendar.TimezoneId,
+	).Scan(&result)
+
+	return result, err
+}
+
+func (r *CalendarsPostgres) Update(calendar domain.Calendar) (domain.Calendar, error) {
+	var result domain.Calendar
+
+	query := fmt.Sprintf(
+		`UPDATE %s SET name=$1, color=$2, timezone_id=$3
+		 WHERE organization_id=$4 AND owner_id=$5 AND id=$6
+		 RETURNING %s`,
+		CALENDARS_TABLE, calendarColumns,
+	)
+	err := r.db.Get(
+		&result,
+		query,
+		calendar.Name, calendar.Color, calendar.TimezoneId, calendar.OrganizationId, calendar.OwnerId, calendar.Id,
+	)
+
+	return result, err
+}
+
+// Events of the calendar are deleted with it, default calendar can not be deleted
+func (r *CalendarsPostgres) Delete(organizationId, ownerId, calendarId int) (bool, error) {
+	query := fmt.Sprintf(
+		"DELETE FROM %s WHERE organization_id=$1 AND owner_id=$2 AND id=$3 AND NOT is_default",
+		CALENDARS_TABLE,
+	)
+	res, err := r.db.Exec(query, organizationId, ownerId, calendarId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
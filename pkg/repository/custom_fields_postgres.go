@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+var ErrDuplicateCustomField = errors.New("Custom field with this key already exists")
+
+const customFieldColumns = "id, organization_id, key, label, type, required, options, created_at"
+
+type CustomFieldsPostgres struct {
+	db *sqlx.DB
+}
+
+func NewCustomFieldsPostgres(db *sqlx.DB) *CustomFieldsPostgres {
+	return &CustomFieldsPostgres{db: db}
+}
+
+func (r *CustomFieldsPostgres) GetAll(organizationId int) ([]domain.CustomField, error) {
+	var result []domain.CustomField
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE organization_id=$1 ORDER BY id", customFieldColumns, CUSTOM_FIELDS_TABLE)
+	err := r.db.Select(&result, query, organizationId)
+
+	return result, err
+}
+
+func (r *CustomFieldsPostgres) Create(field domain.CustomField) (int, error) {
+	var result int
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (organization_id, key, label, type, required, options) VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING id`,
+		CUSTOM_FIELDS_TABLE,
+	)
+	err := r.db.QueryRow(
+		query, field.OrganizationId, field.Key, field.Label, field.Type, field.Required, field.Options,
+	).Scan(&result)
+
+	return result, customFieldError(err)
+}
+
+func (r *CustomFieldsPostgres) Update(field domain.CustomField) (domain.CustomField, error) {
+	var result domain.CustomField
+
+	query := fmt.Sprintf(
+		`UPDATE %s SET label=$1, required=$2, options=$3 WHERE organization_id=$4 AND id=$5
+		 RETURNING %s`,
+		CUSTOM_FIELDS_TABLE, customFieldColumns,
+	)
+	err := r.db.Get(&result, query, field.Label, field.Required, field.Options, field.OrganizationId, field.Id)
+
+	return result, err
+}
+
+// Values of the field are removed from events of the organization with the field
+func (r *CustomFieldsPostgres) Delete(organizationId, fieldId int) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+
+	var key string
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE organization_id=$1 AND id=$2 RETURNING key", CUSTOM_FIELDS_TABLE)
+	err = tx.Get(&key, query, organizationId, fieldId)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return false, nil
+	}
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	query = fmt.Sprintf(
+		"UPDATE %s SET custom_fields = custom_fields - $1::text WHERE organization_id=$2 AND custom_fields ? $1::text",
+		EVENTS_TABLE,
+	)
+	if _, err := tx.Exec(query, key, organizationId); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func customFieldError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == UNIQUE_VIOLATION {
+		return ErrDuplicateCustomField
+	}
+
+	return err
+}
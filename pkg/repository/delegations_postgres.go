@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+const delegationColumns = "id, organization_id, principal_id, delegate_id, access, created_at"
+
+type DelegationsPostgres struct {
+	db *sqlx.DB
+}
+
+func NewDelegationsPostgres(db *sqlx.DB) *DelegationsPostgres {
+	return &DelegationsPostgres{db: db}
+}
+
+func (r *DelegationsPostgres) Get(organizationId, principalId, delegateId int) (domain.Delegation, error) {
+	var result domain.Delegation
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE organization_id=$1 AND principal_id=$2 AND delegate_id=$3",
+		delegationColumns, CALENDAR_DELEGATIONS_TABLE,
+	)
+	err := r.db.Get(&result, query, organizationId, principalId, delegateId)
+
+	return result, err
+}
+
+// Delegations given by the user and given to the user
+func (r *DelegationsPostgres) GetByUser(organizationId, userId int) ([]domain.Delegation, error) {
+	var result []domain.Delegation
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE organization_id=$1 AND (principal_id=$2 OR delegate_id=$2) ORDER BY id",
+		delegationColumns, CALENDAR_DELEGATIONS_TABLE,
+	)
+	err := r.db.Select(&result, query, organizationId, userId)
+
+	return result, err
+}
+
+func (r *DelegationsPostgres) Save(delegation domain.Delegation) (domain.Delegation, error) {
+	var result domain.Delegation
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (organization_id, principal_id, delegate_id, access) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (organization_id, principal_id, delegate_id) DO UPDATE SET access=EXCLUDED.access
+		 RETURNING %s`,
+		CALENDAR_DELEGATIONS_TABLE, delegationColumns,
+	)
+	err := r.db.Get(
+		&result,
+		query,
+		delegation.OrganizationId, delegation.PrincipalId, delegation.DelegateId, delegation.Access,
+	)
+
+	return result, err
+}
+
+// Delegation can be revoked by principal or declined by delegate
+func (r *DelegationsPostgres) Delete(organizationId, userId, delegationId int) (bool, error) {
+	query := fmt.Sprintf(
+		"DELETE FROM %s WHERE organization_id=$1 AND id=$2 AND (principal_id=$3 OR delegate_id=$3)",
+		CALENDAR_DELEGATIONS_TABLE,
+	)
+	res, err := r.db.Exec(query, organizationId, delegationId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+var digestColumns = fmt.Sprintf(
+	`u.id AS user_id, COALESCE(d.frequency, '%s') AS frequency, to_char(COALESCE(d.send_time, '08:00'), 'HH24:MI') AS send_time,
+	COALESCE(d.weekday, 1) AS weekday, u.timezone_id, d.next_send_at, d.last_sent_at, u.email`,
+	domain.DIGEST_FREQUENCY_OFF,
+)
+
+type DigestsPostgres struct {
+	db *sqlx.DB
+}
+
+func NewDigestsPostgres(db *sqlx.DB) *DigestsPostgres {
+	return &DigestsPostgres{db: db}
+}
+
+// Preferences of the user, digest is off when they are not saved
+func (r *DigestsPostgres) Get(userId int) (domain.DigestPreferences, error) {
+	var result domain.DigestPreferences
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s u LEFT JOIN %s d ON d.user_id = u.id WHERE u.id=$1",
+		digestColumns, USERS_TABLE, DIGEST_PREFERENCES_TABLE,
+	)
+	err := r.db.Get(&result, query, userId)
+
+	return result, err
+}
+
+// Preferences are removed when digest is turned off
+func (r *DigestsPostgres) Save(digest domain.DigestPreferences) error {
+	if digest.Frequency == domain.DIGEST_FREQUENCY_OFF {
+		query := fmt.Sprintf("DELETE FROM %s WHERE user_id=$1", DIGEST_PREFERENCES_TABLE)
+		_, err := r.db.Exec(query, digest.UserId)
+
+		return err
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (user_id, frequency, send_time, weekday, next_send_at) VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (user_id) DO UPDATE
+			SET frequency=EXCLUDED.frequency, send_time=EXCLUDED.send_time, weekday=EXCLUDED.weekday,
+				next_send_at=EXCLUDED.next_send_at`,
+		DIGEST_PREFERENCES_TABLE,
+	)
+	_, err := r.db.Exec(query, digest.UserId, digest.Frequency, digest.SendTime, digest.Weekday, digest.NextSendAt)
+
+	return err
+}
+
+// Claim the earliest due digest and deliver it while its row is locked, concurrent servers skip locked digests.
+// Send times returned by delivery are saved in the same transaction. False is returned when there are no due digests
+func (r *DigestsPostgres) DeliverDue(
+	deliver func(digest domain.DigestPreferences) domain.DigestPreferences,
+) (bool, error) {
+	var digest domain.DigestPreferences
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+
+	query := fmt.Sprintf(
+		`SELECT %s FROM %s d JOIN %s u ON u.id = d.user_id
+		 WHERE d.next_send_at <= now()
+		 ORDER BY d.next_send_at
+		 LIMIT 1
+		 FOR UPDATE OF d SKIP LOCKED`,
+		digestColumns, DIGEST_PREFERENCES_TABLE, USERS_TABLE,
+	)
+	if err := tx.Get(&digest, query); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	digest = deliver(digest)
+
+	query = fmt.Sprintf("UPDATE %s SET next_send_at=$1, last_sent_at=$2 WHERE user_id=$3", DIGEST_PREFERENCES_TABLE)
+	if _, err := tx.Exec(query, digest.NextSendAt, digest.LastSentAt, digest.UserId); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+)
+
+// Subquery of users whose time is taken by the event: organizer unless the event is cancelled,
+// attendees when the event is published
+func eventParticipants(eventRef string) string {
+	return fmt.Sprintf(
+		`SELECT %[1]s.organizerId AS user_id WHERE %[1]s.status <> '%[2]s'
+		 UNION
+		 SELECT a.user_id FROM %[3]s a WHERE a.event_id=%[1]s.id AND %[1]s.status = '%[4]s'`,
+		eventRef, domain.EVENT_STATUS_CANCELLED, EVENT_ATTENDEES_TABLE, domain.EVENT_STATUS_PUBLISHED,
+	)
+}
+
+// Time of events taking time of the user in all organizations the user belongs to, the user is given by placeholder
+func userBusyEvents(userParam string) string {
+	return fmt.Sprintf(
+		`SELECT e.starts_at, e.ends_at
+		 FROM %[1]s e JOIN LATERAL (%[2]s) p ON p.user_id = %[3]s
+		 JOIN %[4]s m ON m.organization_id = e.organization_id AND m.user_id = p.user_id`,
+		EVENTS_TABLE, eventParticipants("e"), userParam, ORGANIZATION_MEMBERS_TABLE,
+	)
+}
+
+// Busy time of the user in all organizations overlapping the time range, details of events are not loaded
+func (r *EventsPostgres) GetBusy(userId int, startsAt, endsAt time.Time) ([]domain.BusyInterval, error) {
+	var result []domain.BusyInterval
+
+	query := fmt.Sprintf(
+		"SELECT b.starts_at, b.ends_at FROM (%s) b WHERE b.starts_at < $3 AND b.ends_at > $2 ORDER BY b.starts_at",
+		userBusyEvents("$1"),
+	)
+	err := r.db.Select(&result, query, userId, startsAt, endsAt)
+
+	return result, err
+}
+
+// Events of the users overlapping the time range, the saved event itself is excluded
+func (r *EventsPostgres) GetOverlapping(
+	organizationId int, userIds []int, startsAt, endsAt time.Time, excludeEventId int,
+) ([]domain.EventConflict, error) {
+	var result []domain.EventConflict
+
+	query := fmt.Sprintf(
+		`SELECT p.user_id, e.id AS event_id, e.starts_at, e.ends_at
+		 FROM %[1]s e JOIN LATERAL (%[2]s) p ON p.user_id = ANY($2)
+		 WHERE e.organization_id=$1 AND e.starts_at < $4 AND e.ends_at > $3 AND e.id <> $5
+		 ORDER BY e.starts_at, e.id, p.user_id`,
+		EVENTS_TABLE, eventParticipants("e"),
+	)
+	err := r.db.Select(&result, query, organizationId, intArray(userIds), startsAt, endsAt, excludeEventId)
+
+	return result, err
+}
+
+// Pairs of upcoming events of the user which overlap
+func (r *EventsPostgres) GetOverlaps(organizationId, userId int) ([]domain.EventOverlap, error) {
+	var result []domain.EventOverlap
+
+	query := fmt.Sprintf(
+		`WITH busy AS (
+			SELECT e.id, e.starts_at, e.ends_at
+			FROM %[1]s e JOIN LATERAL (%[2]s) p ON p.user_id = $2
+			WHERE e.organization_id=$1 AND e.ends_at > now()
+		 )
+		 SELECT a.id AS event_id, b.id AS conflicting_event_id,
+			GREATEST(a.starts_at, b.starts_at) AS starts_at, LEAST(a.ends_at, b.ends_at) AS ends_at
+		 FROM busy a JOIN busy b ON a.id < b.id AND a.starts_at < b.ends_at AND b.starts_at < a.ends_at
+		 ORDER BY starts_at, a.id, b.id`,
+		EVENTS_TABLE, eventParticipants("e"),
+	)
+	err := r.db.Select(&result, query, organizationId, userId)
+
+	return result, err
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+const eventGrantColumns = "id, event_id, user_id, group_id, permission, created_at"
+
+type EventGrantsPostgres struct {
+	db *sqlx.DB
+}
+
+func NewEventGrantsPostgres(db *sqlx.DB) *EventGrantsPostgres {
+	return &EventGrantsPostgres{db: db}
+}
+
+// Subquery of the highest permission granted to the user directly, via groups or as attendee (viewer)
+func grantedPermission(eventIdRef, userIdRef string) string {
+	return fmt.Sprintf(
+		`SELECT permission FROM (
+			SELECT g.permission FROM %[1]s g
+			WHERE g.event_id=%[2]s AND (g.user_id=%[3]s OR g.group_id IN (SELECT group_id FROM %[4]s WHERE user_id=%[3]s))
+			UNION ALL
+			SELECT '%[5]s' FROM %[6]s a WHERE a.event_id=%[2]s AND a.user_id=%[3]s
+		 ) granted
+		 ORDER BY CASE permission WHEN '%[7]s' THEN 3 WHEN '%[8]s' THEN 2 ELSE 1 END DESC
+		 LIMIT 1`,
+		EVENT_GRANTS_TABLE, eventIdRef, userIdRef, GROUP_MEMBERS_TABLE,
+		domain.EVENT_PERMISSION_VIEWER, EVENT_ATTENDEES_TABLE,
+		domain.EVENT_PERMISSION_CO_ORGANIZER, domain.EVENT_PERMISSION_EDITOR,
+	)
+}
+
+func (r *EventGrantsPostgres) GetPermission(eventId, userId int) (string, error) {
+	var result []string
+
+	err := r.db.Select(&result, grantedPermission("$1", "$2"), eventId, userId)
+	if err != nil || len(result) == 0 {
+		return "", err
+	}
+
+	return result[0], nil
+}
+
+func (r *EventGrantsPostgres) GetGrants(eventId int) ([]domain.EventGrant, error) {
+	var result []domain.EventGrant
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE event_id=$1 ORDER BY id", eventGrantColumns, EVENT_GRANTS_TABLE)
+	err := r.db.Select(&result, query, eventId)
+
+	return result, err
+}
+
+// Create grant or change permission of existing grant to the same user or group
+func (r *EventGrantsPostgres) SaveGrant(grant domain.EventGrant) (domain.EventGrant, error) {
+	var result domain.EventGrant
+
+	target := "user_id"
+	if grant.GroupId != nil {
+		target = "group_id"
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (event_id, user_id, group_id, permission) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (event_id, %s) WHERE %s IS NOT NULL DO UPDATE SET permission=EXCLUDED.permission
+		 RETURNING %s`,
+		EVENT_GRANTS_TABLE, target, target, eventGrantColumns,
+	)
+	err := r.db.Get(&result, query, grant.EventId, grant.UserId, grant.GroupId, grant.Permission)
+
+	return result, err
+}
+
+func (r *EventGrantsPostgres) DeleteGrant(eventId, grantId int) (bool, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE event_id=$1 AND id=$2", EVENT_GRANTS_TABLE)
+	res, err := r.db.Exec(query, eventId, grantId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
@@ -2,11 +2,26 @@ package repository
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/salesforceanton/events-api/domain"
 )
 
+const eventColumns = `id, title, timezoneId, startDatetime, endDatetime, organizerId, organization_id, description, calendar_id,
+	visibility, COALESCE(slug, '') AS slug, status, COALESCE(cancellation_reason, '') AS cancellation_reason, address,
+	latitude, longitude, online_url, custom_fields, sequence`
+
+// Great-circle distance in kilometers between event and the point ($3, $4) by haversine formula
+const eventDistanceKm = `2 * 6371 * asin(LEAST(1, sqrt(
+	power(sin(radians(latitude - $3) / 2), 2) +
+	cos(radians($3)) * cos(radians(latitude)) * power(sin(radians(longitude - $4) / 2), 2)
+)))`
+
+const publicEventColumns = `slug, title, timezoneId, startDatetime, endDatetime, COALESCE(description, '') AS description,
+	status, COALESCE(cancellation_reason, '') AS cancellation_reason`
+
 type EventsPostgres struct {
 	db *sqlx.DB
 }
@@ -15,70 +30,371 @@ func NewEventsPostgres(db *sqlx.DB) *EventsPostgres {
 	return &EventsPostgres{db: db}
 }
 
-func (r *EventsPostgres) GetAll(userId int) ([]domain.Event, error) {
+func (r *EventsPostgres) GetSystemWide() ([]domain.Event, error) {
+	var result []domain.Event
+
+	query := fmt.Sprintf("SELECT %s FROM %s ORDER BY id", eventColumns, EVENTS_TABLE)
+	err := r.db.Select(&result, query)
+
+	return result, err
+}
+
+// Events which user organizes or which are shared with the user, with effective permission.
+// Drafts are not shown to viewers. Events are filtered by calendars, by any or all of the tags and by custom fields
+func (r *EventsPostgres) GetAccessible(organizationId, userId int, filter domain.EventsFilter) ([]domain.Event, error) {
 	var result []domain.Event
 
 	query := fmt.Sprintf(
-		"SELECT id, title, timezoneId, startDatetime, organizerId, description FROM %s WHERE organizerId=$1",
-		EVENTS_TABLE,
+		`SELECT * FROM (
+			SELECT %[1]s, CASE WHEN organizerId=$2 THEN '%[2]s' ELSE (%[3]s) END AS permission
+			FROM %[4]s WHERE organization_id=$1 AND ($3::int[] IS NULL OR calendar_id = ANY($3))
+				AND ($6::int[] IS NULL OR (
+					SELECT count(*) FROM %[5]s et WHERE et.event_id = %[4]s.id AND et.tag_id = ANY($6)
+				) >= CASE WHEN $7 = '%[6]s' THEN cardinality($6) ELSE 1 END)
+				AND ($8::jsonb IS NULL OR custom_fields @> $8)
+		 ) accessible
+		 WHERE permission IS NOT NULL AND (status <> $4 OR permission <> $5) ORDER BY id`,
+		eventColumns, domain.EVENT_PERMISSION_ORGANIZER, grantedPermission(EVENTS_TABLE+".id", "$2"), EVENTS_TABLE,
+		EVENT_TAGS_TABLE, domain.TAG_MATCH_ALL,
+	)
+	err := r.db.Select(
+		&result,
+		query,
+		organizationId, userId, intArray(filter.CalendarIds), domain.EVENT_STATUS_DRAFT, domain.EVENT_PERMISSION_VIEWER,
+		intArray(filter.TagIds), filter.TagMatch, jsonObject(filter.CustomValues),
 	)
-	err := r.db.Select(&result, query, userId)
 
 	return result, err
 }
 
-func (r *EventsPostgres) GetById(userId, eventId int) (domain.Event, error) {
-	var result domain.Event
+// Upcoming accessible events within radius of the point, nearest first.
+// Bounding box uses coordinates index, exact distance is checked for events inside it only
+func (r *EventsPostgres) GetNearby(
+	organizationId, userId int, filter domain.NearbyFilter, box domain.GeoBox,
+) ([]domain.Event, error) {
+	var result []domain.Event
 
 	query := fmt.Sprintf(
-		"SELECT id, title, timezoneId, startDatetime, description, organizerId FROM %s WHERE organizerId=$1 AND id=$2",
+		`SELECT * FROM (
+			SELECT %[1]s, CASE WHEN organizerId=$2 THEN '%[2]s' ELSE (%[3]s) END AS permission,
+				%[4]s AS distance_km
+			FROM %[5]s
+			WHERE organization_id=$1 AND latitude BETWEEN $5 AND $6 AND longitude BETWEEN $7 AND $8
+				AND status <> $10 AND ends_at > now()
+		 ) nearby
+		 WHERE permission IS NOT NULL AND (status <> $11 OR permission <> $12) AND distance_km <= $9
+		 ORDER BY distance_km, id`,
+		eventColumns, domain.EVENT_PERMISSION_ORGANIZER, grantedPermission(EVENTS_TABLE+".id", "$2"), eventDistanceKm,
 		EVENTS_TABLE,
 	)
-	err := r.db.Get(&result, query, userId, eventId)
+	err := r.db.Select(
+		&result,
+		query,
+		organizationId, userId, *filter.Lat, *filter.Lng, box.MinLat, box.MaxLat, box.MinLng, box.MaxLng, filter.Radius,
+		domain.EVENT_STATUS_CANCELLED, domain.EVENT_STATUS_DRAFT, domain.EVENT_PERMISSION_VIEWER,
+	)
 
 	return result, err
 }
 
-func (r *EventsPostgres) Create(userId int, request domain.SaveEventRequest) (int, error) {
+func (r *EventsPostgres) GetById(organizationId, eventId int) (domain.Event, error) {
+	var result domain.Event
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE organization_id=$1 AND id=$2", eventColumns, EVENTS_TABLE)
+	err := r.db.Get(&result, query, organizationId, eventId)
+
+	return result, err
+}
+
+// Event, its attendees, reservations, tags and reminders are saved in one transaction
+func (r *EventsPostgres) Create(organizationId, organizerId int, request domain.SaveEventRequest) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := insertEvent(tx, organizationId, organizerId, request)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return result, tx.Commit()
+}
+
+func insertEvent(tx *sqlx.Tx, organizationId, organizerId int, request domain.SaveEventRequest) (int, error) {
 	var result int
 
 	query := fmt.Sprintf(
-		`INSERT INTO %s (title, timezoneId, startDatetime, description, organizerId) 
-		VALUES ($1, $2, $3, $4, $5)
+		`INSERT INTO %s (title, timezoneId, startDatetime, endDatetime, starts_at, ends_at, description, organizerId,
+			organization_id, calendar_id, visibility, slug, status, address, latitude, longitude, online_url, custom_fields) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''), $13, $14, $15, $16, $17, $18)
 		RETURNING id`,
 		EVENTS_TABLE,
 	)
-	row := r.db.QueryRow(query, request.Title, request.TimezoneId, request.StartDatetime, request.Description, userId)
+	row := tx.QueryRow(
+		query,
+		request.Title, request.TimezoneId, request.StartDatetime, request.EndDatetime, request.StartsAt, request.EndsAt,
+		request.Description, organizerId, organizationId, request.CalendarId, request.Visibility, request.Slug,
+		request.Status, request.Address, request.Latitude, request.Longitude, request.OnlineUrl, request.CustomFields,
+	)
 	if err := row.Scan(&result); err != nil {
 		return 0, err
 	}
 
+	if err := saveAttendees(tx, result, request.AttendeeIds); err != nil {
+		return 0, err
+	}
+
+	if err := saveReservations(tx, result, request.ResourceIds, request.StartsAt, request.EndsAt, false); err != nil {
+		return 0, err
+	}
+
+	if err := saveEventTags(tx, result, request.TagIds); err != nil {
+		return 0, err
+	}
+
+	if err := saveEventReminders(tx, result, request.Reminders); err != nil {
+		return 0, err
+	}
+
 	return result, nil
 }
 
-func (r *EventsPostgres) Update(userId, eventId int, request domain.SaveEventRequest) (domain.Event, error) {
+// Attendees, reservations, tags and reminders are replaced when they are set in the request,
+// kept reservations are moved with the event. Sequence of the event is increased for calendar messages
+func (r *EventsPostgres) Update(organizationId, eventId int, request domain.SaveEventRequest) (domain.Event, error) {
 	var result domain.Event
 
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return result, err
+	}
+
 	query := fmt.Sprintf(
-		`UPDATE %s SET title='%s', timezoneid='%s', startdatetime='%s', description='%s' 
-		 WHERE id=$1 AND organizerid=$2
-		 RETURNING id, title, description, organizerid, startdatetime, timezoneid`,
-		EVENTS_TABLE, request.Title, request.TimezoneId, request.StartDatetime, request.Description,
+		`UPDATE %s SET title=$1, timezoneid=$2, startdatetime=$3, enddatetime=$4, starts_at=$5, ends_at=$6,
+			description=$7, calendar_id=$8, visibility=$9, slug=NULLIF($10, ''), address=$11, latitude=$12,
+			longitude=$13, online_url=$14, custom_fields=$15, sequence=sequence+1
+		 WHERE organization_id=$16 AND id=$17
+		 RETURNING %s`,
+		EVENTS_TABLE, eventColumns,
 	)
-	err := r.db.Get(
+	err = tx.Get(
 		&result,
 		query,
-		eventId, userId,
+		request.Title, request.TimezoneId, request.StartDatetime, request.EndDatetime, request.StartsAt, request.EndsAt,
+		request.Description, request.CalendarId, request.Visibility, request.Slug, request.Address, request.Latitude,
+		request.Longitude, request.OnlineUrl, request.CustomFields, organizationId, eventId,
+	)
+	if err != nil {
+		tx.Rollback()
+		return result, err
+	}
+
+	if request.AttendeeIds != nil {
+		query := fmt.Sprintf("DELETE FROM %s WHERE event_id=$1", EVENT_ATTENDEES_TABLE)
+		if _, err := tx.Exec(query, eventId); err != nil {
+			tx.Rollback()
+			return result, err
+		}
+
+		if err := saveAttendees(tx, eventId, request.AttendeeIds); err != nil {
+			tx.Rollback()
+			return result, err
+		}
+	}
+
+	if err := updateReservations(tx, eventId, request); err != nil {
+		tx.Rollback()
+		return result, err
+	}
+
+	if request.TagIds != nil {
+		query := fmt.Sprintf("DELETE FROM %s WHERE event_id=$1", EVENT_TAGS_TABLE)
+		if _, err := tx.Exec(query, eventId); err != nil {
+			tx.Rollback()
+			return result, err
+		}
+
+		if err := saveEventTags(tx, eventId, request.TagIds); err != nil {
+			tx.Rollback()
+			return result, err
+		}
+	}
+
+	if request.Reminders != nil {
+		query := fmt.Sprintf("DELETE FROM %s WHERE event_id=$1", EVENT_REMINDERS_TABLE)
+		if _, err := tx.Exec(query, eventId); err != nil {
+			tx.Rollback()
+			return result, err
+		}
+
+		if err := saveEventReminders(tx, eventId, request.Reminders); err != nil {
+			tx.Rollback()
+			return result, err
+		}
+	}
+
+	return result, tx.Commit()
+}
+
+func saveAttendees(tx *sqlx.Tx, eventId int, userIds []int) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (event_id, user_id) SELECT $1, unnest($2::int[]) ON CONFLICT DO NOTHING",
+		EVENT_ATTENDEES_TABLE,
+	)
+	_, err := tx.Exec(query, eventId, pq.Array(userIds))
+
+	return err
+}
+
+func updateReservations(tx *sqlx.Tx, eventId int, request domain.SaveEventRequest) error {
+	if request.ResourceIds == nil {
+		query := fmt.Sprintf("UPDATE %s SET starts_at=$1, ends_at=$2 WHERE event_id=$3", EVENT_RESOURCES_TABLE)
+		_, err := tx.Exec(query, request.StartsAt, request.EndsAt, eventId)
+
+		return reservationError(err)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE event_id=$1", EVENT_RESOURCES_TABLE)
+	if _, err := tx.Exec(query, eventId); err != nil {
+		return err
+	}
+
+	return saveReservations(tx, eventId, request.ResourceIds, request.StartsAt, request.EndsAt, false)
+}
+
+func (r *EventsPostgres) GetAttendees(eventId int) ([]int, error) {
+	var result []int
+
+	query := fmt.Sprintf("SELECT user_id FROM %s WHERE event_id=$1 ORDER BY user_id", EVENT_ATTENDEES_TABLE)
+	err := r.db.Select(&result, query, eventId)
+
+	return result, err
+}
+
+func (r *EventsPostgres) GetResources(eventId int) ([]int, error) {
+	var result []int
+
+	query := fmt.Sprintf("SELECT resource_id FROM %s WHERE event_id=$1 ORDER BY resource_id", EVENT_RESOURCES_TABLE)
+	err := r.db.Select(&result, query, eventId)
+
+	return result, err
+}
+
+func (r *EventsPostgres) GetReminders(eventId int) ([]int, error) {
+	var result []int
+
+	query := fmt.Sprintf("SELECT minutes_before FROM %s WHERE event_id=$1 ORDER BY minutes_before", EVENT_REMINDERS_TABLE)
+	err := r.db.Select(&result, query, eventId)
+
+	return result, err
+}
+
+// Published events organized or attended by the user in all of the user organizations
+func (r *EventsPostgres) GetAgenda(userId int, from, to time.Time) ([]domain.AgendaItem, error) {
+	var result []domain.AgendaItem
+
+	query := fmt.Sprintf(
+		`SELECT e.id, e.title, e.starts_at, e.ends_at, e.address, e.online_url
+		 FROM %[1]s e JOIN LATERAL (%[2]s) p ON p.user_id = $1
+		 WHERE e.status = '%[3]s' AND e.starts_at < $3 AND e.ends_at > $2
+		 ORDER BY e.starts_at, e.id`,
+		EVENTS_TABLE, eventParticipants("e"), domain.EVENT_STATUS_PUBLISHED,
 	)
+	err := r.db.Select(&result, query, userId, from, to)
 
 	return result, err
 }
 
-func (r *EventsPostgres) Delete(userId, eventId int) error {
-	query := fmt.Sprintf("DELETE FROM %s WHERE organizerId=$1 AND id=$2", EVENTS_TABLE)
+// Status is changed only from the expected one, so concurrent transitions can not skip the state machine.
+// Cancelled event releases its resources, reopened event reserves them again unless they are taken meanwhile
+func (r *EventsPostgres) SetStatus(organizationId, eventId int, from, to, reason string) (domain.Event, error) {
+	var result domain.Event
 
-	r.db.Exec(query, eventId)
-	_, err := r.db.Exec(query, userId, eventId)
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return result, err
+	}
+
+	query := fmt.Sprintf(
+		`UPDATE %s SET status=$1, cancellation_reason=NULLIF($2, ''),
+			cancelled_at=CASE WHEN $1=$3 THEN now() END, sequence=sequence+1
+		 WHERE organization_id=$4 AND id=$5 AND status=$6
+		 RETURNING %s`,
+		EVENTS_TABLE, eventColumns,
+	)
+	err = tx.Get(&result, query, to, reason, domain.EVENT_STATUS_CANCELLED, organizationId, eventId, from)
+	if err != nil {
+		tx.Rollback()
+		return result, err
+	}
+
+	query = fmt.Sprintf("UPDATE %s SET released=$1 WHERE event_id=$2", EVENT_RESOURCES_TABLE)
+	if _, err := tx.Exec(query, to == domain.EVENT_STATUS_CANCELLED, eventId); err != nil {
+		tx.Rollback()
+		return result, reservationError(err)
+	}
+
+	return result, tx.Commit()
+}
+
+// Upcoming public events of all organizations
+func (r *EventsPostgres) GetPublic(limit int) ([]domain.PublicEvent, error) {
+	var result []domain.PublicEvent
+
+	query := fmt.Sprintf(
+		`SELECT %s FROM %s WHERE visibility=$1 AND status <> $2 AND ends_at > now()
+		 ORDER BY starts_at, id LIMIT $3`,
+		publicEventColumns, EVENTS_TABLE,
+	)
+	err := r.db.Select(&result, query, domain.EVENT_VISIBILITY_PUBLIC, domain.EVENT_STATUS_DRAFT, limit)
+
+	return result, err
+}
+
+// Unlisted events are available by slug only
+func (r *EventsPostgres) GetPublicBySlug(slug string) (domain.PublicEvent, error) {
+	var result domain.PublicEvent
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE slug=$1 AND visibility IN ($2, $3) AND status <> $4",
+		publicEventColumns, EVENTS_TABLE,
+	)
+	err := r.db.Get(
+		&result,
+		query,
+		slug, domain.EVENT_VISIBILITY_PUBLIC, domain.EVENT_VISIBILITY_UNLISTED, domain.EVENT_STATUS_DRAFT,
+	)
+
+	return result, err
+}
+
+func (r *EventsPostgres) Delete(organizationId, eventId int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE organization_id=$1 AND id=$2", EVENTS_TABLE)
+	_, err := r.db.Exec(query, organizationId, eventId)
 
 	return err
 }
+
+// Empty list is passed as NULL so optional filters can be written as "$n IS NULL OR ..."
+func intArray(values []int) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return pq.Array(values)
+}
+
+// Empty object is passed as NULL like empty list
+func jsonObject(values domain.CustomValues) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+type GroupsPostgres struct {
+	db *sqlx.DB
+}
+
+func NewGroupsPostgres(db *sqlx.DB) *GroupsPostgres {
+	return &GroupsPostgres{db: db}
+}
+
+func (r *GroupsPostgres) Create(organizationId int, name string) (int, error) {
+	var result int
+
+	query := fmt.Sprintf("INSERT INTO %s (organization_id, name) VALUES ($1, $2) RETURNING id", GROUPS_TABLE)
+	err := r.db.QueryRow(query, organizationId, name).Scan(&result)
+
+	return result, err
+}
+
+func (r *GroupsPostgres) GetAll(organizationId int) ([]domain.Group, error) {
+	var result []domain.Group
+
+	query := fmt.Sprintf(
+		"SELECT id, organization_id, name, created_at FROM %s WHERE organization_id=$1 ORDER BY name",
+		GROUPS_TABLE,
+	)
+	err := r.db.Select(&result, query, organizationId)
+
+	return result, err
+}
+
+func (r *GroupsPostgres) GetById(organizationId, groupId int) (domain.Group, error) {
+	var result domain.Group
+
+	query := fmt.Sprintf(
+		"SELECT id, organization_id, name, created_at FROM %s WHERE organization_id=$1 AND id=$2",
+		GROUPS_TABLE,
+	)
+	err := r.db.Get(&result, query, organizationId, groupId)
+
+	return result, err
+}
+
+func (r *GroupsPostgres) GetMembers(groupId int) ([]domain.GroupMember, error) {
+	var result []domain.GroupMember
+
+	query := fmt.Sprintf(
+		`SELECT m.user_id, u.email, u.username FROM %s m
+		 INNER JOIN %s u ON u.id = m.user_id
+		 WHERE m.group_id=$1 ORDER BY u.username`,
+		GROUP_MEMBERS_TABLE, USERS_TABLE,
+	)
+	err := r.db.Select(&result, query, groupId)
+
+	return result, err
+}
+
+func (r *GroupsPostgres) AddMember(groupId, userId int) error {
+	query := fmt.Sprintf(
+		"INSERT INTO %s (group_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		GROUP_MEMBERS_TABLE,
+	)
+	_, err := r.db.Exec(query, groupId, userId)
+
+	return err
+}
+
+func (r *GroupsPostgres) RemoveMember(groupId, userId int) (bool, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE group_id=$1 AND user_id=$2", GROUP_MEMBERS_TABLE)
+	res, err := r.db.Exec(query, groupId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+type LoginThrottlesPostgres struct {
+	db *sqlx.DB
+}
+
+func NewLoginThrottlesPostgres(db *sqlx.DB) *LoginThrottlesPostgres {
+	return &LoginThrottlesPostgres{db: db}
+}
+
+func (r *LoginThrottlesPostgres) GetThrottles(keys []string) ([]domain.LoginThrottle, error) {
+	var result []domain.LoginThrottle
+
+	query, args, err := sqlx.In(
+		fmt.Sprintf(
+			`SELECT key, failures, last_failure_at, COALESCE(locked_until, 'epoch') AS locked_until
+			 FROM %s WHERE key IN (?)`,
+			LOGIN_THROTTLES_TABLE,
+		),
+		keys,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.Select(&result, r.db.Rebind(query), args...)
+
+	return result, err
+}
+
+// Increase failures counter, counter starts over when previous failure is older than window
+func (r *LoginThrottlesPostgres) RegisterFailure(key string, window time.Duration) (domain.LoginThrottle, error) {
+	var result domain.LoginThrottle
+
+	query := fmt.Sprintf(
+		`INSERT INTO %[1]s (key, failures, last_failure_at) VALUES ($1, 1, now())
+		 ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN %[1]s.last_failure_at < now() - make_interval(secs => $2) THEN 1 ELSE %[1]s.failures + 1 END,
+			last_failure_at = now()
+		 RETURNING key, failures, last_failure_at, COALESCE(locked_until, 'epoch') AS locked_until`,
+		LOGIN_THROTTLES_TABLE,
+	)
+	err := r.db.Get(&result, query, key, window.Seconds())
+
+	return result, err
+}
+
+func (r *LoginThrottlesPostgres) Lock(key string, until time.Time) error {
+	query := fmt.Sprintf("UPDATE %s SET locked_until=$1, failures=0 WHERE key=$2", LOGIN_THROTTLES_TABLE)
+	_, err := r.db.Exec(query, until, key)
+
+	return err
+}
+
+func (r *LoginThrottlesPostgres) Reset(key string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE key=$1", LOGIN_THROTTLES_TABLE)
+	_, err := r.db.Exec(query, key)
+
+	return err
+}
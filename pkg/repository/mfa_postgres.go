@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type MfaPostgres struct {
+	db *sqlx.DB
+}
+
+func NewMfaPostgres(db *sqlx.DB) *MfaPostgres {
+	return &MfaPostgres{db: db}
+}
+
+// Store pending secret with fresh recovery codes, MFA stays disabled until first code is confirmed.
+// Last accepted time step is kept when the secret is the same, so codes can not be replayed after re-enrollment
+func (r *MfaPostgres) StartEnrollment(userId int, secret string, recoveryCodeHashes []string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(
+		`UPDATE %s SET mfa_secret=$1, mfa_enabled=false,
+			mfa_last_step=CASE WHEN mfa_secret=$1 THEN mfa_last_step ELSE 0 END
+		 WHERE id=$2`,
+		USERS_TABLE,
+	)
+	if _, err := tx.Exec(query, secret, userId); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf("DELETE FROM %s WHERE user_id=$1", MFA_RECOVERY_CODES_TABLE)
+	if _, err := tx.Exec(query, userId); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s (user_id, code_hash) VALUES ($1, $2)", MFA_RECOVERY_CODES_TABLE)
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.Exec(query, userId, hash); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *MfaPostgres) SetMfaEnabled(userId int, enabled bool) error {
+	query := fmt.Sprintf("UPDATE %s SET mfa_enabled=$1 WHERE id=$2", USERS_TABLE)
+	if !enabled {
+		query = fmt.Sprintf("UPDATE %s SET mfa_enabled=$1, mfa_secret=NULL WHERE id=$2", USERS_TABLE)
+	}
+	_, err := r.db.Exec(query, enabled, userId)
+
+	return err
+}
+
+// Remember last accepted time step so the same code can not be used twice
+func (r *MfaPostgres) UseTimeStep(userId int, step int64) (bool, error) {
+	query := fmt.Sprintf("UPDATE %s SET mfa_last_step=$1 WHERE id=$2 AND mfa_last_step < $1", USERS_TABLE)
+	res, err := r.db.Exec(query, step, userId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
+
+func (r *MfaPostgres) UseRecoveryCode(userId int, codeHash string) (bool, error) {
+	query := fmt.Sprintf(
+		"UPDATE %s SET used_at=now() WHERE user_id=$1 AND code_hash=$2 AND used_at IS NULL",
+		MFA_RECOVERY_CODES_TABLE,
+	)
+	res, err := r.db.Exec(query, userId, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
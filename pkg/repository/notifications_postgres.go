@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+// Notifications are enabled for users without saved preferences
+const notificationPreferencesColumns = `u.id AS user_id, COALESCE(n.event_updates, true) AS event_updates,
+	COALESCE(n.event_cancellations, true) AS event_cancellations`
+
+type NotificationsPostgres struct {
+	db *sqlx.DB
+}
+
+func NewNotificationsPostgres(db *sqlx.DB) *NotificationsPostgres {
+	return &NotificationsPostgres{db: db}
+}
+
+func (r *NotificationsPostgres) GetPreferences(userId int) (domain.NotificationPreferences, error) {
+	var result domain.NotificationPreferences
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s u LEFT JOIN %s n ON n.user_id = u.id WHERE u.id=$1",
+		notificationPreferencesColumns, USERS_TABLE, NOTIFICATION_PREFERENCES_TABLE,
+	)
+	err := r.db.Get(&result, query, userId)
+
+	return result, err
+}
+
+func (r *NotificationsPostgres) SavePreferences(preferences domain.NotificationPreferences) error {
+	query := fmt.Sprintf(
+		`INSERT INTO %s (user_id, event_updates, event_cancellations) VALUES ($1, $2, $3)
+		 ON CONFLICT (user_id) DO UPDATE
+			SET event_updates=EXCLUDED.event_updates, event_cancellations=EXCLUDED.event_cancellations`,
+		NOTIFICATION_PREFERENCES_TABLE,
+	)
+	_, err := r.db.Exec(query, preferences.UserId, preferences.EventUpdates, preferences.EventCancellations)
+
+	return err
+}
+
+// Email addresses and preferences of the users
+func (r *NotificationsPostgres) GetRecipients(userIds []int) ([]domain.NotificationRecipient, error) {
+	var result []domain.NotificationRecipient
+
+	query := fmt.Sprintf(
+		`SELECT %s, u.email, u.username FROM %s u LEFT JOIN %s n ON n.user_id = u.id
+		 WHERE u.id = ANY($1) ORDER BY u.id`,
+		notificationPreferencesColumns, USERS_TABLE, NOTIFICATION_PREFERENCES_TABLE,
+	)
+	err := r.db.Select(&result, query, pq.Array(userIds))
+
+	return result, err
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+type OidcPostgres struct {
+	db *sqlx.DB
+}
+
+func NewOidcPostgres(db *sqlx.DB) *OidcPostgres {
+	return &OidcPostgres{db: db}
+}
+
+func (r *OidcPostgres) SaveLoginState(state domain.OidcLoginState) error {
+	query := fmt.Sprintf(
+		"INSERT INTO %s (state, nonce, code_verifier, expires_at) VALUES ($1, $2, $3, $4)",
+		OIDC_LOGIN_STATES_TABLE,
+	)
+	_, err := r.db.Exec(query, state.State, state.Nonce, state.CodeVerifier, state.ExpiresAt)
+
+	return err
+}
+
+// Login state is single-use - it is deleted on the first callback
+func (r *OidcPostgres) ConsumeLoginState(state string) (domain.OidcLoginState, error) {
+	var result domain.OidcLoginState
+
+	query := fmt.Sprintf(
+		"DELETE FROM %s WHERE state=$1 AND expires_at > now() RETURNING state, nonce, code_verifier, expires_at",
+		OIDC_LOGIN_STATES_TABLE,
+	)
+	err := r.db.Get(&result, query, state)
+
+	return result, err
+}
+
+func (r *OidcPostgres) GetUserByIdentity(issuer, subject string) (domain.User, error) {
+	var result domain.User
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE id=(SELECT user_id FROM %s WHERE issuer=$1 AND subject=$2)",
+		userColumns, USERS_TABLE, USER_IDENTITIES_TABLE,
+	)
+	err := r.db.Get(&result, query, issuer, subject)
+
+	return result, err
+}
+
+func (r *OidcPostgres) LinkIdentity(identity domain.UserIdentity) error {
+	query := fmt.Sprintf(
+		"INSERT INTO %s (user_id, issuer, subject, email) VALUES ($1, $2, $3, $4)",
+		USER_IDENTITIES_TABLE,
+	)
+	_, err := r.db.Exec(query, identity.UserId, identity.Issuer, identity.Subject, identity.Email)
+
+	return err
+}
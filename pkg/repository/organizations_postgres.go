@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+type OrganizationsPostgres struct {
+	db *sqlx.DB
+}
+
+func NewOrganizationsPostgres(db *sqlx.DB) *OrganizationsPostgres {
+	return &OrganizationsPostgres{db: db}
+}
+
+func (r *OrganizationsPostgres) Create(name string, ownerId int) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := createOrganization(tx, name, ownerId)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// Organization is created together with the owner membership
+func createOrganization(tx *sqlx.Tx, name string, ownerId int) (int, error) {
+	var result int
+
+	query := fmt.Sprintf("INSERT INTO %s (name, created_by) VALUES ($1, $2) RETURNING id", ORGANIZATIONS_TABLE)
+	if err := tx.QueryRow(query, name, ownerId).Scan(&result); err != nil {
+		return 0, err
+	}
+
+	query = fmt.Sprintf(
+		"INSERT INTO %s (organization_id, user_id, role) VALUES ($1, $2, $3)",
+		ORGANIZATION_MEMBERS_TABLE,
+	)
+	if _, err := tx.Exec(query, result, ownerId, domain.ORG_ROLE_OWNER); err != nil {
+		return 0, err
+	}
+
+	return result, nil
+}
+
+func (r *OrganizationsPostgres) GetByUser(userId int) ([]domain.Organization, error) {
+	var result []domain.Organization
+
+	query := fmt.Sprintf(
+		`SELECT o.id, o.name, m.role, o.created_at FROM %s o
+		 INNER JOIN %s m ON m.organization_id = o.id
+		 WHERE m.user_id=$1 ORDER BY m.created_at, o.id`,
+		ORGANIZATIONS_TABLE, ORGANIZATION_MEMBERS_TABLE,
+	)
+	err := r.db.Select(&result, query, userId)
+
+	return result, err
+}
+
+// The first organization user has joined, normally the personal workspace
+func (r *OrganizationsPostgres) GetDefault(userId int) (int, error) {
+	var result int
+
+	query := fmt.Sprintf(
+		"SELECT organization_id FROM %s WHERE user_id=$1 ORDER BY created_at, organization_id LIMIT 1",
+		ORGANIZATION_MEMBERS_TABLE,
+	)
+	err := r.db.Get(&result, query, userId)
+
+	return result, err
+}
+
+func (r *OrganizationsPostgres) GetMember(organizationId, userId int) (domain.OrganizationMember, error) {
+	var result domain.OrganizationMember
+
+	query := fmt.Sprintf(
+		`SELECT m.user_id, u.email, u.username, m.role FROM %s m
+		 INNER JOIN %s u ON u.id = m.user_id
+		 WHERE m.organization_id=$1 AND m.user_id=$2`,
+		ORGANIZATION_MEMBERS_TABLE, USERS_TABLE,
+	)
+	err := r.db.Get(&result, query, organizationId, userId)
+
+	return result, err
+}
+
+func (r *OrganizationsPostgres) GetMembers(organizationId int) ([]domain.OrganizationMember, error) {
+	var result []domain.OrganizationMember
+
+	query := fmt.Sprintf(
+		`SELECT m.user_id, u.email, u.username, m.role FROM %s m
+		 INNER JOIN %s u ON u.id = m.user_id
+		 WHERE m.organization_id=$1 ORDER BY m.created_at, m.user_id`,
+		ORGANIZATION_MEMBERS_TABLE, USERS_TABLE,
+	)
+	err := r.db.Select(&result, query, organizationId)
+
+	return result, err
+}
+
+// Add user to organization or change role of existing member
+func (r *OrganizationsPostgres) SaveMember(organizationId, userId int, role string) error {
+	query := fmt.Sprintf(
+		`INSERT INTO %s (organization_id, user_id, role) VALUES ($1, $2, $3)
+		 ON CONFLICT (organization_id, user_id) DO UPDATE SET role=EXCLUDED.role`,
+		ORGANIZATION_MEMBERS_TABLE,
+	)
+	_, err := r.db.Exec(query, organizationId, userId, role)
+
+	return err
+}
+
+func (r *OrganizationsPostgres) RemoveMember(organizationId, userId int) (bool, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE organization_id=$1 AND user_id=$2", ORGANIZATION_MEMBERS_TABLE)
+	res, err := r.db.Exec(query, organizationId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
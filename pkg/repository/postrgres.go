@@ -12,6 +12,39 @@ const (
 	POSTGRESS_DB_TYPE = "postgres"
 	USERS_TABLE       = "users"
 	EVENTS_TABLE      = "events"
+	USER_TOKENS_TABLE = "user_tokens"
+
+	MFA_RECOVERY_CODES_TABLE   = "mfa_recovery_codes"
+	LOGIN_THROTTLES_TABLE      = "login_throttles"
+	ACCESS_TOKENS_TABLE        = "access_tokens"
+	SIGNING_KEYS_TABLE         = "signing_keys"
+	OIDC_LOGIN_STATES_TABLE    = "oidc_login_states"
+	USER_IDENTITIES_TABLE      = "user_identities"
+	ORGANIZATIONS_TABLE        = "organizations"
+	ORGANIZATION_MEMBERS_TABLE = "organization_members"
+	GROUPS_TABLE               = "groups"
+	GROUP_MEMBERS_TABLE        = "group_members"
+	EVENT_GRANTS_TABLE         = "event_grants"
+	CALENDAR_DELEGATIONS_TABLE = "calendar_delegations"
+	AUDIT_LOG_TABLE            = "audit_log"
+	CALENDARS_TABLE            = "calendars"
+	EVENT_ATTENDEES_TABLE      = "event_attendees"
+	USER_WORKING_HOURS_TABLE   = "user_working_hours"
+	OUT_OF_OFFICE_TABLE        = "out_of_office"
+	BOOKING_PAGES_TABLE        = "booking_pages"
+	BOOKING_PAGE_WINDOWS_TABLE = "booking_page_windows"
+	BOOKINGS_TABLE             = "bookings"
+	RESOURCES_TABLE            = "resources"
+	EVENT_RESOURCES_TABLE      = "event_resources"
+	TAGS_TABLE                 = "tags"
+	EVENT_TAGS_TABLE           = "event_tags"
+	CUSTOM_FIELDS_TABLE        = "custom_fields"
+	USER_REMINDERS_TABLE       = "user_reminders"
+	EVENT_REMINDERS_TABLE      = "event_reminders"
+	REMINDER_JOBS_TABLE        = "reminder_jobs"
+	DIGEST_PREFERENCES_TABLE   = "digest_preferences"
+
+	NOTIFICATION_PREFERENCES_TABLE = "notification_preferences"
 )
 
 func NewPostgresDB(cfg *config.Config) (*sqlx.DB, error) {
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+type RemindersPostgres struct {
+	db *sqlx.DB
+}
+
+func NewRemindersPostgres(db *sqlx.DB) *RemindersPostgres {
+	return &RemindersPostgres{db: db}
+}
+
+// Reminders of the event, or defaults of the participant when the event has no own reminders
+func effectiveReminders(eventRef, userRef string) string {
+	return fmt.Sprintf(
+		`SELECT minutes_before FROM %[3]s WHERE event_id=%[1]s.id
+		 UNION
+		 SELECT minutes_before FROM %[4]s WHERE user_id=%[2]s
+			AND NOT EXISTS (SELECT 1 FROM %[3]s WHERE event_id=%[1]s.id)`,
+		eventRef, userRef, EVENT_REMINDERS_TABLE, USER_REMINDERS_TABLE,
+	)
+}
+
+func (r *RemindersPostgres) GetDefaults(userId int) ([]int, error) {
+	var result []int
+
+	query := fmt.Sprintf("SELECT minutes_before FROM %s WHERE user_id=$1 ORDER BY minutes_before", USER_REMINDERS_TABLE)
+	err := r.db.Select(&result, query, userId)
+
+	return result, err
+}
+
+func (r *RemindersPostgres) SaveDefaults(userId int, minutes []int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id=$1", USER_REMINDERS_TABLE)
+	if _, err := tx.Exec(query, userId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if len(minutes) > 0 {
+		query := fmt.Sprintf(
+			"INSERT INTO %s (user_id, minutes_before) SELECT $1, unnest($2::int[]) ON CONFLICT DO NOTHING",
+			USER_REMINDERS_TABLE,
+		)
+		if _, err := tx.Exec(query, userId, pq.Array(minutes)); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Create jobs for reminders of published events which are due until the time.
+// Job which was skipped is scheduled again when the event is moved back to its time
+func (r *RemindersPostgres) Enqueue(until time.Time) (int, error) {
+	query := fmt.Sprintf(
+		`INSERT INTO %[1]s (event_id, user_id, minutes_before, starts_at, remind_at)
+		 SELECT e.id, p.user_id, m.minutes_before, e.starts_at, e.starts_at - make_interval(mins => m.minutes_before)
+		 FROM %[2]s e
+		 JOIN LATERAL (%[3]s) p ON true
+		 JOIN LATERAL (%[4]s) m ON true
+		 WHERE e.status = '%[5]s' AND e.starts_at > now() AND e.starts_at <= $1::timestamptz + interval '%[6]d minutes'
+			AND e.starts_at - make_interval(mins => m.minutes_before) <= $1
+		 ON CONFLICT (event_id, user_id, minutes_before, starts_at) DO UPDATE
+			SET status = '%[7]s', attempts = 0, last_error = ''
+			WHERE %[1]s.status = '%[8]s'`,
+		REMINDER_JOBS_TABLE, EVENTS_TABLE, eventParticipants("e"), effectiveReminders("e", "p.user_id"),
+		domain.EVENT_STATUS_PUBLISHED, domain.REMINDER_MAX_MINUTES,
+		domain.REMINDER_STATUS_PENDING, domain.REMINDER_STATUS_SKIPPED,
+	)
+	result, err := r.db.Exec(query, until)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+
+	return int(affected), err
+}
+
+// Claim the earliest due job and deliver it while its row is locked, concurrent servers skip locked jobs.
+// Job returned by delivery is saved in the same transaction, so a sent reminder is never claimed again.
+// False is returned when there are no due jobs
+func (r *RemindersPostgres) DeliverDue(deliver func(job domain.ReminderJob) domain.ReminderJob) (bool, error) {
+	var job domain.ReminderJob
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+
+	query := fmt.Sprintf(
+		`SELECT j.id, j.event_id, j.user_id, j.minutes_before, j.starts_at, j.remind_at, j.status, j.attempts,
+			j.last_error, e.title, e.timezoneid, u.email,
+			e.status = '%[4]s' AND e.starts_at = j.starts_at AND e.starts_at > now()
+				AND j.user_id IN (%[5]s) AND j.minutes_before IN (%[6]s) AS valid
+		 FROM %[1]s j JOIN %[2]s e ON e.id = j.event_id JOIN %[3]s u ON u.id = j.user_id
+		 WHERE j.status = '%[7]s' AND j.remind_at <= now()
+		 ORDER BY j.remind_at
+		 LIMIT 1
+		 FOR UPDATE OF j SKIP LOCKED`,
+		REMINDER_JOBS_TABLE, EVENTS_TABLE, USERS_TABLE, domain.EVENT_STATUS_PUBLISHED,
+		eventParticipants("e"), effectiveReminders("e", "j.user_id"), domain.REMINDER_STATUS_PENDING,
+	)
+	if err := tx.Get(&job, query); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	job = deliver(job)
+
+	query = fmt.Sprintf(
+		`UPDATE %s SET status=$1, attempts=$2, remind_at=$3, last_error=$4,
+			sent_at = CASE WHEN $1 = '%s' THEN now() END
+		 WHERE id=$5`,
+		REMINDER_JOBS_TABLE, domain.REMINDER_STATUS_SENT,
+	)
+	if _, err := tx.Exec(query, job.Status, job.Attempts, job.RemindAt, job.LastError, job.Id); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func saveEventReminders(tx *sqlx.Tx, eventId int, minutes []int) error {
+	if len(minutes) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (event_id, minutes_before) SELECT $1, unnest($2::int[]) ON CONFLICT DO NOTHING",
+		EVENT_REMINDERS_TABLE,
+	)
+	_, err := tx.Exec(query, eventId, pq.Array(minutes))
+
+	return err
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/salesforceanton/events-api/domain"
 )
@@ -8,24 +10,241 @@ import (
 type Repository struct {
 	Authorization
 	Events
+	UserTokens
+	Mfa
+	LoginThrottles
+	AccessTokens
+	SigningKeys
+	Oidc
+	Organizations
+	Groups
+	EventGrants
+	Delegations
+	AuditLog
+	Calendars
+	Availability
+	BookingPages
+	Resources
+	Tags
+	CustomFields
+	Reminders
+	Digests
+	Notifications
 }
 
 type Authorization interface {
 	CreateUser(user domain.User) (int, error)
 	GetUser(username, password string) (domain.User, error)
+	GetUserById(userId int) (domain.User, error)
+	GetUserByEmail(email string) (domain.User, error)
+	UsernameExists(username string) (bool, error)
+	SetEmailVerified(userId int) error
+	UpdatePassword(userId int, passwordHash string) error
+	GetUsers() ([]domain.UserInfo, error)
+	SetRole(userId int, role string) (bool, error)
+	SetMfaRequired(userId int, required bool) (bool, error)
 }
 
+// Access to events is checked by service policies, repository does not filter records by user.
+// All queries except system-wide listing are scoped by organization
 type Events interface {
-	GetAll(userId int) ([]domain.Event, error)
-	GetById(userId, eventId int) (domain.Event, error)
-	Create(userId int, request domain.SaveEventRequest) (int, error)
-	Update(userId, eventId int, request domain.SaveEventRequest) (domain.Event, error)
-	Delete(userId, eventId int) error
+	GetSystemWide() ([]domain.Event, error)
+	GetAccessible(organizationId, userId int, filter domain.EventsFilter) ([]domain.Event, error)
+	GetNearby(organizationId, userId int, filter domain.NearbyFilter, box domain.GeoBox) ([]domain.Event, error)
+	GetById(organizationId, eventId int) (domain.Event, error)
+	Create(organizationId, organizerId int, request domain.SaveEventRequest) (int, error)
+	Update(organizationId, eventId int, request domain.SaveEventRequest) (domain.Event, error)
+	Delete(organizationId, eventId int) error
+	SetStatus(organizationId, eventId int, from, to, reason string) (domain.Event, error)
+	GetAttendees(eventId int) ([]int, error)
+	GetResources(eventId int) ([]int, error)
+	GetReminders(eventId int) ([]int, error)
+	GetAgenda(userId int, from, to time.Time) ([]domain.AgendaItem, error)
+	GetOverlapping(organizationId int, userIds []int, startsAt, endsAt time.Time, excludeEventId int) ([]domain.EventConflict, error)
+	GetBusy(userId int, startsAt, endsAt time.Time) ([]domain.BusyInterval, error)
+	GetOverlaps(organizationId, userId int) ([]domain.EventOverlap, error)
+	GetPublic(limit int) ([]domain.PublicEvent, error)
+	GetPublicBySlug(slug string) (domain.PublicEvent, error)
+}
+
+type UserTokens interface {
+	CreateToken(token domain.UserToken) error
+	ConsumeToken(purpose, tokenHash string) (int, error)
+	RevokeTokens(userId int, purpose string) error
+}
+
+type Mfa interface {
+	StartEnrollment(userId int, secret string, recoveryCodeHashes []string) error
+	SetMfaEnabled(userId int, enabled bool) error
+	UseTimeStep(userId int, step int64) (bool, error)
+	UseRecoveryCode(userId int, codeHash string) (bool, error)
+}
+
+type LoginThrottles interface {
+	GetThrottles(keys []string) ([]domain.LoginThrottle, error)
+	RegisterFailure(key string, window time.Duration) (domain.LoginThrottle, error)
+	Lock(key string, until time.Time) error
+	Reset(key string) error
+}
+
+type AccessTokens interface {
+	Create(token domain.AccessToken, tokenHash string) (domain.AccessToken, error)
+	GetAll(userId int) ([]domain.AccessToken, error)
+	Use(tokenHash string) (domain.AccessToken, error)
+	Revoke(userId, tokenId int) (bool, error)
+}
+
+type SigningKeys interface {
+	GetKeys() ([]domain.SigningKey, error)
+	PublishKey(key domain.SigningKey, latestActivation time.Time) (bool, error)
+	RetireKeys(activatedBefore, retireUntil time.Time) error
+}
+
+type Oidc interface {
+	SaveLoginState(state domain.OidcLoginState) error
+	ConsumeLoginState(state string) (domain.OidcLoginState, error)
+	GetUserByIdentity(issuer, subject string) (domain.User, error)
+	LinkIdentity(identity domain.UserIdentity) error
+}
+
+type Organizations interface {
+	Create(name string, ownerId int) (int, error)
+	GetByUser(userId int) ([]domain.Organization, error)
+	GetDefault(userId int) (int, error)
+	GetMember(organizationId, userId int) (domain.OrganizationMember, error)
+	GetMembers(organizationId int) ([]domain.OrganizationMember, error)
+	SaveMember(organizationId, userId int, role string) error
+	RemoveMember(organizationId, userId int) (bool, error)
+}
+
+type Groups interface {
+	Create(organizationId int, name string) (int, error)
+	GetAll(organizationId int) ([]domain.Group, error)
+	GetById(organizationId, groupId int) (domain.Group, error)
+	GetMembers(groupId int) ([]domain.GroupMember, error)
+	AddMember(groupId, userId int) error
+	RemoveMember(groupId, userId int) (bool, error)
+}
+
+type EventGrants interface {
+	GetPermission(eventId, userId int) (string, error)
+	GetGrants(eventId int) ([]domain.EventGrant, error)
+	SaveGrant(grant domain.EventGrant) (domain.EventGrant, error)
+	DeleteGrant(eventId, grantId int) (bool, error)
+}
+
+type Delegations interface {
+	Get(organizationId, principalId, delegateId int) (domain.Delegation, error)
+	GetByUser(organizationId, userId int) ([]domain.Delegation, error)
+	Save(delegation domain.Delegation) (domain.Delegation, error)
+	Delete(organizationId, userId, delegationId int) (bool, error)
+}
+
+type AuditLog interface {
+	Record(entry domain.AuditEntry) error
+	GetByUser(organizationId, userId, limit int) ([]domain.AuditEntry, error)
+}
+
+type Calendars interface {
+	GetByOwner(organizationId, ownerId int) ([]domain.Calendar, error)
+	GetById(organizationId, ownerId, calendarId int) (domain.Calendar, error)
+	GetDefault(organizationId, ownerId int) (domain.Calendar, error)
+	Create(calendar domain.Calendar) (int, error)
+	Update(calendar domain.Calendar) (domain.Calendar, error)
+	Delete(organizationId, ownerId, calendarId int) (bool, error)
+}
+
+type Availability interface {
+	GetAvailability(userIds []int) ([]domain.Availability, error)
+	SaveAvailability(userId int, request domain.SaveAvailabilityRequest) error
+	GetUpcomingOutOfOffice(userId int) ([]domain.OutOfOffice, error)
+	GetOutOfOffice(userIds []int, startsAt, endsAt time.Time) ([]domain.OutOfOffice, error)
+	CreateOutOfOffice(userId int, request domain.SaveOutOfOfficeRequest) (int, error)
+	DeleteOutOfOffice(userId, outOfOfficeId int) (bool, error)
+}
+
+type BookingPages interface {
+	GetByHost(organizationId, hostId int) ([]domain.BookingPage, error)
+	GetById(organizationId, hostId, pageId int) (domain.BookingPage, error)
+	GetBySlug(slug string) (domain.BookingPage, error)
+	Create(page domain.BookingPage) (int, error)
+	Update(page domain.BookingPage) (domain.BookingPage, error)
+	Delete(organizationId, hostId, pageId int) (bool, error)
+	GetBookings(pageId int, from, to time.Time) ([]domain.Booking, error)
+	Book(
+		page domain.BookingPage, event domain.SaveEventRequest, booking domain.Booking, busyFrom, busyTo, dayFrom, dayTo time.Time,
+	) (domain.Booking, bool, error)
+}
+
+type Resources interface {
+	GetAll(organizationId int) ([]domain.Resource, error)
+	GetByIds(organizationId int, resourceIds []int) ([]domain.Resource, error)
+	Create(resource domain.Resource) (int, error)
+	Update(resource domain.Resource) (domain.Resource, error)
+	Delete(organizationId, resourceId int) (bool, error)
+	GetReservations(resourceId int, from, to time.Time) ([]domain.BusyInterval, error)
+	GetUtilization(organizationId int, from, to time.Time) ([]domain.ResourceUtilization, error)
+}
+
+type Tags interface {
+	GetAll(organizationId, userId int) ([]domain.Tag, error)
+	GetByIds(organizationId, userId int, tagIds []int) ([]domain.Tag, error)
+	GetByEvents(userId int, eventIds []int) ([]domain.Tag, error)
+	Create(tag domain.Tag) (int, error)
+	Update(tag domain.Tag) (domain.Tag, error)
+	Delete(organizationId, tagId int) (bool, error)
+}
+
+type CustomFields interface {
+	GetAll(organizationId int) ([]domain.CustomField, error)
+	Create(field domain.CustomField) (int, error)
+	Update(field domain.CustomField) (domain.CustomField, error)
+	Delete(organizationId, fieldId int) (bool, error)
+}
+
+// Reminder jobs are claimed with row locks, so any number of servers can deliver them
+type Reminders interface {
+	GetDefaults(userId int) ([]int, error)
+	SaveDefaults(userId int, minutes []int) error
+	Enqueue(until time.Time) (int, error)
+	DeliverDue(deliver func(job domain.ReminderJob) domain.ReminderJob) (bool, error)
+}
+
+type Digests interface {
+	Get(userId int) (domain.DigestPreferences, error)
+	Save(digest domain.DigestPreferences) error
+	DeliverDue(deliver func(digest domain.DigestPreferences) domain.DigestPreferences) (bool, error)
+}
+
+type Notifications interface {
+	GetPreferences(userId int) (domain.NotificationPreferences, error)
+	SavePreferences(preferences domain.NotificationPreferences) error
+	GetRecipients(userIds []int) ([]domain.NotificationRecipient, error)
 }
 
 func NewRepository(db *sqlx.DB) *Repository {
 	return &Repository{
-		Authorization: NewAuthPostgres(db),
-		Events:        NewEventsPostgres(db),
+		Authorization:  NewAuthPostgres(db),
+		Events:         NewEventsPostgres(db),
+		UserTokens:     NewTokensPostgres(db),
+		Mfa:            NewMfaPostgres(db),
+		LoginThrottles: NewLoginThrottlesPostgres(db),
+		AccessTokens:   NewAccessTokensPostgres(db),
+		SigningKeys:    NewSigningKeysPostgres(db),
+		Oidc:           NewOidcPostgres(db),
+		Organizations:  NewOrganizationsPostgres(db),
+		Groups:         NewGroupsPostgres(db),
+		EventGrants:    NewEventGrantsPostgres(db),
+		Delegations:    NewDelegationsPostgres(db),
+		AuditLog:       NewAuditPostgres(db),
+		Calendars:      NewCalendarsPostgres(db),
+		Availability:   NewAvailabilityPostgres(db),
+		BookingPages:   NewBookingPagesPostgres(db),
+		Resources:      NewResourcesPostgres(db),
+		Tags:           NewTagsPostgres(db),
+		CustomFields:   NewCustomFieldsPostgres(db),
+		Reminders:      NewRemindersPostgres(db),
+		Digests:        NewDigestsPostgres(db),
+		Notifications:  NewNotificationsPostgres(db),
 	}
 }
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+// Postgres reports rejected overlapping reservation with exclusion_violation code
+const EXCLUSION_VIOLATION = "23P01"
+
+var ErrReservationConflict = errors.New("Resource is already reserved at this time")
+
+const resourceColumns = "id, organization_id, name, capacity, location, created_at"
+
+type ResourcesPostgres struct {
+	db *sqlx.DB
+}
+
+func NewResourcesPostgres(db *sqlx.DB) *ResourcesPostgres {
+	return &ResourcesPostgres{db: db}
+}
+
+func (r *ResourcesPostgres) GetAll(organizationId int) ([]domain.Resource, error) {
+	var result []domain.Resource
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE organization_id=$1 ORDER BY name", resourceColumns, RESOURCES_TABLE)
+	err := r.db.Select(&result, query, organizationId)
+
+	return result, err
+}
+
+func (r *ResourcesPostgres) GetByIds(organizationId int, resourceIds []int) ([]domain.Resource, error) {
+	var result []domain.Resource
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE organization_id=$1 AND id = ANY($2) ORDER BY id",
+		resourceColumns, RESOURCES_TABLE,
+	)
+	err := r.db.Select(&result, query, organizationId, pq.Array(resourceIds))
+
+	return result, err
+}
+
+func (r *ResourcesPostgres) Create(resource domain.Resource) (int, error) {
+	var result int
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (organization_id, name, capacity, location) VALUES ($1, $2, $3, $4) RETURNING id",
+		RESOURCES_TABLE,
+	)
+	err := r.db.QueryRow(query, resource.OrganizationId, resource.Name, resource.Capacity, resource.Location).Scan(&result)
+
+	return result, err
+}
+
+func (r *ResourcesPostgres) Update(resource domain.Resource) (domain.Resource, error) {
+	var result domain.Resource
+
+	query := fmt.Sprintf(
+		`UPDATE %s SET name=$1, capacity=$2, location=$3 WHERE organization_id=$4 AND id=$5
+		 RETURNING %s`,
+		RESOURCES_TABLE, resourceColumns,
+	)
+	err := r.db.Get(
+		&result, query, resource.Name, resource.Capacity, resource.Location, resource.OrganizationId, resource.Id,
+	)
+
+	return result, err
+}
+
+// Reservations of the resource are deleted with it
+func (r *ResourcesPostgres) Delete(organizationId, resourceId int) (bool, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE organization_id=$1 AND id=$2", RESOURCES_TABLE)
+	res, err := r.db.Exec(query, organizationId, resourceId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
+
+// Active reservations of the resource overlapping the time range, clipped to the range.
+// Reservations do not overlap each other, so they are returned as busy intervals as is
+func (r *ResourcesPostgres) GetReservations(resourceId int, from, to time.Time) ([]domain.BusyInterval, error) {
+	var result []domain.BusyInterval
+
+	query := fmt.Sprintf(
+		`SELECT GREATEST(starts_at, $2) AS starts_at, LEAST(ends_at, $3) AS ends_at FROM %s
+		 WHERE resource_id=$1 AND NOT released AND starts_at < $3 AND ends_at > $2
+		 ORDER BY starts_at`,
+		EVENT_RESOURCES_TABLE,
+	)
+	err := r.db.Select(&result, query, resourceId, from, to)
+
+	return result, err
+}
+
+// Reserved minutes of each resource of the organization inside the time range
+func (r *ResourcesPostgres) GetUtilization(organizationId int, from, to time.Time) ([]domain.ResourceUtilization, error) {
+	var result []domain.ResourceUtilization
+
+	query := fmt.Sprintf(
+		`SELECT r.id AS resource_id, r.name,
+			COALESCE(SUM(EXTRACT(EPOCH FROM LEAST(er.ends_at, $3) - GREATEST(er.starts_at, $2))), 0)::int / 60
+				AS reserved_minutes
+		 FROM %s r
+		 LEFT JOIN %s er ON er.resource_id = r.id AND NOT er.released AND er.starts_at < $3 AND er.ends_at > $2
+		 WHERE r.organization_id=$1
+		 GROUP BY r.id, r.name
+		 ORDER BY r.name`,
+		RESOURCES_TABLE, EVENT_RESOURCES_TABLE,
+	)
+	err := r.db.Select(&result, query, organizationId, from, to)
+
+	return result, err
+}
+
+// Reservations follow time of the event, reservations of cancelled event are released.
+// Conflict target is set, so overlapping reservations are not skipped but fail with exclusion violation
+func saveReservations(tx *sqlx.Tx, eventId int, resourceIds []int, startsAt, endsAt time.Time, released bool) error {
+	if len(resourceIds) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (event_id, resource_id, starts_at, ends_at, released)
+		 SELECT $1, unnest($2::int[]), $3, $4, $5 ON CONFLICT (event_id, resource_id) DO NOTHING`,
+		EVENT_RESOURCES_TABLE,
+	)
+	_, err := tx.Exec(query, eventId, pq.Array(resourceIds), startsAt, endsAt, released)
+
+	return reservationError(err)
+}
+
+// Overlapping reservation is rejected by exclusion constraint, it is reported as ErrReservationConflict
+func reservationError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == EXCLUSION_VIOLATION {
+		return ErrReservationConflict
+	}
+
+	return err
+}
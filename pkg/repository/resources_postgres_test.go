@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+// Driver which records statements and fails them with the configured error
+type recordingDriver struct {
+	queries []string
+	err     error
+}
+
+func (d *recordingDriver) Open(name string) (driver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *recordingConn) Commit() error {
+	return nil
+}
+
+func (c *recordingConn) Rollback() error {
+	return nil
+}
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.driver.queries = append(c.driver.queries, query)
+	if c.driver.err != nil {
+		return nil, c.driver.err
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func TestSaveReservations(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedError error
+	}{
+		{
+			name: "Ok",
+		},
+		{
+			name:          "Overlapping Reservation",
+			err:           &pq.Error{Code: EXCLUSION_VIOLATION, Constraint: "event_resources_overlap_excl"},
+			expectedError: ErrReservationConflict,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := &recordingDriver{err: test.err}
+			driverName := "recording-" + strings.ReplaceAll(test.name, " ", "-")
+			sql.Register(driverName, recorder)
+
+			db, err := sqlx.Open(driverName, "")
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tx, err := db.Beginx()
+			assert.NoError(t, err)
+
+			startsAt := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+			err = saveReservations(tx, 1, []int{2, 3}, startsAt, startsAt.Add(time.Hour), false)
+
+			assert.Equal(t, test.expectedError, err)
+			// Only duplicated key is ignored, exclusion violation should reach the caller
+			assert.Len(t, recorder.queries, 1)
+			assert.Contains(t, recorder.queries[0], "ON CONFLICT (event_id, resource_id) DO NOTHING")
+		})
+	}
+}
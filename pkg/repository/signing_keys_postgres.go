@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+// Any constant shared by all app instances, only one instance rotates keys at a time
+const SIGNING_KEYS_LOCK_ID = 7340301
+
+type SigningKeysPostgres struct {
+	db *sqlx.DB
+}
+
+func NewSigningKeysPostgres(db *sqlx.DB) *SigningKeysPostgres {
+	return &SigningKeysPostgres{db: db}
+}
+
+func (r *SigningKeysPostgres) GetKeys() ([]domain.SigningKey, error) {
+	var result []domain.SigningKey
+
+	query := fmt.Sprintf(
+		`SELECT kid, algorithm, private_key, public_key, created_at, activates_at, retired_at, expires_at
+		 FROM %s WHERE expires_at IS NULL OR expires_at > now()
+		 ORDER BY activates_at DESC, created_at DESC`,
+		SIGNING_KEYS_TABLE,
+	)
+	err := r.db.Select(&result, query)
+
+	return result, err
+}
+
+// Save new key unless the newest not retired key of the same algorithm is activated after latestActivation,
+// i.e. the key has been already published by another instance
+func (r *SigningKeysPostgres) PublishKey(key domain.SigningKey, latestActivation time.Time) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.Get(&locked, "SELECT pg_try_advisory_xact_lock($1)", SIGNING_KEYS_LOCK_ID); err != nil {
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+
+	var activatesAt time.Time
+	query := fmt.Sprintf(
+		"SELECT activates_at FROM %s WHERE retired_at IS NULL AND algorithm=$1 ORDER BY activates_at DESC LIMIT 1",
+		SIGNING_KEYS_TABLE,
+	)
+	err = tx.Get(&activatesAt, query, key.Algorithm)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	if err == nil && activatesAt.After(latestActivation) {
+		return false, nil
+	}
+
+	query = fmt.Sprintf(
+		"INSERT INTO %s (kid, algorithm, private_key, public_key, activates_at) VALUES ($1, $2, $3, $4, $5)",
+		SIGNING_KEYS_TABLE,
+	)
+	if _, err := tx.Exec(query, key.Kid, key.Algorithm, key.PrivateKey, key.PublicKey, key.ActivatesAt); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// Keys replaced by newer active key stay valid for verification until retireUntil
+func (r *SigningKeysPostgres) RetireKeys(activatedBefore, retireUntil time.Time) error {
+	query := fmt.Sprintf(
+		"UPDATE %s SET retired_at=now(), expires_at=$1 WHERE retired_at IS NULL AND activates_at < $2",
+		SIGNING_KEYS_TABLE,
+	)
+	_, err := r.db.Exec(query, retireUntil, activatedBefore)
+
+	return err
+}
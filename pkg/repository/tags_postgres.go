@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+// Postgres reports duplicated key of unique index with unique_violation code
+const UNIQUE_VIOLATION = "23505"
+
+var ErrDuplicateTag = errors.New("Tag with this name already exists")
+
+var tagColumns = fmt.Sprintf(
+	`id, organization_id, COALESCE(owner_id, 0) AS owner_id, name,
+	CASE WHEN owner_id IS NULL THEN '%s' ELSE '%s' END AS scope`,
+	domain.TAG_SCOPE_ORGANIZATION, domain.TAG_SCOPE_USER,
+)
+
+type TagsPostgres struct {
+	db *sqlx.DB
+}
+
+func NewTagsPostgres(db *sqlx.DB) *TagsPostgres {
+	return &TagsPostgres{db: db}
+}
+
+// Tags of the organization and personal tags of the user
+func (r *TagsPostgres) GetAll(organizationId, userId int) ([]domain.Tag, error) {
+	var result []domain.Tag
+
+	query := fmt.Sprintf(
+		`SELECT %s FROM %s WHERE organization_id=$1 AND (owner_id IS NULL OR owner_id=$2)
+		 ORDER BY owner_id NULLS FIRST, lower(name)`,
+		tagColumns, TAGS_TABLE,
+	)
+	err := r.db.Select(&result, query, organizationId, userId)
+
+	return result, err
+}
+
+// Tags from the list which are available to the user
+func (r *TagsPostgres) GetByIds(organizationId, userId int, tagIds []int) ([]domain.Tag, error) {
+	var result []domain.Tag
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE organization_id=$1 AND (owner_id IS NULL OR owner_id=$2) AND id = ANY($3) ORDER BY id",
+		tagColumns, TAGS_TABLE,
+	)
+	err := r.db.Select(&result, query, organizationId, userId, pq.Array(tagIds))
+
+	return result, err
+}
+
+// Tags of the events available to the user, a tag is returned for each event.
+// Personal tags of other users are not returned, so their names are not disclosed to grantees
+func (r *TagsPostgres) GetByEvents(userId int, eventIds []int) ([]domain.Tag, error) {
+	var result []domain.Tag
+
+	query := fmt.Sprintf(
+		`SELECT %s, et.event_id FROM %s t JOIN %s et ON et.tag_id = t.id
+		 WHERE et.event_id = ANY($1) AND (t.owner_id IS NULL OR t.owner_id=$2) ORDER BY et.event_id, lower(t.name)`,
+		tagColumns, TAGS_TABLE, EVENT_TAGS_TABLE,
+	)
+	err := r.db.Select(&result, query, pq.Array(eventIds), userId)
+
+	return result, err
+}
+
+func (r *TagsPostgres) Create(tag domain.Tag) (int, error) {
+	var result int
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (organization_id, owner_id, name) VALUES ($1, NULLIF($2, 0), $3) RETURNING id",
+		TAGS_TABLE,
+	)
+	err := r.db.QueryRow(query, tag.OrganizationId, tag.OwnerId, tag.Name).Scan(&result)
+
+	return result, tagError(err)
+}
+
+func (r *TagsPostgres) Update(tag domain.Tag) (domain.Tag, error) {
+	var result domain.Tag
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET name=$1 WHERE organization_id=$2 AND id=$3 RETURNING %s",
+		TAGS_TABLE, tagColumns,
+	)
+	err := r.db.Get(&result, query, tag.Name, tag.OrganizationId, tag.Id)
+
+	return result, tagError(err)
+}
+
+// Tag is removed from events with it
+func (r *TagsPostgres) Delete(organizationId, tagId int) (bool, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE organization_id=$1 AND id=$2", TAGS_TABLE)
+	res, err := r.db.Exec(query, organizationId, tagId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return affected == 1, err
+}
+
+func saveEventTags(tx *sqlx.Tx, eventId int, tagIds []int) error {
+	if len(tagIds) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (event_id, tag_id) SELECT $1, unnest($2::int[]) ON CONFLICT DO NOTHING",
+		EVENT_TAGS_TABLE,
+	)
+	_, err := tx.Exec(query, eventId, pq.Array(tagIds))
+
+	return err
+}
+
+func tagError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == UNIQUE_VIOLATION {
+		return ErrDuplicateTag
+	}
+
+	return err
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salesforceanton/events-api/domain"
+)
+
+type TokensPostgres struct {
+	db *sqlx.DB
+}
+
+func NewTokensPostgres(db *sqlx.DB) *TokensPostgres {
+	return &TokensPostgres{db: db}
+}
+
+func (r *TokensPostgres) CreateToken(token domain.UserToken) error {
+	query := fmt.Sprintf(
+		"INSERT INTO %s (user_id, purpose, token_hash, expires_at) VALUES ($1, $2, $3, $4)",
+		USER_TOKENS_TABLE,
+	)
+	_, err := r.db.Exec(query, token.UserId, token.Purpose, token.TokenHash, token.ExpiresAt)
+
+	return err
+}
+
+// Mark token as used and return its owner - fails if token is unknown, expired or already used
+func (r *TokensPostgres) ConsumeToken(purpose, tokenHash string) (int, error) {
+	var result int
+
+	query := fmt.Sprintf(
+		`UPDATE %s SET used_at=now()
+		 WHERE purpose=$1 AND token_hash=$2 AND used_at IS NULL AND expires_at > now()
+		 RETURNING user_id`,
+		USER_TOKENS_TABLE,
+	)
+	err := r.db.Get(&result, query, purpose, tokenHash)
+
+	return result, err
+}
+
+func (r *TokensPostgres) RevokeTokens(userId int, purpose string) error {
+	query := fmt.Sprintf(
+		"UPDATE %s SET used_at=now() WHERE user_id=$1 AND purpose=$2 AND used_at IS NULL",
+		USER_TOKENS_TABLE,
+	)
+	_, err := r.db.Exec(query, userId, purpose)
+
+	return err
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/salesforceanton/events-api/config"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+// Personal access tokens are recognized by this prefix in Authorization header
+const ACCESS_TOKEN_PREFIX = "eapi_"
+
+var (
+	ErrInvalidAccessToken  = errors.New("Personal access token is invalid, expired or revoked")
+	ErrAccessTokenNotFound = errors.New("Personal access token is not found")
+	ErrUnknownScope        = errors.New("Unknown scope")
+)
+
+type AccessTokensService struct {
+	repo repository.AccessTokens
+	cfg  *config.Config
+}
+
+func NewAccessTokensService(repo repository.AccessTokens, cfg *config.Config) *AccessTokensService {
+	return &AccessTokensService{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// Token is bound to the active organization of the session
+func (s *AccessTokensService) Create(actor domain.Actor, request domain.CreateAccessTokenRequest) (domain.CreatedAccessToken, error) {
+	for _, scope := range request.Scopes {
+		if !isKnownScope(scope) {
+			return domain.CreatedAccessToken{}, fmt.Errorf("%w: %s", ErrUnknownScope, scope)
+		}
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return domain.CreatedAccessToken{}, err
+	}
+	value := ACCESS_TOKEN_PREFIX + base64.RawURLEncoding.EncodeToString(raw)
+
+	token := domain.AccessToken{
+		UserId:         actor.UserId,
+		OrganizationId: actor.OrganizationId,
+		Name:           request.Name,
+		Prefix:         value[:len(ACCESS_TOKEN_PREFIX)+6],
+		Scopes:         request.Scopes,
+	}
+	if request.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, request.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	created, err := s.repo.Create(token, hashToken(value))
+	if err != nil {
+		return domain.CreatedAccessToken{}, err
+	}
+
+	return domain.CreatedAccessToken{AccessToken: created, Token: value}, nil
+}
+
+func (s *AccessTokensService) GetAll(userId int) ([]domain.AccessToken, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *AccessTokensService) Revoke(userId, tokenId int) error {
+	revoked, err := s.repo.Revoke(userId, tokenId)
+	if err != nil {
+		return err
+	}
+
+	if !revoked {
+		return ErrAccessTokenNotFound
+	}
+
+	return nil
+}
+
+func (s *AccessTokensService) Authenticate(token string) (domain.AccessToken, error) {
+	result, err := s.repo.Use(hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.AccessToken{}, ErrInvalidAccessToken
+	}
+
+	return result, err
+}
+
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(token, ACCESS_TOKEN_PREFIX)
+}
+
+func isKnownScope(scope string) bool {
+	for _, known := range domain.AccessTokenScopes {
+		if scope == known {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+var (
+	ErrUnknownRole  = errors.New("Unknown role")
+	ErrUserNotFound = errors.New("User is not found")
+	ErrOwnRole      = errors.New("Administrator can not change own role")
+)
+
+type AdminService struct {
+	users  repository.Authorization
+	events repository.Events
+}
+
+func NewAdminService(users repository.Authorization, events repository.Events) *AdminService {
+	return &AdminService{
+		users:  users,
+		events: events,
+	}
+}
+
+func (s *AdminService) GetUsers() ([]domain.UserInfo, error) {
+	return s.users.GetUsers()
+}
+
+func (s *AdminService) GetEvents() ([]domain.Event, error) {
+	return s.events.GetSystemWide()
+}
+
+// Own role is protected so the system can not be left without administrators by mistake
+func (s *AdminService) SetRole(actorId, userId int, role string) error {
+	if !isKnownRole(role) {
+		return ErrUnknownRole
+	}
+
+	if actorId == userId {
+		return ErrOwnRole
+	}
+
+	updated, err := s.users.SetRole(userId, role)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (s *AdminService) SetMfaRequired(userId int, required bool) error {
+	updated, err := s.users.SetMfaRequired(userId, required)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+const (
+	AUDIT_EVENTS_LIST          = "events.list"
+	AUDIT_EVENTS_GET           = "events.get"
+	AUDIT_EVENTS_CREATE        = "events.create"
+	AUDIT_EVENTS_UPDATE        = "events.update"
+	AUDIT_EVENTS_DELETE        = "events.delete"
+	AUDIT_EVENTS_PUBLISH       = "events.publish"
+	AUDIT_EVENTS_CANCEL        = "events.cancel"
+	AUDIT_EVENTS_REOPEN        = "events.reopen"
+	AUDIT_EVENTS_CONFLICTS     = "events.conflicts"
+	AUDIT_EVENTS_FREEBUSY      = "events.freebusy"
+	AUDIT_EVENTS_NEARBY        = "events.nearby"
+	AUDIT_SCHEDULING_SUGGEST   = "scheduling.suggest"
+	AUDIT_EVENTS_GRANTS_LIST   = "events.grants.list"
+	AUDIT_EVENTS_GRANTS_SAVE   = "events.grants.save"
+	AUDIT_EVENTS_GRANTS_DELETE = "events.grants.delete"
+
+	AUDIT_OUTCOME_SUCCESS = "success"
+	AUDIT_LOG_LIMIT       = 100
+)
+
+type AuditService struct {
+	repo repository.AuditLog
+}
+
+func NewAuditService(repo repository.AuditLog) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// Audit is best effort - failure to write the entry is logged and does not fail the operation
+func (s *AuditService) Record(actor domain.Actor, action string, eventId int, opErr error) {
+	outcome := AUDIT_OUTCOME_SUCCESS
+	if opErr != nil {
+		outcome = opErr.Error()
+	}
+
+	err := s.repo.Record(domain.AuditEntry{
+		OrganizationId: actor.OrganizationId,
+		ActorId:        actor.UserId,
+		PrincipalId:    actor.PrincipalId,
+		Action:         action,
+		EventId:        eventId,
+		Outcome:        outcome,
+	})
+	if err != nil {
+		logger.LogServiceIssue("audit", err)
+	}
+}
+
+// Operations done by the user and operations done on behalf of the user by delegates
+func (s *AuditService) GetAll(actor domain.Actor) ([]domain.AuditEntry, error) {
+	return s.repo.GetByUser(actor.OrganizationId, actor.UserId, AUDIT_LOG_LIMIT)
+}
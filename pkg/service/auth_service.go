@@ -2,6 +2,7 @@ package service
 
 import (
 	"crypto/sha1"
+	"database/sql"
 	"errors"
 	"fmt"
 	"time"
@@ -9,30 +10,70 @@ import (
 	"github.com/dgrijalva/jwt-go"
 	"github.com/salesforceanton/events-api/config"
 	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/mailer"
 	"github.com/salesforceanton/events-api/pkg/repository"
+	"github.com/salesforceanton/events-api/pkg/signing"
 )
 
+var ErrInvalidCredentials = errors.New("No registered User with this credentials")
+
+// Lifetime of access tokens issued on sign-in
+const ACCESS_TOKEN_TTL = time.Hour * 12
+
 type AuthService struct {
-	repo repository.Authorization
-	cfg  *config.Config
+	repo    repository.Authorization
+	mfaRepo repository.Mfa
+	orgs    repository.Organizations
+	tokens  *userTokens
+	keys    *SigningKeysService
+	mailer  mailer.Mailer
+	cfg     *config.Config
 }
 
 type TokenClaims struct {
 	jwt.StandardClaims
 	UserId int `json:"user_id"`
+	// Active organization, empty in tokens issued before organizations were introduced
+	OrganizationId int `json:"org_id,omitempty"`
+	// Empty for access tokens, short-lived tokens of sign-in steps have their own purpose
+	Purpose string `json:"purpose,omitempty"`
 }
 
-func NewAuthService(repo repository.Authorization, cfg *config.Config) *AuthService {
+func NewAuthService(
+	repo repository.Authorization,
+	tokensRepo repository.UserTokens,
+	mfaRepo repository.Mfa,
+	orgs repository.Organizations,
+	keys *SigningKeysService,
+	mailer mailer.Mailer,
+	cfg *config.Config,
+) *AuthService {
 	return &AuthService{
-		repo: repo,
-		cfg:  cfg,
+		repo:    repo,
+		mfaRepo: mfaRepo,
+		orgs:    orgs,
+		tokens:  newUserTokens(tokensRepo, cfg.TokenSecret),
+		keys:    keys,
+		mailer:  mailer,
+		cfg:     cfg,
 	}
 }
 
 func (s *AuthService) CreateUser(user domain.User) (int, error) {
 	user.Password = s.generatePasswordHash(user.Password)
 
-	return s.repo.CreateUser(user)
+	id, err := s.repo.CreateUser(user)
+	if err != nil {
+		return 0, err
+	}
+
+	// User is already registered at this point - verification email can be requested again later
+	if err := s.sendVerificationEmail(id, user.Email); err != nil {
+		logger.LogServiceIssue("auth", err)
+	}
+
+	return id, nil
 }
 
 func (s *AuthService) generatePasswordHash(password string) string {
@@ -44,44 +85,236 @@ func (s *AuthService) generatePasswordHash(password string) string {
 	return fmt.Sprintf("%x", hash.Sum([]byte(passwordSecret)))
 }
 
-func (s *AuthService) GenerateToken(username, password string) (string, error) {
+// Check credentials and return access token.
+// Users with MFA receive a challenge token instead which is exchanged for access token with verification code
+func (s *AuthService) GenerateToken(username, password string) (domain.SignInResult, error) {
 	user, err := s.repo.GetUser(username, s.generatePasswordHash(password))
 
 	if err != nil {
-		return "", errors.New("No registered User with this credentials")
+		return domain.SignInResult{}, ErrInvalidCredentials
+	}
+
+	return s.signIn(user)
+}
+
+// Issue access token or MFA challenge for the user who has passed the first authentication factor
+func (s *AuthService) signIn(user domain.User) (domain.SignInResult, error) {
+	if s.cfg.RequireVerifiedEmail && !user.EmailVerified {
+		return domain.SignInResult{}, errors.New("Email address is not verified")
+	}
+
+	if user.MfaEnabled {
+		challenge, err := s.signChallenge(user.Id, MFA_CHALLENGE_PURPOSE)
+		return domain.SignInResult{MfaRequired: true, ChallengeToken: challenge}, err
+	}
+
+	if user.MfaRequired {
+		challenge, err := s.signChallenge(user.Id, MFA_ENROLL_PURPOSE)
+		return domain.SignInResult{MfaEnrollmentRequired: true, ChallengeToken: challenge}, err
+	}
+
+	token, err := s.issueAccessToken(user.Id)
+
+	return domain.SignInResult{Token: token}, err
+}
+
+// Access token is issued for the default organization of the user, other organizations are switched later
+func (s *AuthService) issueAccessToken(userId int) (string, error) {
+	organizationId, err := s.orgs.GetDefault(userId)
+	if err != nil {
+		return "", err
+	}
+
+	return s.signToken(userId, organizationId, "", ACCESS_TOKEN_TTL)
+}
+
+// Issue access token for another organization of the user
+func (s *AuthService) SwitchOrganization(userId, organizationId int) (string, error) {
+	_, err := s.orgs.GetMember(organizationId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrOrganizationNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return s.signToken(userId, organizationId, "", ACCESS_TOKEN_TTL)
+}
+
+func (s *AuthService) signToken(userId, organizationId int, purpose string, ttl time.Duration) (string, error) {
+	claims := &TokenClaims{
+		jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(ttl).Unix(),
+			IssuedAt:  time.Now().Unix(),
+		},
+		userId,
+		organizationId,
+		purpose,
+	}
+
+	return s.signClaims(claims)
+}
+
+// Challenge of a sign-in step has jti registered as single-use token, it is burned when the step is passed
+func (s *AuthService) signChallenge(userId int, purpose string) (string, error) {
+	jti, err := s.tokens.IssueNonce(userId, purpose, s.cfg.MfaChallengeTTL)
+	if err != nil {
+		return "", err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &TokenClaims{
+	claims := &TokenClaims{
 		jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Hour * 12).Unix(),
+			Id:        jti,
+			ExpiresAt: time.Now().Add(s.cfg.MfaChallengeTTL).Unix(),
 			IssuedAt:  time.Now().Unix(),
 		},
-		user.Id,
-	})
+		userId,
+		0,
+		purpose,
+	}
+
+	return s.signClaims(claims)
+}
+
+func (s *AuthService) signClaims(claims *TokenClaims) (string, error) {
+	if !signing.IsAsymmetric(s.cfg.TokenSigningAlg) {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.TokenSecret))
+	}
+
+	kid, method, key, err := s.keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
 
-	return token.SignedString([]byte(s.cfg.TokenSecret))
+	return token.SignedString(key)
 }
 
-func (s *AuthService) ParseToken(accessToken string) (int, error) {
+// Parse access token and check that the user is still a member of the token organization
+func (s *AuthService) ParseToken(accessToken string) (domain.Actor, error) {
+	claims, err := s.parseClaims(accessToken)
+	if err != nil {
+		return domain.Actor{}, err
+	}
+
+	if claims.Purpose != "" {
+		return domain.Actor{}, errors.New("Token can not be used as Access Token")
+	}
+
+	actor := domain.Actor{UserId: claims.UserId, OrganizationId: claims.OrganizationId}
+	if actor.OrganizationId == 0 {
+		actor.OrganizationId, err = s.orgs.GetDefault(actor.UserId)
+		return actor, err
+	}
+
+	_, err = s.orgs.GetMember(actor.OrganizationId, actor.UserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Actor{}, errors.New("User is not a member of the token organization")
+	}
+
+	return actor, err
+}
+
+func (s *AuthService) parseClaims(accessToken string) (*TokenClaims, error) {
 	token, err := jwt.ParseWithClaims(
 		accessToken,
 		&TokenClaims{},
 		func(t *jwt.Token) (interface{}, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok {
+				// HS256 tokens issued before migration to asymmetric keys
+				if signing.IsAsymmetric(s.cfg.TokenSigningAlg) && !s.cfg.AcceptLegacyTokens {
+					return nil, errors.New("Invalid Signing Method")
+				}
+				return []byte(s.cfg.TokenSecret), nil
+			}
+
+			if t.Method.Alg() != signing.ALG_RS256 && t.Method.Alg() != signing.ALG_EDDSA {
 				return nil, errors.New("Invalid Signing Method")
 			}
-			return []byte(s.cfg.TokenSecret), nil
+
+			kid, _ := t.Header["kid"].(string)
+			return s.keys.VerificationKey(kid, t.Method.Alg())
 		},
 	)
 
 	if err != nil {
-		return 0, errors.New("Error with parsing Access Token")
+		return nil, errors.New("Error with parsing Access Token")
 	}
 
 	claims, ok := token.Claims.(*TokenClaims)
 	if !ok {
-		return 0, errors.New("Error with parsing Access Token")
+		return nil, errors.New("Error with parsing Access Token")
+	}
+
+	return claims, nil
+}
+
+func (s *AuthService) VerifyEmail(token string) error {
+	userId, err := s.tokens.Consume(token, VERIFY_EMAIL_PURPOSE)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.SetEmailVerified(userId)
+}
+
+// Send reset link if user with defined email exists.
+// Unknown emails are not reported to the caller to prevent accounts enumeration
+func (s *AuthService) ForgotPassword(email string) error {
+	user, err := s.repo.GetUserByEmail(email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := s.tokens.Issue(user.Id, RESET_PASSWORD_PURPOSE, s.cfg.ResetPasswordTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(resetPasswordMessage(s.cfg.AppUrl, user.Email, token))
+}
+
+func (s *AuthService) ResetPassword(token, password string) error {
+	userId, err := s.tokens.Consume(token, RESET_PASSWORD_PURPOSE)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdatePassword(userId, s.generatePasswordHash(password)); err != nil {
+		return err
+	}
+
+	// Other reset links sent before are not valid anymore
+	return s.tokens.Revoke(userId, RESET_PASSWORD_PURPOSE)
+}
+
+// Send a new verification link, previous links become invalid
+func (s *AuthService) ResendVerification(email string) error {
+	user, err := s.repo.GetUserByEmail(email)
+	if errors.Is(err, sql.ErrNoRows) || user.EmailVerified {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokens.Revoke(user.Id, VERIFY_EMAIL_PURPOSE); err != nil {
+		return err
+	}
+
+	return s.sendVerificationEmail(user.Id, user.Email)
+}
+
+func (s *AuthService) sendVerificationEmail(userId int, email string) error {
+	token, err := s.tokens.Issue(userId, VERIFY_EMAIL_PURPOSE, s.cfg.VerifyEmailTokenTTL)
+	if err != nil {
+		return err
 	}
 
-	return claims.UserId, nil
+	return s.mailer.Send(verifyEmailMessage(s.cfg.AppUrl, email, token))
 }
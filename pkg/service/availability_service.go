@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+var (
+	ErrDuplicateWeekday    = errors.New("Working hours are set twice for the same weekday")
+	ErrInvalidOutOfOffice  = errors.New("Out-of-office is invalid, end should be after start")
+	ErrOutOfOfficeNotFound = errors.New("Out-of-office is not found")
+)
+
+// Working hours and out-of-office of the user, they are the same in all organizations of the user
+type AvailabilityService struct {
+	repo repository.Availability
+}
+
+func NewAvailabilityService(repo repository.Availability) *AvailabilityService {
+	return &AvailabilityService{repo: repo}
+}
+
+func (s *AvailabilityService) Get(actor domain.Actor) (domain.Availability, error) {
+	availability, err := s.repo.GetAvailability([]int{actor.UserId})
+	if err != nil {
+		return domain.Availability{}, err
+	}
+	if len(availability) == 0 {
+		return domain.Availability{}, ErrUserNotFound
+	}
+
+	result := availability[0]
+	if result.OutOfOffice, err = s.repo.GetUpcomingOutOfOffice(actor.UserId); err != nil {
+		return domain.Availability{}, err
+	}
+	if result.OutOfOffice == nil {
+		result.OutOfOffice = []domain.OutOfOffice{}
+	}
+
+	return result, nil
+}
+
+func (s *AvailabilityService) Save(actor domain.Actor, request domain.SaveAvailabilityRequest) (domain.Availability, error) {
+	if request.TimezoneId == "" {
+		request.TimezoneId = "UTC"
+	}
+	if _, err := time.LoadLocation(request.TimezoneId); err != nil {
+		return domain.Availability{}, ErrUnknownTimezone
+	}
+
+	weekdays := make(map[int]bool)
+	for i, day := range request.WorkingHours {
+		if weekdays[day.Weekday] {
+			return domain.Availability{}, ErrDuplicateWeekday
+		}
+		weekdays[day.Weekday] = true
+
+		start, end, err := parseWorkingHours(domain.WorkingHours{Start: day.Start, End: day.End})
+		if err != nil {
+			return domain.Availability{}, err
+		}
+
+		request.WorkingHours[i].Start = start.Format(WORKING_HOURS_LAYOUT)
+		request.WorkingHours[i].End = end.Format(WORKING_HOURS_LAYOUT)
+	}
+
+	if err := s.repo.SaveAvailability(actor.UserId, request); err != nil {
+		return domain.Availability{}, err
+	}
+
+	return s.Get(actor)
+}
+
+func (s *AvailabilityService) AddOutOfOffice(actor domain.Actor, request domain.SaveOutOfOfficeRequest) (int, error) {
+	if !request.EndsAt.After(request.StartsAt) {
+		return 0, ErrInvalidOutOfOffice
+	}
+
+	return s.repo.CreateOutOfOffice(actor.UserId, request)
+}
+
+func (s *AvailabilityService) DeleteOutOfOffice(actor domain.Actor, outOfOfficeId int) error {
+	deleted, err := s.repo.DeleteOutOfOffice(actor.UserId, outOfOfficeId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrOutOfOfficeNotFound
+	}
+
+	return nil
+}
+
+// Users who share free/busy with other members of organization
+func (s *AvailabilityService) freeBusyShared(userIds []int) (map[int]bool, error) {
+	availability, err := s.repo.GetAvailability(userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]bool, len(availability))
+	for _, user := range availability {
+		result[user.UserId] = user.FreeBusyShared
+	}
+
+	return result, nil
+}
+
+// Working hours of a user by weekday in timezone of the user
+type workSchedule struct {
+	location *time.Location
+	days     map[time.Weekday][2]time.Time
+}
+
+// Work schedules of the users, unknown timezone is treated as UTC like for events
+func (s *AvailabilityService) schedules(userIds []int) (map[int]workSchedule, error) {
+	availability, err := s.repo.GetAvailability(userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]workSchedule, len(availability))
+	for _, user := range availability {
+		location, err := time.LoadLocation(user.TimezoneId)
+		if err != nil {
+			location = time.UTC
+		}
+
+		schedule := workSchedule{location: location, days: make(map[time.Weekday][2]time.Time)}
+		for _, day := range user.WorkingHours {
+			start, end, err := parseWorkingHours(domain.WorkingHours{Start: day.Start, End: day.End})
+			if err != nil {
+				return nil, err
+			}
+
+			schedule.days[time.Weekday(day.Weekday)] = [2]time.Time{start, end}
+		}
+
+		result[user.UserId] = schedule
+	}
+
+	return result, nil
+}
+
+// Time range should be inside working hours of one day, users without working hours work at any time
+func (w workSchedule) covers(start, end time.Time) bool {
+	if len(w.days) == 0 {
+		return true
+	}
+
+	start = start.In(w.location)
+	hours, ok := w.days[start.Weekday()]
+	if !ok {
+		return false
+	}
+
+	return !start.Before(onDay(start, hours[0])) && !end.After(onDay(start, hours[1]))
+}
+
+func (s *AvailabilityService) outOfOffice(userIds []int, startsAt, endsAt time.Time) ([]domain.OutOfOffice, error) {
+	return s.repo.GetOutOfOffice(userIds, startsAt, endsAt)
+}
@@ -0,0 +1,387 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+const (
+	BOOKING_SLOT_STEP = 15 * time.Minute
+	// Slots are listed for a week by default
+	BOOKING_SLOTS_WINDOW     = 7 * 24 * time.Hour
+	BOOKING_SLOTS_MAX_WINDOW = 31 * 24 * time.Hour
+	BOOKING_DAY_LAYOUT       = "2006-01-02"
+)
+
+var (
+	ErrBookingPageNotFound      = errors.New("Booking page is not found")
+	ErrOverlappingBookingWindow = errors.New("Availability windows of the same weekday overlap")
+	ErrInvalidBookingWindow     = errors.New("Time window is invalid, to should be after from and not later than 31 days")
+	ErrSlotUnavailable          = errors.New("Slot is not available")
+)
+
+// Booking pages of the host in the active organization and booking of their slots by guests.
+// Slots are free time of the host in availability windows of the page, with buffers around
+type BookingPagesService struct {
+	repo         repository.BookingPages
+	events       repository.Events
+	availability *AvailabilityService
+	calendars    *CalendarsService
+}
+
+func NewBookingPagesService(
+	repo repository.BookingPages, events repository.Events, availability *AvailabilityService, calendars *CalendarsService,
+) *BookingPagesService {
+	return &BookingPagesService{repo: repo, events: events, availability: availability, calendars: calendars}
+}
+
+func (s *BookingPagesService) GetAll(actor domain.Actor) ([]domain.BookingPage, error) {
+	return s.repo.GetByHost(actor.OrganizationId, actor.EffectiveUserId())
+}
+
+func (s *BookingPagesService) GetById(actor domain.Actor, pageId int) (domain.BookingPage, error) {
+	return s.page(actor.OrganizationId, actor.EffectiveUserId(), pageId)
+}
+
+// Page gets calendar and timezone of the calendar when they are not set
+func (s *BookingPagesService) Create(actor domain.Actor, request domain.SaveBookingPageRequest) (int, error) {
+	if isReadOnlyDelegate(actor) {
+		return 0, ErrForbidden
+	}
+
+	page := domain.BookingPage{OrganizationId: actor.OrganizationId, HostId: actor.EffectiveUserId(), IsActive: true}
+	if err := s.applyRequest(&page, request); err != nil {
+		return 0, err
+	}
+
+	slug, err := generateSlug(page.Title)
+	if err != nil {
+		return 0, err
+	}
+	page.Slug = slug
+
+	return s.repo.Create(page)
+}
+
+func (s *BookingPagesService) Update(
+	actor domain.Actor, pageId int, request domain.SaveBookingPageRequest,
+) (domain.BookingPage, error) {
+	if isReadOnlyDelegate(actor) {
+		return domain.BookingPage{}, ErrForbidden
+	}
+
+	page, err := s.page(actor.OrganizationId, actor.EffectiveUserId(), pageId)
+	if err != nil {
+		return domain.BookingPage{}, err
+	}
+
+	if err := s.applyRequest(&page, request); err != nil {
+		return domain.BookingPage{}, err
+	}
+
+	result, err := s.repo.Update(page)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.BookingPage{}, ErrBookingPageNotFound
+	}
+
+	return result, err
+}
+
+// Events which were booked stay in the calendar of the host
+func (s *BookingPagesService) Delete(actor domain.Actor, pageId int) error {
+	if isReadOnlyDelegate(actor) {
+		return ErrForbidden
+	}
+
+	deleted, err := s.repo.Delete(actor.OrganizationId, actor.EffectiveUserId(), pageId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrBookingPageNotFound
+	}
+
+	return nil
+}
+
+func (s *BookingPagesService) GetPage(slug string) (domain.PublicBookingPage, error) {
+	page, err := s.pageBySlug(slug)
+	if err != nil {
+		return domain.PublicBookingPage{}, err
+	}
+
+	return domain.PublicBookingPage{
+		Slug:            page.Slug,
+		Title:           page.Title,
+		Description:     page.Description,
+		DurationMinutes: page.DurationMinutes,
+		TimezoneId:      page.TimezoneId,
+	}, nil
+}
+
+// Open slots in the time range, a week from now by default
+func (s *BookingPagesService) GetSlots(slug string, from, to time.Time) ([]domain.BookingSlot, error) {
+	page, err := s.pageBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.IsZero() {
+		from = time.Now()
+	}
+	if to.IsZero() {
+		to = from.Add(BOOKING_SLOTS_WINDOW)
+	}
+	if !to.After(from) || to.Sub(from) > BOOKING_SLOTS_MAX_WINDOW {
+		return nil, ErrInvalidBookingWindow
+	}
+
+	return s.openSlots(page, from, to)
+}
+
+// Slot is checked again when the event is created, so concurrent guests can not book the same time
+func (s *BookingPagesService) Book(slug string, request domain.BookingRequest) (domain.Booking, error) {
+	page, err := s.pageBySlug(slug)
+	if err != nil {
+		return domain.Booking{}, err
+	}
+
+	duration := time.Duration(page.DurationMinutes) * time.Minute
+	start := request.Start
+	end := start.Add(duration)
+
+	slots, err := s.openSlots(page, start, end)
+	if err != nil {
+		return domain.Booking{}, err
+	}
+	if len(slots) == 0 || !slots[0].Start.Equal(start) {
+		return domain.Booking{}, ErrSlotUnavailable
+	}
+
+	location := pageLocation(page)
+	local := start.In(location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+
+	event := domain.SaveEventRequest{
+		Title:         fmt.Sprintf("%s: %s", page.Title, request.Name),
+		Description:   fmt.Sprintf("Booked by %s <%s>", request.Name, request.Email),
+		TimezoneId:    location.String(),
+		StartDatetime: local.Format(WALL_TIME_LAYOUT),
+		EndDatetime:   end.In(location).Format(WALL_TIME_LAYOUT),
+		StartsAt:      start,
+		EndsAt:        end,
+		CalendarId:    page.CalendarId,
+		Visibility:    domain.EVENT_VISIBILITY_PRIVATE,
+		Status:        domain.EVENT_STATUS_PUBLISHED,
+	}
+	booking := domain.Booking{GuestName: request.Name, GuestEmail: request.Email, StartsAt: start, EndsAt: end}
+
+	from, to := bufferedRange(page, start, end)
+	result, booked, err := s.repo.Book(page, event, booking, from, to, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return domain.Booking{}, err
+	}
+	if !booked {
+		return domain.Booking{}, ErrSlotUnavailable
+	}
+
+	return result, nil
+}
+
+// Slots in availability windows of the page when the host has no events and out-of-office with buffers,
+// days with max bookings are skipped
+func (s *BookingPagesService) openSlots(page domain.BookingPage, from, to time.Time) ([]domain.BookingSlot, error) {
+	if now := time.Now(); from.Before(now) {
+		from = now
+	}
+
+	location := pageLocation(page)
+	duration := time.Duration(page.DurationMinutes) * time.Minute
+	busyFrom, busyTo := bufferedRange(page, from, to)
+
+	// Events of the host in other organizations take the time too
+	events, err := s.events.GetBusy(page.HostId, busyFrom, busyTo)
+	if err != nil {
+		return nil, err
+	}
+
+	outOfOffice, err := s.availability.outOfOffice([]int{page.HostId}, busyFrom, busyTo)
+	if err != nil {
+		return nil, err
+	}
+
+	intervals := make([]domain.BusyInterval, 0, len(events)+len(outOfOffice))
+	intervals = append(intervals, events...)
+	for _, absence := range outOfOffice {
+		intervals = append(intervals, domain.BusyInterval{Start: absence.StartsAt, End: absence.EndsAt})
+	}
+	busy := mergeIntervals(intervals, busyFrom, busyTo)
+
+	first := from.In(location)
+	firstDay := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, location)
+
+	// Bookings of the whole last day are counted like on booking
+	last := to.In(location)
+	lastDayEnd := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, location).AddDate(0, 0, 1)
+
+	bookings, err := s.repo.GetBookings(page.Id, firstDay, lastDayEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	booked := make(map[string]int)
+	for _, booking := range bookings {
+		booked[booking.StartsAt.In(location).Format(BOOKING_DAY_LAYOUT)]++
+	}
+
+	windows, err := parseBookingWindows(page.Windows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []domain.BookingSlot{}
+	for day := firstDay; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if page.MaxPerDay > 0 && booked[day.Format(BOOKING_DAY_LAYOUT)] >= page.MaxPerDay {
+			continue
+		}
+
+		for _, window := range windows[day.Weekday()] {
+			windowEnd := onDay(day, window[1])
+			for start := onDay(day, window[0]); !start.Add(duration).After(windowEnd); start = start.Add(BOOKING_SLOT_STEP) {
+				end := start.Add(duration)
+				if start.Before(from) || end.After(to) {
+					continue
+				}
+
+				if slotFrom, slotTo := bufferedRange(page, start, end); isFree(busy, slotFrom, slotTo) {
+					result = append(result, domain.BookingSlot{Start: start, End: end})
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Calendar, timezone and windows are validated, current values are kept when they are not set
+func (s *BookingPagesService) applyRequest(page *domain.BookingPage, request domain.SaveBookingPageRequest) error {
+	if request.CalendarId != 0 || page.CalendarId == 0 {
+		calendar, err := s.calendars.eventCalendar(page.OrganizationId, page.HostId, request.CalendarId)
+		if err != nil {
+			return err
+		}
+
+		page.CalendarId = calendar.Id
+		if request.TimezoneId == "" && page.TimezoneId == "" {
+			request.TimezoneId = calendar.TimezoneId
+		}
+	}
+
+	if request.TimezoneId == "" {
+		request.TimezoneId = page.TimezoneId
+	}
+	if request.TimezoneId == "" {
+		request.TimezoneId = "UTC"
+	}
+	if _, err := time.LoadLocation(request.TimezoneId); err != nil {
+		return ErrUnknownTimezone
+	}
+
+	windows := make([]domain.BookingWindow, len(request.Windows))
+	for i, window := range request.Windows {
+		start, end, err := parseWorkingHours(domain.WorkingHours{Start: window.Start, End: window.End})
+		if err != nil {
+			return err
+		}
+
+		windows[i] = domain.BookingWindow{
+			Weekday: window.Weekday,
+			Start:   start.Format(WORKING_HOURS_LAYOUT),
+			End:     end.Format(WORKING_HOURS_LAYOUT),
+		}
+	}
+	if _, err := parseBookingWindows(windows); err != nil {
+		return err
+	}
+
+	page.Title = request.Title
+	page.Description = request.Description
+	page.DurationMinutes = request.DurationMinutes
+	page.BufferBeforeMinutes = request.BufferBeforeMinutes
+	page.BufferAfterMinutes = request.BufferAfterMinutes
+	page.MaxPerDay = request.MaxPerDay
+	page.TimezoneId = request.TimezoneId
+	page.Windows = windows
+	if request.IsActive != nil {
+		page.IsActive = *request.IsActive
+	}
+
+	return nil
+}
+
+func (s *BookingPagesService) page(organizationId, hostId, pageId int) (domain.BookingPage, error) {
+	page, err := s.repo.GetById(organizationId, hostId, pageId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.BookingPage{}, ErrBookingPageNotFound
+	}
+
+	return page, err
+}
+
+func (s *BookingPagesService) pageBySlug(slug string) (domain.BookingPage, error) {
+	page, err := s.repo.GetBySlug(slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.BookingPage{}, ErrBookingPageNotFound
+	}
+
+	return page, err
+}
+
+// Windows by weekday ordered by start, windows of the same weekday should not overlap
+func parseBookingWindows(windows []domain.BookingWindow) (map[time.Weekday][][2]time.Time, error) {
+	result := make(map[time.Weekday][][2]time.Time)
+	for _, window := range windows {
+		start, end, err := parseWorkingHours(domain.WorkingHours{Start: window.Start, End: window.End})
+		if err != nil {
+			return nil, err
+		}
+
+		weekday := time.Weekday(window.Weekday)
+		result[weekday] = append(result[weekday], [2]time.Time{start, end})
+	}
+
+	for _, dayWindows := range result {
+		sort.Slice(dayWindows, func(i, j int) bool {
+			return dayWindows[i][0].Before(dayWindows[j][0])
+		})
+
+		for i := 1; i < len(dayWindows); i++ {
+			if dayWindows[i][0].Before(dayWindows[i-1][1]) {
+				return nil, ErrOverlappingBookingWindow
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func bufferedRange(page domain.BookingPage, start, end time.Time) (time.Time, time.Time) {
+	return start.Add(-time.Duration(page.BufferBeforeMinutes) * time.Minute),
+		end.Add(time.Duration(page.BufferAfterMinutes) * time.Minute)
+}
+
+// Timezone is validated when the page is saved
+func pageLocation(page domain.BookingPage) *time.Location {
+	location, err := time.LoadLocation(page.TimezoneId)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
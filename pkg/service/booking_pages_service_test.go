@@ -0,0 +1,249 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubBookingPagesRepo struct {
+	repository.BookingPages
+	bookings []domain.Booking
+}
+
+func (r stubBookingPagesRepo) GetBookings(pageId int, from, to time.Time) ([]domain.Booking, error) {
+	var result []domain.Booking
+	for _, booking := range r.bookings {
+		if !booking.StartsAt.Before(from) && booking.StartsAt.Before(to) {
+			result = append(result, booking)
+		}
+	}
+
+	return result, nil
+}
+
+func TestBookingPagesService_openSlots(t *testing.T) {
+	// Init Test Table
+	page := domain.BookingPage{
+		Id:              1,
+		OrganizationId:  1,
+		HostId:          2,
+		DurationMinutes: 30,
+		TimezoneId:      "UTC",
+		Windows: []domain.BookingWindow{
+			{Weekday: 1, Start: "09:00", End: "10:30"},
+			{Weekday: 2, Start: "09:00", End: "09:30"},
+		},
+	}
+
+	withPage := func(change func(page *domain.BookingPage)) domain.BookingPage {
+		result := page
+		change(&result)
+		return result
+	}
+
+	tests := []struct {
+		name          string
+		page          domain.BookingPage
+		from          time.Time
+		to            time.Time
+		busy          []domain.EventConflict
+		outOfOffice   []domain.OutOfOffice
+		bookings      []domain.Booking
+		expectedSlots []domain.BookingSlot
+	}{
+		{
+			name: "Free Window",
+			page: page,
+			from: testTime("2030-06-03T00:00:00Z"),
+			to:   testTime("2030-06-04T00:00:00Z"),
+			expectedSlots: []domain.BookingSlot{
+				{Start: testTime("2030-06-03T09:00:00Z"), End: testTime("2030-06-03T09:30:00Z")},
+				{Start: testTime("2030-06-03T09:15:00Z"), End: testTime("2030-06-03T09:45:00Z")},
+				{Start: testTime("2030-06-03T09:30:00Z"), End: testTime("2030-06-03T10:00:00Z")},
+				{Start: testTime("2030-06-03T09:45:00Z"), End: testTime("2030-06-03T10:15:00Z")},
+				{Start: testTime("2030-06-03T10:00:00Z"), End: testTime("2030-06-03T10:30:00Z")},
+			},
+		},
+		{
+			name: "Buffers On Window Edge",
+			page: withPage(func(page *domain.BookingPage) {
+				page.BufferBeforeMinutes = 15
+				page.BufferAfterMinutes = 15
+			}),
+			from: testTime("2030-06-03T09:00:00Z"),
+			to:   testTime("2030-06-03T10:30:00Z"),
+			busy: []domain.EventConflict{
+				{UserId: 2, StartsAt: testTime("2030-06-03T08:40:00Z"), EndsAt: testTime("2030-06-03T08:50:00Z")},
+				{UserId: 2, StartsAt: testTime("2030-06-03T10:35:00Z"), EndsAt: testTime("2030-06-03T11:00:00Z")},
+			},
+			expectedSlots: []domain.BookingSlot{
+				{Start: testTime("2030-06-03T09:15:00Z"), End: testTime("2030-06-03T09:45:00Z")},
+				{Start: testTime("2030-06-03T09:30:00Z"), End: testTime("2030-06-03T10:00:00Z")},
+				{Start: testTime("2030-06-03T09:45:00Z"), End: testTime("2030-06-03T10:15:00Z")},
+			},
+		},
+		{
+			name: "Busy Host And Out Of Office",
+			page: page,
+			from: testTime("2030-06-03T00:00:00Z"),
+			to:   testTime("2030-06-04T00:00:00Z"),
+			busy: []domain.EventConflict{
+				{UserId: 2, StartsAt: testTime("2030-06-03T09:30:00Z"), EndsAt: testTime("2030-06-03T10:00:00Z")},
+			},
+			outOfOffice: []domain.OutOfOffice{
+				{UserId: 2, StartsAt: testTime("2030-06-03T10:15:00Z"), EndsAt: testTime("2030-06-03T12:00:00Z")},
+			},
+			expectedSlots: []domain.BookingSlot{
+				{Start: testTime("2030-06-03T09:00:00Z"), End: testTime("2030-06-03T09:30:00Z")},
+			},
+		},
+		{
+			name: "Max Per Day Reached",
+			page: withPage(func(page *domain.BookingPage) {
+				page.MaxPerDay = 1
+			}),
+			from: testTime("2030-06-03T00:00:00Z"),
+			to:   testTime("2030-06-05T00:00:00Z"),
+			bookings: []domain.Booking{
+				{StartsAt: testTime("2030-06-03T12:00:00Z"), EndsAt: testTime("2030-06-03T12:30:00Z")},
+			},
+			expectedSlots: []domain.BookingSlot{
+				{Start: testTime("2030-06-04T09:00:00Z"), End: testTime("2030-06-04T09:30:00Z")},
+			},
+		},
+		{
+			name: "Max Per Day Reached After Window End",
+			page: withPage(func(page *domain.BookingPage) {
+				page.MaxPerDay = 1
+			}),
+			from: testTime("2030-06-03T00:00:00Z"),
+			to:   testTime("2030-06-03T10:00:00Z"),
+			bookings: []domain.Booking{
+				{StartsAt: testTime("2030-06-03T12:00:00Z"), EndsAt: testTime("2030-06-03T12:30:00Z")},
+			},
+			expectedSlots: []domain.BookingSlot{},
+		},
+		{
+			name: "Page Timezone",
+			page: withPage(func(page *domain.BookingPage) {
+				page.TimezoneId = "Europe/Berlin"
+				page.Windows = []domain.BookingWindow{{Weekday: 1, Start: "09:00", End: "09:30"}}
+			}),
+			from: testTime("2030-06-03T00:00:00Z"),
+			to:   testTime("2030-06-04T00:00:00Z"),
+			expectedSlots: []domain.BookingSlot{
+				{Start: testTime("2030-06-03T07:00:00Z"), End: testTime("2030-06-03T07:30:00Z")},
+			},
+		},
+		{
+			name:          "Past Window",
+			page:          page,
+			from:          testTime("2020-06-01T00:00:00Z"),
+			to:            testTime("2020-06-02T00:00:00Z"),
+			expectedSlots: []domain.BookingSlot{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			bookingPages := &BookingPagesService{
+				repo:   stubBookingPagesRepo{bookings: test.bookings},
+				events: stubEventsRepo{busy: test.busy},
+				availability: NewAvailabilityService(stubAvailabilityRepo{
+					outOfOffice: test.outOfOffice,
+				}),
+			}
+
+			// Get Slots
+			result, err := bookingPages.openSlots(test.page, test.from, test.to)
+			for i := range result {
+				result[i].Start, result[i].End = result[i].Start.UTC(), result[i].End.UTC()
+			}
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedSlots, result)
+		})
+	}
+}
+
+func TestParseBookingWindows(t *testing.T) {
+	// Init Test Table
+	clock := func(value string) time.Time {
+		result, _ := time.Parse(WORKING_HOURS_LAYOUT, value)
+		return result
+	}
+
+	tests := []struct {
+		name          string
+		windows       []domain.BookingWindow
+		expected      map[time.Weekday][][2]time.Time
+		expectedError error
+	}{
+		{
+			name:     "Empty",
+			windows:  []domain.BookingWindow{},
+			expected: map[time.Weekday][][2]time.Time{},
+		},
+		{
+			name: "Ordered By Start",
+			windows: []domain.BookingWindow{
+				{Weekday: 1, Start: "13:00", End: "17:00"},
+				{Weekday: 1, Start: "09:00", End: "12:00"},
+			},
+			expected: map[time.Weekday][][2]time.Time{
+				time.Monday: {{clock("09:00"), clock("12:00")}, {clock("13:00"), clock("17:00")}},
+			},
+		},
+		{
+			name: "Touching Windows",
+			windows: []domain.BookingWindow{
+				{Weekday: 1, Start: "09:00", End: "12:00"},
+				{Weekday: 1, Start: "12:00", End: "15:00"},
+			},
+			expected: map[time.Weekday][][2]time.Time{
+				time.Monday: {{clock("09:00"), clock("12:00")}, {clock("12:00"), clock("15:00")}},
+			},
+		},
+		{
+			name: "Same Time On Different Weekdays",
+			windows: []domain.BookingWindow{
+				{Weekday: 0, Start: "09:00", End: "12:00"},
+				{Weekday: 6, Start: "09:00", End: "12:00"},
+			},
+			expected: map[time.Weekday][][2]time.Time{
+				time.Sunday:   {{clock("09:00"), clock("12:00")}},
+				time.Saturday: {{clock("09:00"), clock("12:00")}},
+			},
+		},
+		{
+			name: "Overlapping Windows",
+			windows: []domain.BookingWindow{
+				{Weekday: 1, Start: "09:00", End: "12:00"},
+				{Weekday: 1, Start: "11:00", End: "13:00"},
+			},
+			expectedError: ErrOverlappingBookingWindow,
+		},
+		{
+			name: "End Before Start",
+			windows: []domain.BookingWindow{
+				{Weekday: 1, Start: "12:00", End: "09:00"},
+			},
+			expectedError: ErrInvalidWorkingHours,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := parseBookingWindows(test.windows)
+
+			assert.ErrorIs(t, err, test.expectedError)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+var (
+	ErrCalendarNotFound       = errors.New("Calendar is not found")
+	ErrInvalidCalendarColor   = errors.New("Calendar color should be hex color like #3174ad")
+	ErrUnknownTimezone        = errors.New("Unknown timezone")
+	ErrDefaultCalendarRemoval = errors.New("Default calendar can not be deleted")
+	calendarColorPattern      = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// Calendars of the user in the active organization, delegates work with calendars of the principal
+type CalendarsService struct {
+	repo repository.Calendars
+}
+
+func NewCalendarsService(repo repository.Calendars) *CalendarsService {
+	return &CalendarsService{repo: repo}
+}
+
+func (s *CalendarsService) GetAll(actor domain.Actor) ([]domain.Calendar, error) {
+	if _, err := s.repo.GetDefault(actor.OrganizationId, actor.EffectiveUserId()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByOwner(actor.OrganizationId, actor.EffectiveUserId())
+}
+
+func (s *CalendarsService) GetById(actor domain.Actor, calendarId int) (domain.Calendar, error) {
+	return s.calendar(actor.OrganizationId, actor.EffectiveUserId(), calendarId)
+}
+
+func (s *CalendarsService) Create(actor domain.Actor, request domain.SaveCalendarRequest) (int, error) {
+	if isReadOnlyDelegate(actor) {
+		return 0, ErrForbidden
+	}
+
+	calendar, err := calendarFromRequest(request)
+	if err != nil {
+		return 0, err
+	}
+
+	calendar.OrganizationId = actor.OrganizationId
+	calendar.OwnerId = actor.EffectiveUserId()
+
+	return s.repo.Create(calendar)
+}
+
+func (s *CalendarsService) Update(actor domain.Actor, calendarId int, request domain.SaveCalendarRequest) (domain.Calendar, error) {
+	if isReadOnlyDelegate(actor) {
+		return domain.Calendar{}, ErrForbidden
+	}
+
+	calendar, err := calendarFromRequest(request)
+	if err != nil {
+		return domain.Calendar{}, err
+	}
+
+	calendar.Id = calendarId
+	calendar.OrganizationId = actor.OrganizationId
+	calendar.OwnerId = actor.EffectiveUserId()
+
+	result, err := s.repo.Update(calendar)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Calendar{}, ErrCalendarNotFound
+	}
+
+	return result, err
+}
+
+// Events of the calendar are deleted with it
+func (s *CalendarsService) Delete(actor domain.Actor, calendarId int) error {
+	if isReadOnlyDelegate(actor) {
+		return ErrForbidden
+	}
+
+	calendar, err := s.calendar(actor.OrganizationId, actor.EffectiveUserId(), calendarId)
+	if err != nil {
+		return err
+	}
+
+	if calendar.IsDefault {
+		return ErrDefaultCalendarRemoval
+	}
+
+	_, err = s.repo.Delete(actor.OrganizationId, actor.EffectiveUserId(), calendarId)
+
+	return err
+}
+
+// Calendar of the owner for the event, default calendar is used when calendar is not set
+func (s *CalendarsService) eventCalendar(organizationId, ownerId, calendarId int) (domain.Calendar, error) {
+	if calendarId == 0 {
+		return s.repo.GetDefault(organizationId, ownerId)
+	}
+
+	return s.calendar(organizationId, ownerId, calendarId)
+}
+
+// Calendars of other users are reported as not found
+func (s *CalendarsService) calendar(organizationId, ownerId, calendarId int) (domain.Calendar, error) {
+	calendar, err := s.repo.GetById(organizationId, ownerId, calendarId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Calendar{}, ErrCalendarNotFound
+	}
+
+	return calendar, err
+}
+
+func calendarFromRequest(request domain.SaveCalendarRequest) (domain.Calendar, error) {
+	color := request.Color
+	if color == "" {
+		color = domain.DEFAULT_CALENDAR_COLOR
+	}
+
+	if !calendarColorPattern.MatchString(color) {
+		return domain.Calendar{}, ErrInvalidCalendarColor
+	}
+
+	if request.TimezoneId != "" {
+		if _, err := time.LoadLocation(request.TimezoneId); err != nil {
+			return domain.Calendar{}, ErrUnknownTimezone
+		}
+	}
+
+	return domain.Calendar{Name: request.Name, Color: color, TimezoneId: request.TimezoneId}, nil
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+const CUSTOM_FIELD_MAX_LENGTH = 1000
+
+var customFieldKey = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var (
+	ErrCustomFieldNotFound       = errors.New("Custom field is not found")
+	ErrCustomFieldExists         = errors.New("Custom field with this key already exists")
+	ErrInvalidCustomFieldKey     = errors.New("Custom field key is invalid, use lowercase letters, digits and underscores")
+	ErrInvalidCustomFieldOptions = errors.New("Custom field options are invalid, only enum field has options and they are unique")
+	ErrUnknownCustomField        = errors.New("Unknown custom field")
+	ErrMissingCustomField        = errors.New("Required custom field is not set")
+	ErrInvalidCustomValue        = errors.New("Custom field value does not match its type")
+)
+
+// Schema of extra event data of the active organization, it is managed by organization owners and admins.
+// Values of events are validated by the schema when they are saved
+type CustomFieldsService struct {
+	repo repository.CustomFields
+	orgs *OrganizationsService
+}
+
+func NewCustomFieldsService(repo repository.CustomFields, orgs *OrganizationsService) *CustomFieldsService {
+	return &CustomFieldsService{
+		repo: repo,
+		orgs: orgs,
+	}
+}
+
+func (s *CustomFieldsService) GetAll(actor domain.Actor) ([]domain.CustomField, error) {
+	return s.repo.GetAll(actor.OrganizationId)
+}
+
+func (s *CustomFieldsService) Create(actor domain.Actor, request domain.SaveCustomFieldRequest) (int, error) {
+	if _, err := s.orgs.manager(actor.UserId, actor.OrganizationId); err != nil {
+		return 0, err
+	}
+
+	if !customFieldKey.MatchString(request.Key) {
+		return 0, ErrInvalidCustomFieldKey
+	}
+
+	if err := checkOptions(request.Type, request.Options); err != nil {
+		return 0, err
+	}
+
+	result, err := s.repo.Create(domain.CustomField{
+		OrganizationId: actor.OrganizationId,
+		Key:            request.Key,
+		Label:          request.Label,
+		Type:           request.Type,
+		Required:       request.Required,
+		Options:        request.Options,
+	})
+	if errors.Is(err, repository.ErrDuplicateCustomField) {
+		return 0, ErrCustomFieldExists
+	}
+
+	return result, err
+}
+
+// Changed schema is applied to values when events are saved next time
+func (s *CustomFieldsService) Update(
+	actor domain.Actor, fieldId int, request domain.SaveCustomFieldRequest,
+) (domain.CustomField, error) {
+	if _, err := s.orgs.manager(actor.UserId, actor.OrganizationId); err != nil {
+		return domain.CustomField{}, err
+	}
+
+	fields, err := s.repo.GetAll(actor.OrganizationId)
+	if err != nil {
+		return domain.CustomField{}, err
+	}
+
+	var field domain.CustomField
+	for _, current := range fields {
+		if current.Id == fieldId {
+			field = current
+		}
+	}
+	if field.Id == 0 {
+		return domain.CustomField{}, ErrCustomFieldNotFound
+	}
+
+	if err := checkOptions(field.Type, request.Options); err != nil {
+		return domain.CustomField{}, err
+	}
+
+	field.Label = request.Label
+	field.Required = request.Required
+	field.Options = request.Options
+
+	result, err := s.repo.Update(field)
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, ErrCustomFieldNotFound
+	}
+
+	return result, err
+}
+
+func (s *CustomFieldsService) Delete(actor domain.Actor, fieldId int) error {
+	if _, err := s.orgs.manager(actor.UserId, actor.OrganizationId); err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.Delete(actor.OrganizationId, fieldId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrCustomFieldNotFound
+	}
+
+	return nil
+}
+
+// Values should be known fields of the organization of matching type and required fields should be set.
+// Null values are dropped
+func (s *CustomFieldsService) validate(organizationId int, values domain.CustomValues) (domain.CustomValues, error) {
+	fields, err := s.fields(organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(domain.CustomValues, len(values))
+	for key, value := range values {
+		if value == nil {
+			continue
+		}
+
+		field, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCustomField, key)
+		}
+
+		if !matchesType(field, value) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCustomValue, key)
+		}
+
+		result[key] = value
+	}
+
+	for key, field := range fields {
+		if _, ok := result[key]; field.Required && !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingCustomField, key)
+		}
+	}
+
+	return result, nil
+}
+
+// Query values are converted to types of the fields, so they can be compared with stored values
+func (s *CustomFieldsService) filterValues(organizationId int, query map[string]string) (domain.CustomValues, error) {
+	if len(query) == 0 {
+		return nil, nil
+	}
+
+	fields, err := s.fields(organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(domain.CustomValues, len(query))
+	for key, raw := range query {
+		field, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCustomField, key)
+		}
+
+		var value interface{} = raw
+		switch field.Type {
+		case domain.CUSTOM_FIELD_TYPE_NUMBER:
+			value, err = strconv.ParseFloat(raw, 64)
+		case domain.CUSTOM_FIELD_TYPE_BOOLEAN:
+			value, err = strconv.ParseBool(raw)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCustomValue, key)
+		}
+
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+func (s *CustomFieldsService) fields(organizationId int) (map[string]domain.CustomField, error) {
+	fields, err := s.repo.GetAll(organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]domain.CustomField, len(fields))
+	for _, field := range fields {
+		result[field.Key] = field
+	}
+
+	return result, nil
+}
+
+// Values are decoded from JSON, so numbers are float64
+func matchesType(field domain.CustomField, value interface{}) bool {
+	switch field.Type {
+	case domain.CUSTOM_FIELD_TYPE_STRING:
+		text, ok := value.(string)
+		return ok && len(text) <= CUSTOM_FIELD_MAX_LENGTH
+	case domain.CUSTOM_FIELD_TYPE_NUMBER:
+		_, ok := value.(float64)
+		return ok
+	case domain.CUSTOM_FIELD_TYPE_BOOLEAN:
+		_, ok := value.(bool)
+		return ok
+	case domain.CUSTOM_FIELD_TYPE_ENUM:
+		option, ok := value.(string)
+		return ok && containsString(field.Options, option)
+	}
+
+	return false
+}
+
+func checkOptions(fieldType string, options []string) error {
+	if fieldType != domain.CUSTOM_FIELD_TYPE_ENUM {
+		if len(options) > 0 {
+			return ErrInvalidCustomFieldOptions
+		}
+		return nil
+	}
+
+	if len(options) == 0 {
+		return ErrInvalidCustomFieldOptions
+	}
+
+	for i, option := range options {
+		if containsString(options[:i], option) {
+			return ErrInvalidCustomFieldOptions
+		}
+	}
+
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+var (
+	ErrDelegationNotFound      = errors.New("Delegation is not found")
+	ErrUnknownDelegationAccess = errors.New("Unknown delegation access")
+	ErrInvalidDelegation       = errors.New("Calendar can not be delegated to its owner")
+)
+
+// Principal delegates access to own calendar to another member of the organization
+type DelegationsService struct {
+	repo repository.Delegations
+	orgs repository.Organizations
+}
+
+func NewDelegationsService(repo repository.Delegations, orgs repository.Organizations) *DelegationsService {
+	return &DelegationsService{
+		repo: repo,
+		orgs: orgs,
+	}
+}
+
+// Access level the actor has on the principal calendar
+func (s *DelegationsService) Access(actor domain.Actor, principalId int) (string, error) {
+	delegation, err := s.repo.Get(actor.OrganizationId, principalId, actor.UserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrDelegationNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return delegation.Access, nil
+}
+
+func (s *DelegationsService) GetAll(actor domain.Actor) ([]domain.Delegation, error) {
+	return s.repo.GetByUser(actor.OrganizationId, actor.UserId)
+}
+
+// Calendar can be delegated only to a member of the active organization
+func (s *DelegationsService) Save(actor domain.Actor, request domain.SaveDelegationRequest) (domain.Delegation, error) {
+	if request.Access != domain.DELEGATION_ACCESS_READ && request.Access != domain.DELEGATION_ACCESS_FULL {
+		return domain.Delegation{}, ErrUnknownDelegationAccess
+	}
+
+	if request.DelegateId == actor.UserId {
+		return domain.Delegation{}, ErrInvalidDelegation
+	}
+
+	_, err := s.orgs.GetMember(actor.OrganizationId, request.DelegateId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Delegation{}, ErrUserNotFound
+	}
+	if err != nil {
+		return domain.Delegation{}, err
+	}
+
+	return s.repo.Save(domain.Delegation{
+		OrganizationId: actor.OrganizationId,
+		PrincipalId:    actor.UserId,
+		DelegateId:     request.DelegateId,
+		Access:         request.Access,
+	})
+}
+
+func (s *DelegationsService) Delete(actor domain.Actor, delegationId int) error {
+	deleted, err := s.repo.Delete(actor.OrganizationId, actor.UserId, delegationId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrDelegationNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/salesforceanton/events-api/config"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/mailer"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+const (
+	DIGEST_CHECK_INTERVAL = time.Minute
+	// Digests sent by one check of the server, the rest is left to next check or to other servers
+	DIGEST_BATCH_SIZE  = 100
+	DIGEST_RETRY_DELAY = 10 * time.Minute
+
+	DIGEST_DEFAULT_SEND_TIME = "08:00"
+)
+
+var ErrInvalidSendTime = errors.New("Send time is invalid, use HH:MM")
+
+// Agenda digests of upcoming events, daily digest covers the rest of the day and weekly digest covers 7 days
+// in timezone of the user
+type DigestsService struct {
+	repo   repository.Digests
+	events repository.Events
+	mailer mailer.Mailer
+	cfg    *config.Config
+}
+
+func NewDigestsService(
+	repo repository.Digests, events repository.Events, mailer mailer.Mailer, cfg *config.Config,
+) *DigestsService {
+	return &DigestsService{repo: repo, events: events, mailer: mailer, cfg: cfg}
+}
+
+func (s *DigestsService) Get(actor domain.Actor) (domain.DigestPreferences, error) {
+	result, err := s.repo.Get(actor.UserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, ErrUserNotFound
+	}
+
+	return result, err
+}
+
+// Next digest is scheduled from now, so changed preferences take effect from the next send time.
+// Weekday is kept when it is not set
+func (s *DigestsService) Save(actor domain.Actor, request domain.SaveDigestRequest) (domain.DigestPreferences, error) {
+	digest, err := s.Get(actor)
+	if err != nil {
+		return digest, err
+	}
+
+	if request.SendTime == "" {
+		request.SendTime = DIGEST_DEFAULT_SEND_TIME
+	}
+	sendTime, err := time.Parse(WORKING_HOURS_LAYOUT, request.SendTime)
+	if err != nil {
+		return domain.DigestPreferences{}, ErrInvalidSendTime
+	}
+
+	digest.Frequency = request.Frequency
+	digest.SendTime = sendTime.Format(WORKING_HOURS_LAYOUT)
+	if request.Weekday != nil {
+		digest.Weekday = *request.Weekday
+	}
+
+	digest.NextSendAt = nil
+	if digest.Frequency != domain.DIGEST_FREQUENCY_OFF {
+		next := nextDigestAt(digest, time.Now())
+		digest.NextSendAt = &next
+	}
+
+	if err := s.repo.Save(digest); err != nil {
+		return domain.DigestPreferences{}, err
+	}
+
+	return digest, nil
+}
+
+// Background job - send due digests until context is cancelled
+func (s *DigestsService) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(DIGEST_CHECK_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		if err := s.deliverDue(ctx); err != nil {
+			logger.LogServiceIssue("digests", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *DigestsService) deliverDue(ctx context.Context) error {
+	for i := 0; i < DIGEST_BATCH_SIZE && ctx.Err() == nil; i++ {
+		found, err := s.repo.DeliverDue(s.deliver)
+		if err != nil || !found {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Failed digest is retried with delay until its next send time, last sent time is kept when nothing was sent
+func (s *DigestsService) deliver(digest domain.DigestPreferences) domain.DigestPreferences {
+	now := time.Now()
+	next := nextDigestAt(digest, now)
+
+	sent, err := s.send(digest, now)
+	if err != nil {
+		logger.LogServiceIssue("digests", err)
+
+		if retryAt := now.Add(DIGEST_RETRY_DELAY); retryAt.Before(next) {
+			next = retryAt
+		}
+		digest.NextSendAt = &next
+
+		return digest
+	}
+
+	digest.NextSendAt = &next
+	if sent {
+		digest.LastSentAt = &now
+	}
+
+	return digest
+}
+
+// Digest is not sent when there are no upcoming events
+func (s *DigestsService) send(digest domain.DigestPreferences, now time.Time) (bool, error) {
+	location := digestLocation(digest.TimezoneId)
+
+	days := 1
+	if digest.Frequency == domain.DIGEST_FREQUENCY_WEEKLY {
+		days = 7
+	}
+	local := now.In(location)
+	until := time.Date(local.Year(), local.Month(), local.Day()+days, 0, 0, 0, 0, location)
+
+	items, err := s.events.GetAgenda(digest.UserId, now, until)
+	if err != nil || len(items) == 0 {
+		return false, err
+	}
+
+	message, err := digestMessage(s.cfg.AppUrl, digest, local, items)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.mailer.Send(message); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// First send time after the instant, wall time which is skipped by DST change is moved forward
+func nextDigestAt(digest domain.DigestPreferences, after time.Time) time.Time {
+	location := digestLocation(digest.TimezoneId)
+	sendTime, _ := time.Parse(WORKING_HOURS_LAYOUT, digest.SendTime)
+	local := after.In(location)
+
+	for day := 0; ; day++ {
+		next := time.Date(
+			local.Year(), local.Month(), local.Day()+day, sendTime.Hour(), sendTime.Minute(), 0, 0, location,
+		)
+		if !next.After(after) {
+			continue
+		}
+		if digest.Frequency == domain.DIGEST_FREQUENCY_WEEKLY && int(next.Weekday()) != digest.Weekday {
+			continue
+		}
+
+		return next
+	}
+}
+
+func digestLocation(timezoneId string) *time.Location {
+	location, err := time.LoadLocation(timezoneId)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextDigestAt(t *testing.T) {
+	// Init Test Table
+	tests := []struct {
+		name     string
+		digest   domain.DigestPreferences
+		after    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Daily Later Today",
+			digest:   domain.DigestPreferences{Frequency: domain.DIGEST_FREQUENCY_DAILY, SendTime: "08:00", TimezoneId: "UTC"},
+			after:    testTime("2024-05-06T07:00:00Z"),
+			expected: testTime("2024-05-06T08:00:00Z"),
+		},
+		{
+			name:     "Daily At Send Time",
+			digest:   domain.DigestPreferences{Frequency: domain.DIGEST_FREQUENCY_DAILY, SendTime: "08:00", TimezoneId: "UTC"},
+			after:    testTime("2024-05-06T08:00:00Z"),
+			expected: testTime("2024-05-07T08:00:00Z"),
+		},
+		{
+			name:     "Daily In Timezone Of User",
+			digest:   domain.DigestPreferences{Frequency: domain.DIGEST_FREQUENCY_DAILY, SendTime: "08:00", TimezoneId: "Europe/Berlin"},
+			after:    testTime("2024-05-06T06:30:00Z"),
+			expected: testTime("2024-05-07T06:00:00Z"),
+		},
+		{
+			name:     "Daily Across Month End",
+			digest:   domain.DigestPreferences{Frequency: domain.DIGEST_FREQUENCY_DAILY, SendTime: "23:30", TimezoneId: "UTC"},
+			after:    testTime("2024-05-31T23:45:00Z"),
+			expected: testTime("2024-06-01T23:30:00Z"),
+		},
+		{
+			name: "Weekly Same Day",
+			digest: domain.DigestPreferences{
+				Frequency: domain.DIGEST_FREQUENCY_WEEKLY, SendTime: "08:00", Weekday: 1, TimezoneId: "UTC",
+			},
+			after:    testTime("2024-05-06T07:59:00Z"),
+			expected: testTime("2024-05-06T08:00:00Z"),
+		},
+		{
+			name: "Weekly Next Week",
+			digest: domain.DigestPreferences{
+				Frequency: domain.DIGEST_FREQUENCY_WEEKLY, SendTime: "08:00", Weekday: 1, TimezoneId: "UTC",
+			},
+			after:    testTime("2024-05-07T09:00:00Z"),
+			expected: testTime("2024-05-13T08:00:00Z"),
+		},
+		{
+			name: "Weekly On Sunday",
+			digest: domain.DigestPreferences{
+				Frequency: domain.DIGEST_FREQUENCY_WEEKLY, SendTime: "18:00", Weekday: 0, TimezoneId: "UTC",
+			},
+			after:    testTime("2024-05-06T09:00:00Z"),
+			expected: testTime("2024-05-12T18:00:00Z"),
+		},
+		{
+			// Clocks go from 02:00 to 03:00 on 2024-03-31 in Berlin, so 02:30 CET becomes 03:30 CEST
+			name:     "Send Time Skipped By DST",
+			digest:   domain.DigestPreferences{Frequency: domain.DIGEST_FREQUENCY_DAILY, SendTime: "02:30", TimezoneId: "Europe/Berlin"},
+			after:    testTime("2024-03-30T02:00:00Z"),
+			expected: testTime("2024-03-31T01:30:00Z"),
+		},
+		{
+			name:     "Day After DST Change",
+			digest:   domain.DigestPreferences{Frequency: domain.DIGEST_FREQUENCY_DAILY, SendTime: "08:00", TimezoneId: "Europe/Berlin"},
+			after:    testTime("2024-03-31T07:00:00Z"),
+			expected: testTime("2024-04-01T06:00:00Z"),
+		},
+		{
+			name:     "Unknown Timezone",
+			digest:   domain.DigestPreferences{Frequency: domain.DIGEST_FREQUENCY_DAILY, SendTime: "08:00", TimezoneId: "Mars/Olympus"},
+			after:    testTime("2024-05-06T07:00:00Z"),
+			expected: testTime("2024-05-06T08:00:00Z"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := nextDigestAt(test.digest, test.after)
+
+			assert.True(t, test.expected.Equal(result), "expected %s, got %s", test.expected, result.UTC())
+		})
+	}
+}
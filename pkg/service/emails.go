@@ -0,0 +1,185 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"net/url"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/mailer"
+)
+
+var digestTextTemplate = texttemplate.Must(texttemplate.New("digest").Parse(
+	`{{.Heading}}
+{{range .Days}}
+{{.Date}}
+{{range .Events}}  {{.Time}}  {{.Title}}{{if .Location}} ({{.Location}}){{end}}
+{{end}}{{end}}
+Open your calendar: {{.Link}}
+`))
+
+var digestHTMLTemplate = htmltemplate.Must(htmltemplate.New("digest").Parse(
+	`<h2>{{.Heading}}</h2>` +
+		`{{range .Days}}<h3>{{.Date}}</h3><ul>` +
+		`{{range .Events}}<li>{{.Time}} <a href="{{.Link}}">{{.Title}}</a>{{if .Location}} ({{.Location}}){{end}}</li>{{end}}` +
+		`</ul>{{end}}` +
+		`<p><a href="{{.Link}}">Open your calendar</a></p>`,
+))
+
+type digestData struct {
+	Heading string
+	Link    string
+	Days    []digestDay
+}
+
+type digestDay struct {
+	Date   string
+	Events []digestEvent
+}
+
+type digestEvent struct {
+	Time     string
+	Title    string
+	Location string
+	Link     string
+}
+
+func verifyEmailMessage(appUrl, email, token string) mailer.Message {
+	link := fmt.Sprintf("%s/verify-email?token=%s", appUrl, url.QueryEscape(token))
+
+	return mailer.Message{
+		To:      []string{email},
+		Subject: "Confirm your email address",
+		Text:    fmt.Sprintf("Please confirm your email address by following the link:\n\n%s\n", link),
+		HTML: fmt.Sprintf(
+			`<p>Please confirm your email address by following the link:</p><p><a href="%s">Confirm email</a></p>`,
+			html.EscapeString(link),
+		),
+	}
+}
+
+func resetPasswordMessage(appUrl, email, token string) mailer.Message {
+	link := fmt.Sprintf("%s/reset-password?token=%s", appUrl, url.QueryEscape(token))
+
+	return mailer.Message{
+		To:      []string{email},
+		Subject: "Reset your password",
+		Text: fmt.Sprintf(
+			"We received a request to reset your password. Follow the link to choose a new one:\n\n%s\n\n"+
+				"If you did not request a reset, just ignore this email.\n",
+			link,
+		),
+		HTML: fmt.Sprintf(
+			`<p>We received a request to reset your password.</p><p><a href="%s">Choose a new password</a></p>`+
+				`<p>If you did not request a reset, just ignore this email.</p>`,
+			html.EscapeString(link),
+		),
+	}
+}
+
+// Events are grouped by day, times are shown in timezone of the digest
+func digestMessage(appUrl string, digest domain.DigestPreferences, now time.Time, items []domain.AgendaItem) (mailer.Message, error) {
+	location := now.Location()
+
+	data := digestData{
+		Heading: fmt.Sprintf("Your agenda for %s", now.Format("Monday, 02 January")),
+		Link:    fmt.Sprintf("%s/events", appUrl),
+	}
+	if digest.Frequency == domain.DIGEST_FREQUENCY_WEEKLY {
+		data.Heading = fmt.Sprintf("Your agenda for the week of %s", now.Format("02 January"))
+	}
+
+	for _, item := range items {
+		startsAt := item.StartsAt.In(location)
+		day := startsAt
+		if startsAt.Before(now) {
+			day = now
+		}
+
+		date := day.Format("Monday, 02 January")
+		if len(data.Days) == 0 || data.Days[len(data.Days)-1].Date != date {
+			data.Days = append(data.Days, digestDay{Date: date})
+		}
+
+		place := item.Address
+		if place == "" {
+			place = item.OnlineUrl
+		}
+
+		current := &data.Days[len(data.Days)-1]
+		current.Events = append(current.Events, digestEvent{
+			Time:     fmt.Sprintf("%s-%s", startsAt.Format("15:04"), item.EndsAt.In(location).Format("15:04")),
+			Title:    item.Title,
+			Location: place,
+			Link:     fmt.Sprintf("%s/events/%d", appUrl, item.EventId),
+		})
+	}
+
+	var text, body bytes.Buffer
+	if err := digestTextTemplate.Execute(&text, data); err != nil {
+		return mailer.Message{}, err
+	}
+	if err := digestHTMLTemplate.Execute(&body, data); err != nil {
+		return mailer.Message{}, err
+	}
+
+	return mailer.Message{
+		To:      []string{digest.Email},
+		Subject: data.Heading,
+		Text:    text.String(),
+		HTML:    body.String(),
+	}, nil
+}
+
+// Calendar clients apply the calendar part of the message, text and html are shown by other clients
+func eventNotificationMessage(
+	appUrl, kind string, recipient domain.NotificationRecipient, event domain.Event, startsAt, endsAt time.Time,
+	changes []string,
+) mailer.Message {
+	link := fmt.Sprintf("%s/events/%d", appUrl, event.Id)
+
+	var subject, summary string
+	switch kind {
+	case EVENT_NOTIFICATION_INVITATION:
+		subject = fmt.Sprintf("Invitation: %s", event.Title)
+		summary = fmt.Sprintf("You are invited to %s.", event.Title)
+	case EVENT_NOTIFICATION_UPDATE:
+		subject = fmt.Sprintf("Updated: %s", event.Title)
+		summary = fmt.Sprintf("%s is changed: %s.", event.Title, strings.Join(changes, ", "))
+	default:
+		subject = fmt.Sprintf("Cancelled: %s", event.Title)
+		summary = fmt.Sprintf("%s is cancelled.", event.Title)
+		if event.CancellationReason != "" {
+			summary = fmt.Sprintf("%s Reason: %s", summary, event.CancellationReason)
+		}
+	}
+
+	endLayout := "Mon, 02 Jan 2006 15:04"
+	if startsAt.Format("2006-01-02") == endsAt.Format("2006-01-02") {
+		endLayout = "15:04"
+	}
+	when := fmt.Sprintf(
+		"%s - %s (%s)", startsAt.Format("Mon, 02 Jan 2006 15:04"), endsAt.Format(endLayout), event.TimezoneId,
+	)
+	details := fmt.Sprintf("When: %s\n", when)
+	htmlDetails := fmt.Sprintf("<p>When: %s</p>", html.EscapeString(when))
+	if place := eventPlace(event); place != "" {
+		details += fmt.Sprintf("Where: %s\n", place)
+		htmlDetails += fmt.Sprintf("<p>Where: %s</p>", html.EscapeString(place))
+	}
+
+	return mailer.Message{
+		To:      []string{recipient.Email},
+		Subject: subject,
+		Text:    fmt.Sprintf("%s\n\n%s\n%s\n", summary, details, link),
+		HTML: fmt.Sprintf(
+			`<p>%s</p>%s<p><a href="%s">Open event</a></p>`,
+			html.EscapeString(summary), htmlDetails, html.EscapeString(link),
+		),
+	}
+}
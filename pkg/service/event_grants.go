@@ -0,0 +1,110 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/salesforceanton/events-api/domain"
+)
+
+var (
+	ErrInvalidGrant           = errors.New("Grant should have either userId or groupId")
+	ErrUnknownEventPermission = errors.New("Unknown event permission")
+	ErrGrantNotFound          = errors.New("Grant is not found")
+)
+
+// Grants are visible and managed by users who can share the event - organizer and co-organizers
+func (s *EventsService) GetGrants(actor domain.Actor, eventId int) ([]domain.EventGrant, error) {
+	result, err := s.getGrants(actor, eventId)
+	s.audit.Record(actor, AUDIT_EVENTS_GRANTS_LIST, eventId, err)
+
+	return result, err
+}
+
+// Grant permission to a member or a group of the event organization
+func (s *EventsService) SaveGrant(actor domain.Actor, eventId int, request domain.SaveGrantRequest) (domain.EventGrant, error) {
+	result, err := s.saveGrant(actor, eventId, request)
+	s.audit.Record(actor, AUDIT_EVENTS_GRANTS_SAVE, eventId, err)
+
+	return result, err
+}
+
+func (s *EventsService) DeleteGrant(actor domain.Actor, eventId, grantId int) error {
+	err := s.deleteGrant(actor, eventId, grantId)
+	s.audit.Record(actor, AUDIT_EVENTS_GRANTS_DELETE, eventId, err)
+
+	return err
+}
+
+func (s *EventsService) getGrants(actor domain.Actor, eventId int) ([]domain.EventGrant, error) {
+	if _, err := s.authorizedEvent(actor, eventId, EVENT_ACTION_SHARE); err != nil {
+		return nil, err
+	}
+
+	return s.grants.GetGrants(eventId)
+}
+
+func (s *EventsService) saveGrant(actor domain.Actor, eventId int, request domain.SaveGrantRequest) (domain.EventGrant, error) {
+	if (request.UserId == nil) == (request.GroupId == nil) {
+		return domain.EventGrant{}, ErrInvalidGrant
+	}
+
+	if request.Permission == domain.EVENT_PERMISSION_ORGANIZER || eventPermissionLevels[request.Permission] == 0 {
+		return domain.EventGrant{}, ErrUnknownEventPermission
+	}
+
+	event, err := s.authorizedEvent(actor, eventId, EVENT_ACTION_SHARE)
+	if err != nil {
+		return domain.EventGrant{}, err
+	}
+
+	if err := s.checkGrantTarget(event, request); err != nil {
+		return domain.EventGrant{}, err
+	}
+
+	return s.grants.SaveGrant(domain.EventGrant{
+		EventId:    eventId,
+		UserId:     request.UserId,
+		GroupId:    request.GroupId,
+		Permission: request.Permission,
+	})
+}
+
+func (s *EventsService) deleteGrant(actor domain.Actor, eventId, grantId int) error {
+	if _, err := s.authorizedEvent(actor, eventId, EVENT_ACTION_SHARE); err != nil {
+		return err
+	}
+
+	deleted, err := s.grants.DeleteGrant(eventId, grantId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrGrantNotFound
+	}
+
+	return nil
+}
+
+// Events can be shared only inside the organization of the event
+func (s *EventsService) checkGrantTarget(event domain.Event, request domain.SaveGrantRequest) error {
+	var err error
+
+	if request.UserId != nil {
+		if *request.UserId == event.OrganizerId {
+			return ErrInvalidGrant
+		}
+		_, err = s.orgs.GetMember(event.OrganizationId, *request.UserId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	_, err = s.groups.GetById(event.OrganizationId, *request.GroupId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrGroupNotFound
+	}
+
+	return err
+}
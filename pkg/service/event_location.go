@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/salesforceanton/events-api/domain"
+)
+
+const (
+	NEARBY_DEFAULT_RADIUS = 10.0
+	EARTH_RADIUS_KM       = 6371.0
+)
+
+var ErrInvalidLocation = errors.New("Location is invalid, latitude and longitude should be set together")
+
+// Upcoming events organized by the user or shared with the user within radius of the point, nearest first.
+// Events without coordinates are not found
+func (s *EventsService) GetNearby(actor domain.Actor, filter domain.NearbyFilter) ([]domain.Event, error) {
+	if filter.Radius == 0 {
+		filter.Radius = NEARBY_DEFAULT_RADIUS
+	}
+
+	result, err := s.repo.GetNearby(
+		actor.OrganizationId, actor.EffectiveUserId(), filter, boundingBox(*filter.Lat, *filter.Lng, filter.Radius),
+	)
+	s.audit.Record(actor, AUDIT_EVENTS_NEARBY, 0, err)
+
+	return result, err
+}
+
+func applyLocation(request *domain.SaveEventRequest) error {
+	if (request.Latitude == nil) != (request.Longitude == nil) {
+		return ErrInvalidLocation
+	}
+
+	request.Address = strings.TrimSpace(request.Address)
+
+	return nil
+}
+
+// Box around the circle, longitude is not limited near the poles and when the circle crosses 180th meridian
+func boundingBox(lat, lng, radiusKm float64) domain.GeoBox {
+	angle := radiusKm / EARTH_RADIUS_KM
+	box := domain.GeoBox{
+		MinLat: lat - degrees(angle),
+		MaxLat: lat + degrees(angle),
+		MinLng: -180,
+		MaxLng: 180,
+	}
+
+	if box.MinLat <= -90 || box.MaxLat >= 90 {
+		box.MinLat = math.Max(box.MinLat, -90)
+		box.MaxLat = math.Min(box.MaxLat, 90)
+		return box
+	}
+
+	deltaLng := degrees(math.Asin(math.Sin(angle) / math.Cos(radians(lat))))
+	if lng-deltaLng >= -180 && lng+deltaLng <= 180 {
+		box.MinLng = lng - deltaLng
+		box.MaxLng = lng + deltaLng
+	}
+
+	return box
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundingBox(t *testing.T) {
+	// Init Test Table
+	// Radius of one degree of a great circle
+	const degreeKm = 111.19
+
+	tests := []struct {
+		name     string
+		lat      float64
+		lng      float64
+		radiusKm float64
+		expected domain.GeoBox
+	}{
+		{
+			name:     "Equator",
+			lat:      0,
+			lng:      0,
+			radiusKm: degreeKm,
+			expected: domain.GeoBox{MinLat: -1, MaxLat: 1, MinLng: -1, MaxLng: 1},
+		},
+		{
+			name:     "Wider Longitude At High Latitude",
+			lat:      60,
+			lng:      10,
+			radiusKm: degreeKm,
+			expected: domain.GeoBox{MinLat: 59, MaxLat: 61, MinLng: 8, MaxLng: 12},
+		},
+		{
+			name:     "Crossing 180th Meridian Eastward",
+			lat:      10,
+			lng:      179.5,
+			radiusKm: degreeKm,
+			expected: domain.GeoBox{MinLat: 9, MaxLat: 11, MinLng: -180, MaxLng: 180},
+		},
+		{
+			name:     "Crossing 180th Meridian Westward",
+			lat:      -10,
+			lng:      -179.5,
+			radiusKm: degreeKm,
+			expected: domain.GeoBox{MinLat: -11, MaxLat: -9, MinLng: -180, MaxLng: 180},
+		},
+		{
+			name:     "Near North Pole",
+			lat:      89.5,
+			lng:      45,
+			radiusKm: degreeKm,
+			expected: domain.GeoBox{MinLat: 88.5, MaxLat: 90, MinLng: -180, MaxLng: 180},
+		},
+		{
+			name:     "Near South Pole",
+			lat:      -89.5,
+			lng:      45,
+			radiusKm: degreeKm,
+			expected: domain.GeoBox{MinLat: -90, MaxLat: -88.5, MinLng: -180, MaxLng: 180},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := boundingBox(test.lat, test.lng, test.radiusKm)
+
+			assert.InDelta(t, test.expected.MinLat, result.MinLat, 0.001)
+			assert.InDelta(t, test.expected.MaxLat, result.MaxLat, 0.001)
+			assert.InDelta(t, test.expected.MinLng, result.MinLng, 0.001)
+			assert.InDelta(t, test.expected.MaxLng, result.MaxLng, 0.001)
+		})
+	}
+}
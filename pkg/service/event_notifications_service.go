@@ -0,0 +1,236 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/salesforceanton/events-api/config"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/ical"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/mailer"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+const (
+	EVENT_NOTIFICATION_INVITATION   = "invitation"
+	EVENT_NOTIFICATION_UPDATE       = "update"
+	EVENT_NOTIFICATION_CANCELLATION = "cancellation"
+
+	EVENT_CHANGE_TITLE       = "title"
+	EVENT_CHANGE_TIME        = "time"
+	EVENT_CHANGE_LOCATION    = "location"
+	EVENT_CHANGE_DESCRIPTION = "description"
+)
+
+// Attendees get iMIP emails about changed and cancelled events, so their calendar clients update the event.
+// Delivery is best effort - failures are logged and do not fail the change of the event
+type EventNotificationsService struct {
+	repo   repository.Notifications
+	mailer mailer.Mailer
+	cfg    *config.Config
+}
+
+func NewEventNotificationsService(
+	repo repository.Notifications, mailer mailer.Mailer, cfg *config.Config,
+) *EventNotificationsService {
+	return &EventNotificationsService{repo: repo, mailer: mailer, cfg: cfg}
+}
+
+func (s *EventNotificationsService) GetPreferences(actor domain.Actor) (domain.NotificationPreferences, error) {
+	result, err := s.repo.GetPreferences(actor.UserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, ErrUserNotFound
+	}
+
+	return result, err
+}
+
+func (s *EventNotificationsService) SavePreferences(
+	actor domain.Actor, request domain.NotificationPreferences,
+) (domain.NotificationPreferences, error) {
+	request.UserId = actor.UserId
+	if err := s.repo.SavePreferences(request); err != nil {
+		return domain.NotificationPreferences{}, err
+	}
+
+	return request, nil
+}
+
+// Attendees get the event when it is changed, invited attendees get it always and removed attendees
+// get cancellation. Drafts are not announced
+func (s *EventNotificationsService) eventUpdated(actorId int, before, after domain.Event, previousAttendeeIds []int) {
+	if after.Status != domain.EVENT_STATUS_PUBLISHED {
+		return
+	}
+
+	changes := eventChanges(before, after)
+
+	var invited, updated, removed []int
+	for _, attendeeId := range after.AttendeeIds {
+		if !containsInt(previousAttendeeIds, attendeeId) {
+			invited = append(invited, attendeeId)
+		} else if len(changes) > 0 {
+			updated = append(updated, attendeeId)
+		}
+	}
+	for _, attendeeId := range previousAttendeeIds {
+		if !containsInt(after.AttendeeIds, attendeeId) {
+			removed = append(removed, attendeeId)
+		}
+	}
+
+	s.notify(actorId, EVENT_NOTIFICATION_INVITATION, after, after.AttendeeIds, invited, nil)
+	s.notify(actorId, EVENT_NOTIFICATION_UPDATE, after, after.AttendeeIds, updated, changes)
+	// Cancellation for removed attendees lists only them, so the event stays in calendars of the others
+	s.notify(actorId, EVENT_NOTIFICATION_CANCELLATION, after, removed, removed, nil)
+}
+
+// Published draft and reopened event are sent to all attendees
+func (s *EventNotificationsService) eventPublished(actorId int, event domain.Event, attendeeIds []int) {
+	s.notify(actorId, EVENT_NOTIFICATION_INVITATION, event, attendeeIds, attendeeIds, nil)
+}
+
+// Event is cancelled or deleted, sequence of the event should be already increased
+func (s *EventNotificationsService) eventCancelled(actorId int, event domain.Event, attendeeIds []int) {
+	s.notify(actorId, EVENT_NOTIFICATION_CANCELLATION, event, attendeeIds, attendeeIds, nil)
+}
+
+// User who changed the event is not notified
+func (s *EventNotificationsService) notify(
+	actorId int, kind string, event domain.Event, attendeeIds, recipientIds []int, changes []string,
+) {
+	if len(recipientIds) == 0 {
+		return
+	}
+
+	startsAt, endsAt, err := eventTimes(event)
+	if err != nil {
+		logger.LogServiceIssue("event-notifications", err)
+		return
+	}
+
+	userIds := append([]int{event.OrganizerId}, attendeeIds...)
+	users, err := s.repo.GetRecipients(uniqueInts(append(userIds, recipientIds...)))
+	if err != nil {
+		logger.LogServiceIssue("event-notifications", err)
+		return
+	}
+
+	recipients := make(map[int]domain.NotificationRecipient, len(users))
+	for _, user := range users {
+		recipients[user.UserId] = user
+	}
+
+	method := ical.METHOD_REQUEST
+	if kind == EVENT_NOTIFICATION_CANCELLATION {
+		method = ical.METHOD_CANCEL
+	}
+
+	calendarEvent := ical.Event{
+		UID:         s.eventUid(event.Id),
+		Sequence:    event.Sequence,
+		Summary:     event.Title,
+		Description: event.Description,
+		Location:    eventPlace(event),
+		Url:         fmt.Sprintf("%s/events/%d", s.cfg.AppUrl, event.Id),
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		Organizer:   calendarAttendee(recipients[event.OrganizerId]),
+	}
+	for _, attendeeId := range attendeeIds {
+		if attendee, ok := recipients[attendeeId]; ok {
+			calendarEvent.Attendees = append(calendarEvent.Attendees, calendarAttendee(attendee))
+		}
+	}
+	calendar := ical.Build(method, calendarEvent, time.Now())
+
+	for _, recipientId := range recipientIds {
+		recipient, ok := recipients[recipientId]
+		if !ok || recipientId == actorId || !notificationEnabled(kind, recipient) {
+			continue
+		}
+
+		message := eventNotificationMessage(s.cfg.AppUrl, kind, recipient, event, startsAt, endsAt, changes)
+		message.Calendar = calendar
+		message.CalendarMethod = method
+
+		if err := s.mailer.Send(message); err != nil {
+			logger.LogServiceIssue("event-notifications", err)
+		}
+	}
+}
+
+// UID is the same in all messages about the event, host of the client application makes it globally unique
+func (s *EventNotificationsService) eventUid(eventId int) string {
+	host := "events-api"
+	if appUrl, err := url.Parse(s.cfg.AppUrl); err == nil && appUrl.Host != "" {
+		host = appUrl.Host
+	}
+
+	return fmt.Sprintf("event-%d@%s", eventId, host)
+}
+
+// Fields of the event which are shown in calendar clients
+func eventChanges(before, after domain.Event) []string {
+	var result []string
+
+	if before.Title != after.Title {
+		result = append(result, EVENT_CHANGE_TITLE)
+	}
+	if before.StartDatetime != after.StartDatetime || before.EndDatetime != after.EndDatetime ||
+		before.TimezoneId != after.TimezoneId {
+		result = append(result, EVENT_CHANGE_TIME)
+	}
+	if before.Address != after.Address || before.OnlineUrl != after.OnlineUrl {
+		result = append(result, EVENT_CHANGE_LOCATION)
+	}
+	if before.Description != after.Description {
+		result = append(result, EVENT_CHANGE_DESCRIPTION)
+	}
+
+	return result
+}
+
+// Instants of start and end from wall time in event timezone
+func eventTimes(event domain.Event) (time.Time, time.Time, error) {
+	location, err := time.LoadLocation(event.TimezoneId)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrUnknownTimezone
+	}
+
+	start, err := parseWallTime(event.StartDatetime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	end, err := parseWallTime(event.EndDatetime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return inLocation(start, location), inLocation(end, location), nil
+}
+
+func eventPlace(event domain.Event) string {
+	if event.Address != "" {
+		return event.Address
+	}
+
+	return event.OnlineUrl
+}
+
+func calendarAttendee(recipient domain.NotificationRecipient) ical.Attendee {
+	return ical.Attendee{Name: recipient.Username, Email: recipient.Email}
+}
+
+func notificationEnabled(kind string, recipient domain.NotificationRecipient) bool {
+	if kind == EVENT_NOTIFICATION_CANCELLATION {
+		return recipient.EventCancellations
+	}
+
+	return recipient.EventUpdates
+}
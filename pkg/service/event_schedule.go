@@ -0,0 +1,239 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+)
+
+const (
+	DEFAULT_EVENT_DURATION = time.Hour
+	// Wall time is stored without offset, it is interpreted in event timezone
+	WALL_TIME_LAYOUT = "2006-01-02T15:04:05"
+)
+
+var (
+	ErrInvalidEventTime = errors.New("Event time is invalid, end should be after start")
+	ErrEventConflict    = errors.New("Event conflicts with other events of participants")
+	wallTimeLayouts     = []string{
+		time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02T15:04", "2006-01-02 15:04",
+	}
+)
+
+// Conflicts are returned with the error, so client can show them and retry with allowConflicts
+type ConflictError struct {
+	Conflicts []domain.EventConflict
+}
+
+func (e *ConflictError) Error() string {
+	return ErrEventConflict.Error()
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrEventConflict
+}
+
+// Pairs of overlapping upcoming events of the user
+func (s *EventsService) GetConflicts(actor domain.Actor) ([]domain.EventOverlap, error) {
+	result, err := s.repo.GetOverlaps(actor.OrganizationId, actor.EffectiveUserId())
+	s.audit.Record(actor, AUDIT_EVENTS_CONFLICTS, 0, err)
+
+	return result, err
+}
+
+// Validate and normalize start and end of saved event. Offset of given time is ignored like in timestamp column,
+// time is wall time in event timezone. Event keeps its duration when only start is changed
+func applySchedule(request *domain.SaveEventRequest, current domain.Event) error {
+	if request.TimezoneId == "" {
+		request.TimezoneId = current.TimezoneId
+	}
+	if request.TimezoneId == "" {
+		request.TimezoneId = "UTC"
+	}
+
+	location, err := time.LoadLocation(request.TimezoneId)
+	if err != nil {
+		return ErrUnknownTimezone
+	}
+
+	start, err := parseWallTime(request.StartDatetime)
+	if err != nil {
+		return err
+	}
+
+	end := start.Add(DEFAULT_EVENT_DURATION)
+	if request.EndDatetime != "" {
+		if end, err = parseWallTime(request.EndDatetime); err != nil {
+			return err
+		}
+	} else if current.Id != 0 {
+		currentStart, startErr := parseWallTime(current.StartDatetime)
+		currentEnd, endErr := parseWallTime(current.EndDatetime)
+		if startErr == nil && endErr == nil {
+			end = start.Add(currentEnd.Sub(currentStart))
+		}
+	}
+
+	request.StartsAt = inLocation(start, location)
+	request.EndsAt = inLocation(end, location)
+	if !request.EndsAt.After(request.StartsAt) {
+		return ErrInvalidEventTime
+	}
+
+	request.StartDatetime = start.Format(WALL_TIME_LAYOUT)
+	request.EndDatetime = end.Format(WALL_TIME_LAYOUT)
+
+	return nil
+}
+
+// Attendees should be members of the event organization, organizer is not listed as attendee
+func (s *EventsService) checkAttendees(organizationId, organizerId int, request *domain.SaveEventRequest) error {
+	if request.AttendeeIds == nil {
+		return nil
+	}
+
+	attendees := make([]int, 0, len(request.AttendeeIds))
+	added := make(map[int]bool)
+
+	for _, userId := range request.AttendeeIds {
+		if userId == organizerId || added[userId] {
+			continue
+		}
+
+		_, err := s.orgs.GetMember(organizationId, userId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		added[userId] = true
+		attendees = append(attendees, userId)
+	}
+
+	request.AttendeeIds = attendees
+
+	return nil
+}
+
+// Resources are deduplicated and should belong to the organization
+func (s *EventsService) checkResources(organizationId int, request *domain.SaveEventRequest) error {
+	if request.ResourceIds == nil {
+		return nil
+	}
+
+	request.ResourceIds = uniqueInts(request.ResourceIds)
+
+	return s.resources.checkResources(organizationId, request.ResourceIds)
+}
+
+// Tags are deduplicated and should be in vocabulary of the organizer
+func (s *EventsService) checkTags(organizationId, organizerId int, request *domain.SaveEventRequest) error {
+	if request.TagIds == nil {
+		return nil
+	}
+
+	request.TagIds = uniqueInts(request.TagIds)
+
+	return s.tags.checkTags(organizationId, organizerId, request.TagIds)
+}
+
+// Find events of the organizer (and attendees) overlapping the saved event.
+// Only busy time is returned for events of other users
+func (s *EventsService) checkConflicts(
+	actor domain.Actor, organizerId, eventId int, request domain.SaveEventRequest, check domain.ConflictCheck,
+) error {
+	if check.AllowConflicts {
+		return nil
+	}
+
+	userIds := []int{organizerId}
+	if check.CheckAttendees {
+		attendees := request.AttendeeIds
+		if attendees == nil && eventId != 0 {
+			var err error
+			if attendees, err = s.repo.GetAttendees(eventId); err != nil {
+				return err
+			}
+		}
+
+		userIds = append(userIds, attendees...)
+	}
+
+	conflicts, err := s.repo.GetOverlapping(actor.OrganizationId, userIds, request.StartsAt, request.EndsAt, eventId)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	for i := range conflicts {
+		if conflicts[i].UserId != actor.EffectiveUserId() {
+			conflicts[i].EventId = 0
+		}
+	}
+
+	return &ConflictError{Conflicts: conflicts}
+}
+
+// Attendees invited during their out-of-office or outside of their working hours.
+// Event is already saved, so warnings are not returned when availability can not be loaded
+func (s *EventsService) availabilityWarnings(attendeeIds []int, startsAt, endsAt time.Time) []domain.AvailabilityWarning {
+	if len(attendeeIds) == 0 {
+		return nil
+	}
+
+	outOfOffice, err := s.availability.outOfOffice(attendeeIds, startsAt, endsAt)
+	if err != nil {
+		logger.LogServiceIssue("availability", err)
+		return nil
+	}
+
+	absent := make(map[int]bool)
+	for _, absence := range outOfOffice {
+		absent[absence.UserId] = true
+	}
+
+	schedules, err := s.availability.schedules(attendeeIds)
+	if err != nil {
+		logger.LogServiceIssue("availability", err)
+		return nil
+	}
+
+	var result []domain.AvailabilityWarning
+	for _, userId := range attendeeIds {
+		switch {
+		case absent[userId]:
+			result = append(result, domain.AvailabilityWarning{
+				UserId: userId, Reason: domain.AVAILABILITY_WARNING_OUT_OF_OFFICE,
+			})
+		case !schedules[userId].covers(startsAt, endsAt):
+			result = append(result, domain.AvailabilityWarning{
+				UserId: userId, Reason: domain.AVAILABILITY_WARNING_OUTSIDE_WORKING_HOURS,
+			})
+		}
+	}
+
+	return result
+}
+
+func parseWallTime(value string) (time.Time, error) {
+	for _, layout := range wallTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return inLocation(parsed, time.UTC), nil
+		}
+	}
+
+	return time.Time{}, ErrInvalidEventTime
+}
+
+func inLocation(wall time.Time, location *time.Location) time.Time {
+	return time.Date(
+		wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), location,
+	)
+}
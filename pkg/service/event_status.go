@@ -0,0 +1,96 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+)
+
+var (
+	ErrUnknownEventStatus      = errors.New("Unknown event status, event can be created as draft or published")
+	ErrInvalidStatusTransition = errors.New("Event status does not allow this transition")
+	ErrEventCancelled          = errors.New("Cancelled event can not be changed, reopen it first")
+)
+
+// Source and target status of each transition, keyed by its audit action
+var eventStatusTransitions = map[string]struct{ from, to string }{
+	AUDIT_EVENTS_PUBLISH: {domain.EVENT_STATUS_DRAFT, domain.EVENT_STATUS_PUBLISHED},
+	AUDIT_EVENTS_CANCEL:  {domain.EVENT_STATUS_PUBLISHED, domain.EVENT_STATUS_CANCELLED},
+	AUDIT_EVENTS_REOPEN:  {domain.EVENT_STATUS_CANCELLED, domain.EVENT_STATUS_PUBLISHED},
+}
+
+// Announce draft event to attendees
+func (s *EventsService) Publish(actor domain.Actor, eventId int) (domain.Event, error) {
+	return s.transition(actor, eventId, AUDIT_EVENTS_PUBLISH, "")
+}
+
+// Cancelled event is not deleted, attendees still see it with the reason
+func (s *EventsService) Cancel(actor domain.Actor, eventId int, reason string) (domain.Event, error) {
+	return s.transition(actor, eventId, AUDIT_EVENTS_CANCEL, reason)
+}
+
+func (s *EventsService) Reopen(actor domain.Actor, eventId int) (domain.Event, error) {
+	return s.transition(actor, eventId, AUDIT_EVENTS_REOPEN, "")
+}
+
+func (s *EventsService) transition(actor domain.Actor, eventId int, action, reason string) (domain.Event, error) {
+	transition := eventStatusTransitions[action]
+	result, err := s.changeStatus(actor, eventId, transition.from, transition.to, reason)
+	s.audit.Record(actor, action, eventId, err)
+
+	return result, err
+}
+
+func (s *EventsService) changeStatus(actor domain.Actor, eventId int, from, to, reason string) (domain.Event, error) {
+	event, err := s.authorizedEvent(actor, eventId, EVENT_ACTION_PUBLISH)
+	if err != nil {
+		return domain.Event{}, err
+	}
+
+	if event.Status != from {
+		return domain.Event{}, ErrInvalidStatusTransition
+	}
+
+	result, err := s.repo.SetStatus(actor.OrganizationId, eventId, from, to, reason)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Event{}, ErrInvalidStatusTransition
+	}
+	if err != nil {
+		return domain.Event{}, reservationError(err)
+	}
+
+	result.Permission = event.Permission
+	s.notifyStatus(actor, result)
+
+	return result, nil
+}
+
+// Attendees get invitation when the event is published or reopened and cancellation when it is cancelled.
+// Notifications do not fail the transition
+func (s *EventsService) notifyStatus(actor domain.Actor, event domain.Event) {
+	attendeeIds, err := s.repo.GetAttendees(event.Id)
+	if err != nil {
+		logger.LogServiceIssue("event-notifications", err)
+		return
+	}
+
+	if event.Status == domain.EVENT_STATUS_CANCELLED {
+		s.notifications.eventCancelled(actor.UserId, event, attendeeIds)
+	} else {
+		s.notifications.eventPublished(actor.UserId, event, attendeeIds)
+	}
+}
+
+func initialEventStatus(request *domain.SaveEventRequest) error {
+	switch request.Status {
+	case "":
+		request.Status = domain.EVENT_STATUS_PUBLISHED
+	case domain.EVENT_STATUS_DRAFT, domain.EVENT_STATUS_PUBLISHED:
+	default:
+		return ErrUnknownEventStatus
+	}
+
+	return nil
+}
@@ -1,39 +1,387 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
+
 	"github.com/salesforceanton/events-api/config"
 	"github.com/salesforceanton/events-api/domain"
 	"github.com/salesforceanton/events-api/pkg/repository"
 )
 
+var (
+	ErrEventNotFound   = errors.New("Event is not found")
+	ErrUnknownTagMatch = errors.New("Unknown tag match, use any or all")
+)
+
 type EventsService struct {
-	repo repository.Events
-	cfg  *config.Config
+	repo          repository.Events
+	grants        repository.EventGrants
+	orgs          repository.Organizations
+	groups        repository.Groups
+	policy        *PolicyService
+	audit         *AuditService
+	calendars     *CalendarsService
+	availability  *AvailabilityService
+	resources     *ResourcesService
+	tags          *TagsService
+	customFields  *CustomFieldsService
+	notifications *EventNotificationsService
+	cfg           *config.Config
 }
 
-func NewEventsService(repo repository.Events, cfg *config.Config) *EventsService {
+func NewEventsService(
+	repo repository.Events,
+	grants repository.EventGrants,
+	orgs repository.Organizations,
+	groups repository.Groups,
+	policy *PolicyService,
+	audit *AuditService,
+	calendars *CalendarsService,
+	availability *AvailabilityService,
+	resources *ResourcesService,
+	tags *TagsService,
+	customFields *CustomFieldsService,
+	notifications *EventNotificationsService,
+	cfg *config.Config,
+) *EventsService {
 	return &EventsService{
-		repo: repo,
-		cfg:  cfg,
+		repo:          repo,
+		grants:        grants,
+		orgs:          orgs,
+		groups:        groups,
+		policy:        policy,
+		audit:         audit,
+		calendars:     calendars,
+		availability:  availability,
+		resources:     resources,
+		tags:          tags,
+		customFields:  customFields,
+		notifications: notifications,
+		cfg:           cfg,
 	}
 }
 
-func (s *EventsService) GetAll(userId int) ([]domain.Event, error) {
-	return s.repo.GetAll(userId)
+// Events organized by the user and events shared with the user
+func (s *EventsService) GetAll(actor domain.Actor, filter domain.EventsFilter) ([]domain.Event, error) {
+	result, err := s.getAll(actor, filter)
+	s.audit.Record(actor, AUDIT_EVENTS_LIST, 0, err)
+
+	return result, err
+}
+
+func (s *EventsService) GetById(actor domain.Actor, eventId int) (domain.Event, error) {
+	result, err := s.getById(actor, eventId)
+	s.audit.Record(actor, AUDIT_EVENTS_GET, eventId, err)
+
+	return result, err
+}
+
+func (s *EventsService) Create(
+	actor domain.Actor, request domain.SaveEventRequest, check domain.ConflictCheck,
+) (int, []domain.AvailabilityWarning, error) {
+	result, warnings, err := s.create(actor, request, check)
+	s.audit.Record(actor, AUDIT_EVENTS_CREATE, result, err)
+
+	return result, warnings, err
+}
+
+func (s *EventsService) Update(
+	actor domain.Actor, eventId int, request domain.SaveEventRequest, check domain.ConflictCheck,
+) (domain.Event, error) {
+	result, err := s.update(actor, eventId, request, check)
+	s.audit.Record(actor, AUDIT_EVENTS_UPDATE, eventId, err)
+
+	return result, err
+}
+
+func (s *EventsService) Delete(actor domain.Actor, eventId int) error {
+	err := s.delete(actor, eventId)
+	s.audit.Record(actor, AUDIT_EVENTS_DELETE, eventId, err)
+
+	return err
 }
 
-func (s *EventsService) GetById(userId, eventId int) (domain.Event, error) {
-	return s.repo.GetById(userId, eventId)
+func (s *EventsService) getAll(actor domain.Actor, filter domain.EventsFilter) ([]domain.Event, error) {
+	switch filter.TagMatch {
+	case "":
+		filter.TagMatch = domain.TAG_MATCH_ANY
+	case domain.TAG_MATCH_ANY, domain.TAG_MATCH_ALL:
+	default:
+		return nil, ErrUnknownTagMatch
+	}
+	filter.TagIds = uniqueInts(filter.TagIds)
+
+	values, err := s.customFields.filterValues(actor.OrganizationId, filter.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	filter.CustomValues = values
+
+	result, err := s.repo.GetAccessible(actor.OrganizationId, actor.EffectiveUserId(), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, s.tags.loadTags(actor.EffectiveUserId(), result)
 }
 
-func (s *EventsService) Create(userId int, request domain.SaveEventRequest) (int, error) {
-	return s.repo.Create(userId, request)
+func (s *EventsService) getById(actor domain.Actor, eventId int) (domain.Event, error) {
+	result, err := s.authorizedEvent(actor, eventId, EVENT_ACTION_READ)
+	if err != nil {
+		return result, err
+	}
+
+	if result.AttendeeIds, err = s.repo.GetAttendees(eventId); err != nil {
+		return result, err
+	}
+
+	if result.ResourceIds, err = s.repo.GetResources(eventId); err != nil {
+		return result, err
+	}
+
+	if result.Reminders, err = s.repo.GetReminders(eventId); err != nil {
+		return result, err
+	}
+
+	events := []domain.Event{result}
+	err = s.tags.loadTags(actor.EffectiveUserId(), events)
+
+	return events[0], err
 }
 
-func (s *EventsService) Update(userId, eventId int, request domain.SaveEventRequest) (domain.Event, error) {
-	return s.repo.Update(userId, eventId, request)
+// Delegate creates events with the principal as organizer.
+// Timezone of the calendar is used for events without timezone, overlapping events are rejected unless allowed,
+// resources which are already reserved at this time are rejected always.
+// Attendees who do not work at this time are returned as warnings, the event is saved anyway
+func (s *EventsService) create(
+	actor domain.Actor, request domain.SaveEventRequest, check domain.ConflictCheck,
+) (int, []domain.AvailabilityWarning, error) {
+	if isReadOnlyDelegate(actor) {
+		return 0, nil, ErrForbidden
+	}
+
+	calendar, err := s.calendars.eventCalendar(actor.OrganizationId, actor.EffectiveUserId(), request.CalendarId)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	request.CalendarId = calendar.Id
+	if request.TimezoneId == "" {
+		request.TimezoneId = calendar.TimezoneId
+	}
+
+	if err := applyVisibility(&request, domain.Event{}); err != nil {
+		return 0, nil, err
+	}
+
+	if err := initialEventStatus(&request); err != nil {
+		return 0, nil, err
+	}
+
+	if err := applySchedule(&request, domain.Event{}); err != nil {
+		return 0, nil, err
+	}
+
+	if err := applyLocation(&request); err != nil {
+		return 0, nil, err
+	}
+
+	if err := s.checkAttendees(actor.OrganizationId, actor.EffectiveUserId(), &request); err != nil {
+		return 0, nil, err
+	}
+
+	if err := s.checkResources(actor.OrganizationId, &request); err != nil {
+		return 0, nil, err
+	}
+
+	if err := s.checkTags(actor.OrganizationId, actor.EffectiveUserId(), &request); err != nil {
+		return 0, nil, err
+	}
+
+	if request.CustomFields, err = s.customFields.validate(actor.OrganizationId, request.CustomFields); err != nil {
+		return 0, nil, err
+	}
+	request.Reminders = reminderMinutes(request.Reminders)
+
+	if err := s.checkConflicts(actor, actor.EffectiveUserId(), 0, request, check); err != nil {
+		return 0, nil, err
+	}
+
+	result, err := s.repo.Create(actor.OrganizationId, actor.EffectiveUserId(), request)
+	if err != nil {
+		return 0, nil, reservationError(err)
+	}
+
+	return result, s.availabilityWarnings(request.AttendeeIds, request.StartsAt, request.EndsAt), nil
+}
+
+// Event can be moved only to another calendar of its organizer, attendees who do not work at new time are warned about.
+// Attendees are notified about the changes
+func (s *EventsService) update(
+	actor domain.Actor, eventId int, request domain.SaveEventRequest, check domain.ConflictCheck,
+) (domain.Event, error) {
+	event, err := s.authorizedEvent(actor, eventId, EVENT_ACTION_UPDATE)
+	if err != nil {
+		return domain.Event{}, err
+	}
+
+	if event.Status == domain.EVENT_STATUS_CANCELLED {
+		return domain.Event{}, ErrEventCancelled
+	}
+
+	if request.CalendarId == 0 {
+		request.CalendarId = event.CalendarId
+	} else if _, err := s.calendars.calendar(actor.OrganizationId, event.OrganizerId, request.CalendarId); err != nil {
+		return domain.Event{}, err
+	}
+
+	if err := applyVisibility(&request, event); err != nil {
+		return domain.Event{}, err
+	}
+
+	if err := applySchedule(&request, event); err != nil {
+		return domain.Event{}, err
+	}
+
+	if err := applyLocation(&request); err != nil {
+		return domain.Event{}, err
+	}
+
+	if err := s.checkAttendees(actor.OrganizationId, event.OrganizerId, &request); err != nil {
+		return domain.Event{}, err
+	}
+
+	if err := s.checkResources(actor.OrganizationId, &request); err != nil {
+		return domain.Event{}, err
+	}
+
+	if err := s.checkTags(actor.OrganizationId, event.OrganizerId, &request); err != nil {
+		return domain.Event{}, err
+	}
+
+	if request.CustomFields == nil {
+		request.CustomFields = event.CustomFields
+	} else if request.CustomFields, err = s.customFields.validate(actor.OrganizationId, request.CustomFields); err != nil {
+		return domain.Event{}, err
+	}
+	request.Reminders = reminderMinutes(request.Reminders)
+
+	if err := s.checkConflicts(actor, event.OrganizerId, eventId, request, check); err != nil {
+		return domain.Event{}, err
+	}
+
+	previousAttendeeIds, err := s.repo.GetAttendees(eventId)
+	if err != nil {
+		return domain.Event{}, err
+	}
+
+	result, err := s.repo.Update(actor.OrganizationId, eventId, request)
+	if err != nil {
+		return result, reservationError(err)
+	}
+
+	if result.Permission, err = s.policy.EventPermission(actor.EffectiveUserId(), result); err != nil {
+		return result, err
+	}
+
+	if result.AttendeeIds, err = s.repo.GetAttendees(eventId); err != nil {
+		return result, err
+	}
+
+	if result.ResourceIds, err = s.repo.GetResources(eventId); err != nil {
+		return result, err
+	}
+
+	if result.Reminders, err = s.repo.GetReminders(eventId); err != nil {
+		return result, err
+	}
+
+	events := []domain.Event{result}
+	if err := s.tags.loadTags(actor.EffectiveUserId(), events); err != nil {
+		return result, err
+	}
+
+	result = events[0]
+	result.Warnings = s.availabilityWarnings(result.AttendeeIds, request.StartsAt, request.EndsAt)
+	s.notifications.eventUpdated(actor.UserId, event, result, previousAttendeeIds)
+
+	return result, nil
+}
+
+// Attendees of published event get cancellation
+func (s *EventsService) delete(actor domain.Actor, eventId int) error {
+	event, err := s.authorizedEvent(actor, eventId, EVENT_ACTION_DELETE)
+	if err != nil {
+		return err
+	}
+
+	attendeeIds, err := s.repo.GetAttendees(eventId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(actor.OrganizationId, eventId); err != nil {
+		return err
+	}
+
+	if event.Status == domain.EVENT_STATUS_PUBLISHED {
+		event.Sequence++
+		s.notifications.eventCancelled(actor.UserId, event, attendeeIds)
+	}
+
+	return nil
+}
+
+// Load event of active organization and check that the user can do the action.
+// Events which user can not read, including drafts for viewers, are reported as not found to not disclose their existence
+func (s *EventsService) authorizedEvent(actor domain.Actor, eventId int, action string) (domain.Event, error) {
+	event, err := s.repo.GetById(actor.OrganizationId, eventId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Event{}, ErrEventNotFound
+	}
+	if err != nil {
+		return domain.Event{}, err
+	}
+
+	event.Permission, err = s.policy.EventPermission(actor.EffectiveUserId(), event)
+	if err != nil {
+		return domain.Event{}, err
+	}
+
+	// Drafts are visible to users who can edit them
+	readAction := EVENT_ACTION_READ
+	if event.Status == domain.EVENT_STATUS_DRAFT {
+		readAction = EVENT_ACTION_UPDATE
+	}
+
+	canRead, err := s.policy.CanOnEvent(actor, event, readAction)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	if !canRead {
+		return domain.Event{}, ErrEventNotFound
+	}
+
+	if action == EVENT_ACTION_READ {
+		return event, nil
+	}
+
+	if isReadOnlyDelegate(actor) {
+		return domain.Event{}, ErrForbidden
+	}
+
+	allowed, err := s.policy.CanOnEvent(actor, event, action)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	if !allowed {
+		return domain.Event{}, ErrForbidden
+	}
+
+	return event, nil
 }
 
-func (s *EventsService) Delete(userId, eventId int) error {
-	return s.repo.Delete(userId, eventId)
+func isReadOnlyDelegate(actor domain.Actor) bool {
+	return actor.PrincipalId != 0 && actor.DelegatedAccess != domain.DELEGATION_ACCESS_FULL
 }
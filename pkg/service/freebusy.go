@@ -0,0 +1,159 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+)
+
+const (
+	FREEBUSY_MAX_USERS  = 50
+	FREEBUSY_MAX_WINDOW = 93 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidFreeBusyWindow = errors.New("Time window is invalid, timeMax should be after timeMin and not later than 93 days")
+	ErrTooManyFreeBusyUsers  = errors.New("Too many users requested, maximum is 50")
+)
+
+// Busy time of members of the active organization. Only time of events is disclosed, so it does not depend on
+// event visibility or grants: drafts take time of the organizer, published events also of attendees.
+// Out-of-office ranges are busy time too. Users who turned free/busy sharing off are returned as private
+// without busy time, except for the actor. Recurring events are not supported yet, each event is one interval
+func (s *EventsService) GetFreeBusy(actor domain.Actor, request domain.FreeBusyRequest) ([]domain.UserFreeBusy, error) {
+	result, err := s.getFreeBusy(actor, request)
+	s.audit.Record(actor, AUDIT_EVENTS_FREEBUSY, 0, err)
+
+	return result, err
+}
+
+func (s *EventsService) getFreeBusy(actor domain.Actor, request domain.FreeBusyRequest) ([]domain.UserFreeBusy, error) {
+	userIds, err := s.freeBusyMembers(actor, request)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := s.availability.freeBusyShared(userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedIds := make([]int, 0, len(userIds))
+	for _, userId := range userIds {
+		if shared[userId] || userId == actor.UserId {
+			sharedIds = append(sharedIds, userId)
+		}
+	}
+
+	busy, err := s.busyIntervals(actor.OrganizationId, sharedIds, request.TimeMin, request.TimeMax)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.UserFreeBusy, 0, len(userIds))
+	for _, userId := range userIds {
+		result = append(result, domain.UserFreeBusy{
+			UserId:  userId,
+			Busy:    busy[userId],
+			Private: !shared[userId] && userId != actor.UserId,
+		})
+	}
+
+	return result, nil
+}
+
+// Requested users without duplicates, each of them should be a member of the active organization
+func (s *EventsService) freeBusyMembers(actor domain.Actor, request domain.FreeBusyRequest) ([]int, error) {
+	if !request.TimeMax.After(request.TimeMin) || request.TimeMax.Sub(request.TimeMin) > FREEBUSY_MAX_WINDOW {
+		return nil, ErrInvalidFreeBusyWindow
+	}
+
+	userIds := make([]int, 0, len(request.UserIds))
+	added := make(map[int]bool)
+
+	for _, userId := range request.UserIds {
+		if added[userId] {
+			continue
+		}
+		if len(userIds) == FREEBUSY_MAX_USERS {
+			return nil, ErrTooManyFreeBusyUsers
+		}
+
+		_, err := s.orgs.GetMember(actor.OrganizationId, userId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		added[userId] = true
+		userIds = append(userIds, userId)
+	}
+
+	return userIds, nil
+}
+
+// Merged busy intervals of each user from events and out-of-office ranges, sharing settings are not applied here
+func (s *EventsService) busyIntervals(
+	organizationId int, userIds []int, timeMin, timeMax time.Time,
+) (map[int][]domain.BusyInterval, error) {
+	events, err := s.repo.GetOverlapping(organizationId, userIds, timeMin, timeMax, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	outOfOffice, err := s.availability.outOfOffice(userIds, timeMin, timeMax)
+	if err != nil {
+		return nil, err
+	}
+
+	intervals := make(map[int][]domain.BusyInterval)
+	for _, event := range events {
+		intervals[event.UserId] = append(intervals[event.UserId], domain.BusyInterval{Start: event.StartsAt, End: event.EndsAt})
+	}
+	for _, absence := range outOfOffice {
+		intervals[absence.UserId] = append(intervals[absence.UserId], domain.BusyInterval{Start: absence.StartsAt, End: absence.EndsAt})
+	}
+
+	result := make(map[int][]domain.BusyInterval, len(userIds))
+	for _, userId := range userIds {
+		result[userId] = []domain.BusyInterval{}
+		if userIntervals := intervals[userId]; len(userIntervals) > 0 {
+			result[userId] = mergeIntervals(userIntervals, timeMin, timeMax)
+		}
+	}
+
+	return result, nil
+}
+
+// Intervals are clipped to the window and merged, touching intervals are merged too
+func mergeIntervals(intervals []domain.BusyInterval, timeMin, timeMax time.Time) []domain.BusyInterval {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Start.Before(intervals[j].Start)
+	})
+
+	var result []domain.BusyInterval
+	for _, interval := range intervals {
+		if interval.Start.Before(timeMin) {
+			interval.Start = timeMin
+		}
+		if interval.End.After(timeMax) {
+			interval.End = timeMax
+		}
+
+		if last := len(result) - 1; last >= 0 && !interval.Start.After(result[last].End) {
+			if interval.End.After(result[last].End) {
+				result[last].End = interval.End
+			}
+			continue
+		}
+
+		result = append(result, interval)
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+var ErrGroupNotFound = errors.New("Group is not found")
+
+// Groups of users inside the active organization, they are managed by organization owners and admins
+type GroupsService struct {
+	repo repository.Groups
+	orgs *OrganizationsService
+}
+
+func NewGroupsService(repo repository.Groups, orgs *OrganizationsService) *GroupsService {
+	return &GroupsService{
+		repo: repo,
+		orgs: orgs,
+	}
+}
+
+func (s *GroupsService) GetAll(actor domain.Actor) ([]domain.Group, error) {
+	return s.repo.GetAll(actor.OrganizationId)
+}
+
+func (s *GroupsService) Create(actor domain.Actor, request domain.CreateGroupRequest) (int, error) {
+	if _, err := s.orgs.manager(actor.UserId, actor.OrganizationId); err != nil {
+		return 0, err
+	}
+
+	return s.repo.Create(actor.OrganizationId, request.Name)
+}
+
+func (s *GroupsService) GetMembers(actor domain.Actor, groupId int) ([]domain.GroupMember, error) {
+	if err := s.checkGroup(actor, groupId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMembers(groupId)
+}
+
+// Only members of the organization can be added to its groups
+func (s *GroupsService) AddMember(actor domain.Actor, groupId, userId int) error {
+	if err := s.checkManagedGroup(actor, groupId); err != nil {
+		return err
+	}
+
+	_, err := s.orgs.repo.GetMember(actor.OrganizationId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.repo.AddMember(groupId, userId)
+}
+
+func (s *GroupsService) RemoveMember(actor domain.Actor, groupId, userId int) error {
+	if err := s.checkManagedGroup(actor, groupId); err != nil {
+		return err
+	}
+
+	removed, err := s.repo.RemoveMember(groupId, userId)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrMemberNotFound
+	}
+
+	return nil
+}
+
+func (s *GroupsService) checkGroup(actor domain.Actor, groupId int) error {
+	_, err := s.repo.GetById(actor.OrganizationId, groupId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrGroupNotFound
+	}
+
+	return err
+}
+
+func (s *GroupsService) checkManagedGroup(actor domain.Actor, groupId int) error {
+	if err := s.checkGroup(actor, groupId); err != nil {
+		return err
+	}
+
+	_, err := s.orgs.manager(actor.UserId, actor.OrganizationId)
+
+	return err
+}
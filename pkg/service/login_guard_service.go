@@ -0,0 +1,133 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/salesforceanton/events-api/config"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+const (
+	// Failures before progressive delay is applied
+	LOGIN_DELAY_AFTER = 3
+	LOGIN_BASE_DELAY  = time.Second
+	LOGIN_MAX_DELAY   = 30 * time.Second
+)
+
+// Throttle sign-in attempts per username and per client IP.
+// Each failure after a few first ones doubles delay before the next attempt,
+// reaching failures limit locks the key for lockout duration
+type LoginGuardService struct {
+	repo repository.LoginThrottles
+	cfg  *config.Config
+}
+
+func NewLoginGuardService(repo repository.LoginThrottles, cfg *config.Config) *LoginGuardService {
+	return &LoginGuardService{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// Return time client has to wait before the next attempt, zero means attempt is allowed
+func (s *LoginGuardService) Check(username, ip string) (time.Duration, error) {
+	throttles, err := s.repo.GetThrottles(throttleKeys(username, ip))
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var retryAfter time.Duration
+
+	for _, throttle := range throttles {
+		wait := throttle.LockedUntil.Sub(now)
+
+		if now.Sub(throttle.LastFailureAt) < s.cfg.LoginFailureWindow {
+			if delay := throttle.LastFailureAt.Add(loginDelay(throttle.Failures)).Sub(now); delay > wait {
+				wait = delay
+			}
+		}
+
+		if wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		logger.LogSecurityEvent("sign-in-throttled", username, ip, "Sign-in attempt rejected by throttling")
+	}
+
+	return retryAfter, nil
+}
+
+func (s *LoginGuardService) RegisterFailure(username, ip string) error {
+	logger.LogSecurityEvent("sign-in-failed", username, ip, "Sign-in attempt failed")
+
+	for _, key := range throttleKeys(username, ip) {
+		throttle, err := s.repo.RegisterFailure(key, s.cfg.LoginFailureWindow)
+		if err != nil {
+			return err
+		}
+
+		if throttle.Failures < s.maxFailures(key) {
+			continue
+		}
+
+		if err := s.repo.Lock(key, time.Now().Add(s.cfg.LoginLockoutDuration)); err != nil {
+			return err
+		}
+		logger.LogSecurityEvent("sign-in-locked", username, ip, "Too many failed sign-in attempts for "+key)
+	}
+
+	return nil
+}
+
+// Successful sign-in resets username counter only - IP counter keeps tracking stuffing across accounts
+func (s *LoginGuardService) RegisterSuccess(username, ip string) error {
+	if username == "" {
+		return nil
+	}
+
+	return s.repo.Reset(usernameThrottleKey(username))
+}
+
+func (s *LoginGuardService) maxFailures(key string) int {
+	if strings.HasPrefix(key, "ip:") {
+		return s.cfg.LoginMaxIpFailures
+	}
+
+	return s.cfg.LoginMaxFailures
+}
+
+func loginDelay(failures int) time.Duration {
+	if failures < LOGIN_DELAY_AFTER {
+		return 0
+	}
+
+	delay := LOGIN_BASE_DELAY
+	for i := LOGIN_DELAY_AFTER; i < failures && delay < LOGIN_MAX_DELAY; i++ {
+		delay *= 2
+	}
+
+	if delay > LOGIN_MAX_DELAY {
+		return LOGIN_MAX_DELAY
+	}
+
+	return delay
+}
+
+// Empty username means only client IP is checked, e.g. on MFA step
+func throttleKeys(username, ip string) []string {
+	keys := []string{"ip:" + ip}
+	if username != "" {
+		keys = append(keys, usernameThrottleKey(username))
+	}
+
+	return keys
+}
+
+func usernameThrottleKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
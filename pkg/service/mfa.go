@@ -0,0 +1,215 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/totp"
+)
+
+const (
+	MFA_CHALLENGE_PURPOSE = "mfa"
+	MFA_ENROLL_PURPOSE    = "mfa-enroll"
+	RECOVERY_CODES_COUNT  = 10
+)
+
+var (
+	ErrInvalidMfaCode    = errors.New("Verification code is invalid")
+	ErrInvalidChallenge  = errors.New("MFA challenge is invalid or expired")
+	ErrMfaAlreadyEnabled = errors.New("MFA is already enabled")
+	ErrMfaNotEnabled     = errors.New("MFA is not enabled")
+	ErrMfaNotEnrolled    = errors.New("MFA enrollment is not started")
+	ErrMfaRequired       = errors.New("MFA is required for this account and can not be disabled")
+)
+
+// Second sign-in step - exchange challenge token and verification code for access token.
+// Challenge of enrollment step also confirms pending enrollment. Challenge is single-use, it is burned
+// after the code is accepted, so wrong codes can be retried with the same challenge
+func (s *AuthService) VerifyMfaChallenge(challengeToken, code string) (string, error) {
+	claims, err := s.parseClaims(challengeToken)
+	if err != nil || (claims.Purpose != MFA_CHALLENGE_PURPOSE && claims.Purpose != MFA_ENROLL_PURPOSE) {
+		return "", ErrInvalidChallenge
+	}
+
+	user, err := s.repo.GetUserById(claims.UserId)
+	if err != nil {
+		return "", err
+	}
+
+	if user.MfaSecret == "" {
+		return "", ErrMfaNotEnrolled
+	}
+
+	if claims.Purpose == MFA_ENROLL_PURPOSE {
+		if err := s.ConfirmMfa(user.Id, code); err != nil {
+			return "", err
+		}
+	} else if err := s.checkMfaCode(user, code, true); err != nil {
+		return "", err
+	}
+
+	userId, err := s.tokens.ConsumeNonce(claims.Id, claims.Purpose)
+	if err != nil || userId != user.Id {
+		return "", ErrInvalidChallenge
+	}
+
+	return s.issueAccessToken(user.Id)
+}
+
+// Username of the user who is signing in with challenge, used to throttle code guessing per account
+func (s *AuthService) GetMfaChallengeUsername(challengeToken string) (string, error) {
+	claims, err := s.parseClaims(challengeToken)
+	if err != nil || (claims.Purpose != MFA_CHALLENGE_PURPOSE && claims.Purpose != MFA_ENROLL_PURPOSE) {
+		return "", ErrInvalidChallenge
+	}
+
+	user, err := s.repo.GetUserById(claims.UserId)
+	if err != nil {
+		return "", err
+	}
+
+	return user.Username, nil
+}
+
+// Generate TOTP secret with recovery codes, MFA is enabled after the first code is confirmed.
+// Pending secret is kept on repeated enrollment, so authenticator which already scanned it keeps working,
+// recovery codes are generated again because only their hashes are stored
+func (s *AuthService) EnrollMfa(userId int) (domain.MfaEnrollment, error) {
+	user, err := s.repo.GetUserById(userId)
+	if err != nil {
+		return domain.MfaEnrollment{}, err
+	}
+
+	if user.MfaEnabled {
+		return domain.MfaEnrollment{}, ErrMfaAlreadyEnabled
+	}
+
+	secret := user.MfaSecret
+	if secret == "" {
+		if secret, err = totp.GenerateSecret(); err != nil {
+			return domain.MfaEnrollment{}, err
+		}
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return domain.MfaEnrollment{}, err
+	}
+
+	if err := s.mfaRepo.StartEnrollment(user.Id, secret, hashes); err != nil {
+		return domain.MfaEnrollment{}, err
+	}
+
+	return domain.MfaEnrollment{
+		Secret:        secret,
+		OtpauthUri:    totp.URI(s.cfg.MfaIssuer, user.Email, secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// Enrollment for users who are required to use MFA but can not sign in yet
+func (s *AuthService) EnrollMfaWithChallenge(challengeToken string) (domain.MfaEnrollment, error) {
+	claims, err := s.parseClaims(challengeToken)
+	if err != nil || claims.Purpose != MFA_ENROLL_PURPOSE {
+		return domain.MfaEnrollment{}, ErrInvalidChallenge
+	}
+
+	return s.EnrollMfa(claims.UserId)
+}
+
+func (s *AuthService) ConfirmMfa(userId int, code string) error {
+	user, err := s.repo.GetUserById(userId)
+	if err != nil {
+		return err
+	}
+
+	if user.MfaEnabled {
+		return ErrMfaAlreadyEnabled
+	}
+
+	if user.MfaSecret == "" {
+		return ErrMfaNotEnrolled
+	}
+
+	if err := s.checkMfaCode(user, code, false); err != nil {
+		return err
+	}
+
+	return s.mfaRepo.SetMfaEnabled(user.Id, true)
+}
+
+func (s *AuthService) DisableMfa(userId int, code string) error {
+	user, err := s.repo.GetUserById(userId)
+	if err != nil {
+		return err
+	}
+
+	if user.MfaRequired {
+		return ErrMfaRequired
+	}
+
+	if !user.MfaEnabled {
+		return ErrMfaNotEnabled
+	}
+
+	if err := s.checkMfaCode(user, code, true); err != nil {
+		return err
+	}
+
+	return s.mfaRepo.SetMfaEnabled(user.Id, false)
+}
+
+// Accept current TOTP code once per time step, recovery codes are accepted once at all
+func (s *AuthService) checkMfaCode(user domain.User, code string, allowRecovery bool) error {
+	if step, ok := totp.Validate(user.MfaSecret, code, time.Now()); ok {
+		used, err := s.mfaRepo.UseTimeStep(user.Id, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidMfaCode
+		}
+		return nil
+	}
+
+	if !allowRecovery {
+		return ErrInvalidMfaCode
+	}
+
+	used, err := s.mfaRepo.UseRecoveryCode(user.Id, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidMfaCode
+	}
+
+	return nil
+}
+
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, RECOVERY_CODES_COUNT)
+	hashes := make([]string, 0, RECOVERY_CODES_COUNT)
+
+	for i := 0; i < RECOVERY_CODES_COUNT; i++ {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+
+		value := strings.ToLower(base32.StdEncoding.EncodeToString(raw))
+		codes = append(codes, value[:4]+"-"+value[4:])
+		hashes = append(hashes, hashToken(value))
+	}
+
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
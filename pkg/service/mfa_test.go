@@ -0,0 +1,164 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/salesforceanton/events-api/config"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+	"github.com/salesforceanton/events-api/pkg/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubAuthRepo struct {
+	repository.Authorization
+	user *domain.User
+}
+
+func (r stubAuthRepo) GetUserById(userId int) (domain.User, error) {
+	return *r.user, nil
+}
+
+// Every time step is accepted, so tests check the challenge and not the code replay protection
+type stubMfaRepo struct {
+	repository.Mfa
+	user *domain.User
+}
+
+func (r stubMfaRepo) StartEnrollment(userId int, secret string, recoveryCodeHashes []string) error {
+	r.user.MfaSecret = secret
+	return nil
+}
+
+func (r stubMfaRepo) SetMfaEnabled(userId int, enabled bool) error {
+	r.user.MfaEnabled = enabled
+	return nil
+}
+
+func (r stubMfaRepo) UseTimeStep(userId int, step int64) (bool, error) {
+	return true, nil
+}
+
+func (r stubMfaRepo) UseRecoveryCode(userId int, codeHash string) (bool, error) {
+	return false, nil
+}
+
+type stubUserTokensRepo struct {
+	repository.UserTokens
+	tokens map[string]domain.UserToken
+}
+
+func (r stubUserTokensRepo) CreateToken(token domain.UserToken) error {
+	r.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (r stubUserTokensRepo) ConsumeToken(purpose, tokenHash string) (int, error) {
+	token, ok := r.tokens[tokenHash]
+	if !ok || token.Purpose != purpose {
+		return 0, sql.ErrNoRows
+	}
+
+	delete(r.tokens, tokenHash)
+	return token.UserId, nil
+}
+
+func (r stubOrganizationsRepo) GetDefault(userId int) (int, error) {
+	return 1, nil
+}
+
+func newTestAuthService(user *domain.User) *AuthService {
+	return NewAuthService(
+		stubAuthRepo{user: user},
+		stubUserTokensRepo{tokens: make(map[string]domain.UserToken)},
+		stubMfaRepo{user: user},
+		stubOrganizationsRepo{},
+		nil,
+		nil,
+		&config.Config{TokenSecret: "test_secret", TokenSigningAlg: "HS256", MfaChallengeTTL: time.Minute},
+	)
+}
+
+func TestAuthService_VerifyMfaChallenge(t *testing.T) {
+	// Init Test Table
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		user           domain.User
+		codes          []string
+		expectedErrors []error
+	}{
+		{
+			name:           "Ok",
+			user:           domain.User{Id: 2, MfaEnabled: true, MfaSecret: secret},
+			codes:          []string{code},
+			expectedErrors: []error{nil},
+		},
+		{
+			name:           "Replayed Challenge",
+			user:           domain.User{Id: 2, MfaEnabled: true, MfaSecret: secret},
+			codes:          []string{code, code},
+			expectedErrors: []error{nil, ErrInvalidChallenge},
+		},
+		{
+			name:           "Retry After Wrong Code",
+			user:           domain.User{Id: 2, MfaEnabled: true, MfaSecret: secret},
+			codes:          []string{"000000", code},
+			expectedErrors: []error{ErrInvalidMfaCode, nil},
+		},
+		{
+			name:           "Replayed Enrollment Challenge",
+			user:           domain.User{Id: 2, MfaRequired: true, MfaSecret: secret},
+			codes:          []string{code, code},
+			expectedErrors: []error{nil, ErrMfaAlreadyEnabled},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			user := test.user
+			auth := newTestAuthService(&user)
+
+			signIn, err := auth.signIn(user)
+			assert.NoError(t, err)
+
+			// Pass the challenge
+			for i, attempt := range test.codes {
+				token, err := auth.VerifyMfaChallenge(signIn.ChallengeToken, attempt)
+
+				// Assert
+				assert.ErrorIs(t, err, test.expectedErrors[i])
+				assert.Equal(t, err == nil, token != "")
+			}
+		})
+	}
+}
+
+func TestAuthService_EnrollMfaWithChallenge(t *testing.T) {
+	// Init Dependencies
+	user := domain.User{Id: 2, MfaRequired: true}
+	auth := newTestAuthService(&user)
+
+	signIn, err := auth.signIn(user)
+	assert.NoError(t, err)
+
+	// Enroll twice with the same challenge
+	first, err := auth.EnrollMfaWithChallenge(signIn.ChallengeToken)
+	assert.NoError(t, err)
+
+	second, err := auth.EnrollMfaWithChallenge(signIn.ChallengeToken)
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, first.Secret, second.Secret)
+	assert.Equal(t, first.Secret, user.MfaSecret)
+	assert.NotEqual(t, first.RecoveryCodes, second.RecoveryCodes)
+}
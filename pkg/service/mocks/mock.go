@@ -5,10 +5,13 @@
 package mock_service
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/salesforceanton/events-api/domain"
+	signing "github.com/salesforceanton/events-api/pkg/signing"
 )
 
 // MockAuthorization is a mock of Authorization interface.
@@ -34,6 +37,20 @@ func (m *MockAuthorization) EXPECT() *MockAuthorizationMockRecorder {
 	return m.recorder
 }
 
+// ConfirmMfa mocks base method.
+func (m *MockAuthorization) ConfirmMfa(userId int, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmMfa", userId, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmMfa indicates an expected call of ConfirmMfa.
+func (mr *MockAuthorizationMockRecorder) ConfirmMfa(userId, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMfa", reflect.TypeOf((*MockAuthorization)(nil).ConfirmMfa), userId, code)
+}
+
 // CreateUser mocks base method.
 func (m *MockAuthorization) CreateUser(user domain.User) (int, error) {
 	m.ctrl.T.Helper()
@@ -49,11 +66,69 @@ func (mr *MockAuthorizationMockRecorder) CreateUser(user interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthorization)(nil).CreateUser), user)
 }
 
+// DisableMfa mocks base method.
+func (m *MockAuthorization) DisableMfa(userId int, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMfa", userId, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMfa indicates an expected call of DisableMfa.
+func (mr *MockAuthorizationMockRecorder) DisableMfa(userId, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMfa", reflect.TypeOf((*MockAuthorization)(nil).DisableMfa), userId, code)
+}
+
+// EnrollMfa mocks base method.
+func (m *MockAuthorization) EnrollMfa(userId int) (domain.MfaEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMfa", userId)
+	ret0, _ := ret[0].(domain.MfaEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMfa indicates an expected call of EnrollMfa.
+func (mr *MockAuthorizationMockRecorder) EnrollMfa(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMfa", reflect.TypeOf((*MockAuthorization)(nil).EnrollMfa), userId)
+}
+
+// EnrollMfaWithChallenge mocks base method.
+func (m *MockAuthorization) EnrollMfaWithChallenge(challengeToken string) (domain.MfaEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMfaWithChallenge", challengeToken)
+	ret0, _ := ret[0].(domain.MfaEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMfaWithChallenge indicates an expected call of EnrollMfaWithChallenge.
+func (mr *MockAuthorizationMockRecorder) EnrollMfaWithChallenge(challengeToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMfaWithChallenge", reflect.TypeOf((*MockAuthorization)(nil).EnrollMfaWithChallenge), challengeToken)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockAuthorization) ForgotPassword(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockAuthorizationMockRecorder) ForgotPassword(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockAuthorization)(nil).ForgotPassword), email)
+}
+
 // GenerateToken mocks base method.
-func (m *MockAuthorization) GenerateToken(username, password string) (string, error) {
+func (m *MockAuthorization) GenerateToken(username, password string) (domain.SignInResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GenerateToken", username, password)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(domain.SignInResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -64,11 +139,26 @@ func (mr *MockAuthorizationMockRecorder) GenerateToken(username, password interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthorization)(nil).GenerateToken), username, password)
 }
 
+// GetMfaChallengeUsername mocks base method.
+func (m *MockAuthorization) GetMfaChallengeUsername(challengeToken string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMfaChallengeUsername", challengeToken)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMfaChallengeUsername indicates an expected call of GetMfaChallengeUsername.
+func (mr *MockAuthorizationMockRecorder) GetMfaChallengeUsername(challengeToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMfaChallengeUsername", reflect.TypeOf((*MockAuthorization)(nil).GetMfaChallengeUsername), challengeToken)
+}
+
 // ParseToken mocks base method.
-func (m *MockAuthorization) ParseToken(accessToken string) (int, error) {
+func (m *MockAuthorization) ParseToken(accessToken string) (domain.Actor, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ParseToken", accessToken)
-	ret0, _ := ret[0].(int)
+	ret0, _ := ret[0].(domain.Actor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -79,6 +169,78 @@ func (mr *MockAuthorizationMockRecorder) ParseToken(accessToken interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAuthorization)(nil).ParseToken), accessToken)
 }
 
+// ResendVerification mocks base method.
+func (m *MockAuthorization) ResendVerification(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockAuthorizationMockRecorder) ResendVerification(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockAuthorization)(nil).ResendVerification), email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthorization) ResetPassword(token, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthorizationMockRecorder) ResetPassword(token, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthorization)(nil).ResetPassword), token, password)
+}
+
+// SwitchOrganization mocks base method.
+func (m *MockAuthorization) SwitchOrganization(userId, organizationId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwitchOrganization", userId, organizationId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwitchOrganization indicates an expected call of SwitchOrganization.
+func (mr *MockAuthorizationMockRecorder) SwitchOrganization(userId, organizationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwitchOrganization", reflect.TypeOf((*MockAuthorization)(nil).SwitchOrganization), userId, organizationId)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockAuthorization) VerifyEmail(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockAuthorizationMockRecorder) VerifyEmail(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAuthorization)(nil).VerifyEmail), token)
+}
+
+// VerifyMfaChallenge mocks base method.
+func (m *MockAuthorization) VerifyMfaChallenge(challengeToken, code string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMfaChallenge", challengeToken, code)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMfaChallenge indicates an expected call of VerifyMfaChallenge.
+func (mr *MockAuthorizationMockRecorder) VerifyMfaChallenge(challengeToken, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMfaChallenge", reflect.TypeOf((*MockAuthorization)(nil).VerifyMfaChallenge), challengeToken, code)
+}
+
 // MockEvents is a mock of Events interface.
 type MockEvents struct {
 	ctrl     *gomock.Controller
@@ -102,76 +264,1871 @@ func (m *MockEvents) EXPECT() *MockEventsMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockEvents) Create(userId int, event domain.SaveEventRequest) (int, error) {
+// Cancel mocks base method.
+func (m *MockEvents) Cancel(actor domain.Actor, eventId int, reason string) (domain.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", userId, event)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "Cancel", actor, eventId, reason)
+	ret0, _ := ret[0].(domain.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// Cancel indicates an expected call of Cancel.
+func (mr *MockEventsMockRecorder) Cancel(actor, eventId, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockEvents)(nil).Cancel), actor, eventId, reason)
+}
+
+// Create mocks base method.
+func (m *MockEvents) Create(actor domain.Actor, event domain.SaveEventRequest, check domain.ConflictCheck) (int, []domain.AvailabilityWarning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", actor, event, check)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]domain.AvailabilityWarning)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // Create indicates an expected call of Create.
-func (mr *MockEventsMockRecorder) Create(userId, event interface{}) *gomock.Call {
+func (mr *MockEventsMockRecorder) Create(actor, event, check interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEvents)(nil).Create), userId, event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEvents)(nil).Create), actor, event, check)
 }
 
 // Delete mocks base method.
-func (m *MockEvents) Delete(userId, eventId int) error {
+func (m *MockEvents) Delete(actor domain.Actor, eventId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId, eventId)
+	ret := m.ctrl.Call(m, "Delete", actor, eventId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockEventsMockRecorder) Delete(userId, eventId interface{}) *gomock.Call {
+func (mr *MockEventsMockRecorder) Delete(actor, eventId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEvents)(nil).Delete), userId, eventId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEvents)(nil).Delete), actor, eventId)
 }
 
 // GetAll mocks base method.
-func (m *MockEvents) GetAll(userId int) ([]domain.Event, error) {
+func (m *MockEvents) GetAll(actor domain.Actor, filter domain.EventsFilter) ([]domain.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret := m.ctrl.Call(m, "GetAll", actor, filter)
 	ret0, _ := ret[0].([]domain.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockEventsMockRecorder) GetAll(userId interface{}) *gomock.Call {
+func (mr *MockEventsMockRecorder) GetAll(actor, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockEvents)(nil).GetAll), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockEvents)(nil).GetAll), actor, filter)
 }
 
 // GetById mocks base method.
-func (m *MockEvents) GetById(userId, eventId int) (domain.Event, error) {
+func (m *MockEvents) GetById(actor domain.Actor, eventId int) (domain.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", userId, eventId)
+	ret := m.ctrl.Call(m, "GetById", actor, eventId)
 	ret0, _ := ret[0].(domain.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockEventsMockRecorder) GetById(userId, eventId interface{}) *gomock.Call {
+func (mr *MockEventsMockRecorder) GetById(actor, eventId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockEvents)(nil).GetById), actor, eventId)
+}
+
+// GetConflicts mocks base method.
+func (m *MockEvents) GetConflicts(actor domain.Actor) ([]domain.EventOverlap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConflicts", actor)
+	ret0, _ := ret[0].([]domain.EventOverlap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConflicts indicates an expected call of GetConflicts.
+func (mr *MockEventsMockRecorder) GetConflicts(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConflicts", reflect.TypeOf((*MockEvents)(nil).GetConflicts), actor)
+}
+
+// GetNearby mocks base method.
+func (m *MockEvents) GetNearby(actor domain.Actor, filter domain.NearbyFilter) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearby", actor, filter)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearby indicates an expected call of GetNearby.
+func (mr *MockEventsMockRecorder) GetNearby(actor, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearby", reflect.TypeOf((*MockEvents)(nil).GetNearby), actor, filter)
+}
+
+// Publish mocks base method.
+func (m *MockEvents) Publish(actor domain.Actor, eventId int) (domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", actor, eventId)
+	ret0, _ := ret[0].(domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventsMockRecorder) Publish(actor, eventId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEvents)(nil).Publish), actor, eventId)
+}
+
+// Reopen mocks base method.
+func (m *MockEvents) Reopen(actor domain.Actor, eventId int) (domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reopen", actor, eventId)
+	ret0, _ := ret[0].(domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reopen indicates an expected call of Reopen.
+func (mr *MockEventsMockRecorder) Reopen(actor, eventId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockEvents)(nil).GetById), userId, eventId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reopen", reflect.TypeOf((*MockEvents)(nil).Reopen), actor, eventId)
 }
 
 // Update mocks base method.
-func (m *MockEvents) Update(userId, eventId int, event domain.SaveEventRequest) (domain.Event, error) {
+func (m *MockEvents) Update(actor domain.Actor, eventId int, event domain.SaveEventRequest, check domain.ConflictCheck) (domain.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", userId, eventId, event)
+	ret := m.ctrl.Call(m, "Update", actor, eventId, event, check)
 	ret0, _ := ret[0].(domain.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockEventsMockRecorder) Update(userId, eventId, event interface{}) *gomock.Call {
+func (mr *MockEventsMockRecorder) Update(actor, eventId, event, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEvents)(nil).Update), actor, eventId, event, check)
+}
+
+// MockLoginGuard is a mock of LoginGuard interface.
+type MockLoginGuard struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginGuardMockRecorder
+}
+
+// MockLoginGuardMockRecorder is the mock recorder for MockLoginGuard.
+type MockLoginGuardMockRecorder struct {
+	mock *MockLoginGuard
+}
+
+// NewMockLoginGuard creates a new mock instance.
+func NewMockLoginGuard(ctrl *gomock.Controller) *MockLoginGuard {
+	mock := &MockLoginGuard{ctrl: ctrl}
+	mock.recorder = &MockLoginGuardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginGuard) EXPECT() *MockLoginGuardMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockLoginGuard) Check(username, ip string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", username, ip)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockLoginGuardMockRecorder) Check(username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockLoginGuard)(nil).Check), username, ip)
+}
+
+// RegisterFailure mocks base method.
+func (m *MockLoginGuard) RegisterFailure(username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFailure", username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterFailure indicates an expected call of RegisterFailure.
+func (mr *MockLoginGuardMockRecorder) RegisterFailure(username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFailure", reflect.TypeOf((*MockLoginGuard)(nil).RegisterFailure), username, ip)
+}
+
+// RegisterSuccess mocks base method.
+func (m *MockLoginGuard) RegisterSuccess(username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterSuccess", username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterSuccess indicates an expected call of RegisterSuccess.
+func (mr *MockLoginGuardMockRecorder) RegisterSuccess(username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSuccess", reflect.TypeOf((*MockLoginGuard)(nil).RegisterSuccess), username, ip)
+}
+
+// MockAccessTokens is a mock of AccessTokens interface.
+type MockAccessTokens struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessTokensMockRecorder
+}
+
+// MockAccessTokensMockRecorder is the mock recorder for MockAccessTokens.
+type MockAccessTokensMockRecorder struct {
+	mock *MockAccessTokens
+}
+
+// NewMockAccessTokens creates a new mock instance.
+func NewMockAccessTokens(ctrl *gomock.Controller) *MockAccessTokens {
+	mock := &MockAccessTokens{ctrl: ctrl}
+	mock.recorder = &MockAccessTokensMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessTokens) EXPECT() *MockAccessTokensMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockAccessTokens) Authenticate(token string) (domain.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", token)
+	ret0, _ := ret[0].(domain.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAccessTokensMockRecorder) Authenticate(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAccessTokens)(nil).Authenticate), token)
+}
+
+// Create mocks base method.
+func (m *MockAccessTokens) Create(actor domain.Actor, request domain.CreateAccessTokenRequest) (domain.CreatedAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", actor, request)
+	ret0, _ := ret[0].(domain.CreatedAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAccessTokensMockRecorder) Create(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccessTokens)(nil).Create), actor, request)
+}
+
+// GetAll mocks base method.
+func (m *MockAccessTokens) GetAll(userId int) ([]domain.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]domain.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAccessTokensMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAccessTokens)(nil).GetAll), userId)
+}
+
+// Revoke mocks base method.
+func (m *MockAccessTokens) Revoke(userId, tokenId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", userId, tokenId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAccessTokensMockRecorder) Revoke(userId, tokenId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAccessTokens)(nil).Revoke), userId, tokenId)
+}
+
+// MockSigningKeys is a mock of SigningKeys interface.
+type MockSigningKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockSigningKeysMockRecorder
+}
+
+// MockSigningKeysMockRecorder is the mock recorder for MockSigningKeys.
+type MockSigningKeysMockRecorder struct {
+	mock *MockSigningKeys
+}
+
+// NewMockSigningKeys creates a new mock instance.
+func NewMockSigningKeys(ctrl *gomock.Controller) *MockSigningKeys {
+	mock := &MockSigningKeys{ctrl: ctrl}
+	mock.recorder = &MockSigningKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigningKeys) EXPECT() *MockSigningKeysMockRecorder {
+	return m.recorder
+}
+
+// JWKS mocks base method.
+func (m *MockSigningKeys) JWKS() (signing.JWKSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(signing.JWKSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockSigningKeysMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockSigningKeys)(nil).JWKS))
+}
+
+// RunRotation mocks base method.
+func (m *MockSigningKeys) RunRotation(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunRotation", ctx)
+}
+
+// RunRotation indicates an expected call of RunRotation.
+func (mr *MockSigningKeysMockRecorder) RunRotation(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRotation", reflect.TypeOf((*MockSigningKeys)(nil).RunRotation), ctx)
+}
+
+// MockOidc is a mock of Oidc interface.
+type MockOidc struct {
+	ctrl     *gomock.Controller
+	recorder *MockOidcMockRecorder
+}
+
+// MockOidcMockRecorder is the mock recorder for MockOidc.
+type MockOidcMockRecorder struct {
+	mock *MockOidc
+}
+
+// NewMockOidc creates a new mock instance.
+func NewMockOidc(ctrl *gomock.Controller) *MockOidc {
+	mock := &MockOidc{ctrl: ctrl}
+	mock.recorder = &MockOidcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOidc) EXPECT() *MockOidcMockRecorder {
+	return m.recorder
+}
+
+// Callback mocks base method.
+func (m *MockOidc) Callback(code, state string) (domain.SignInResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Callback", code, state)
+	ret0, _ := ret[0].(domain.SignInResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Callback indicates an expected call of Callback.
+func (mr *MockOidcMockRecorder) Callback(code, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Callback", reflect.TypeOf((*MockOidc)(nil).Callback), code, state)
+}
+
+// LoginUrl mocks base method.
+func (m *MockOidc) LoginUrl() (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUrl")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoginUrl indicates an expected call of LoginUrl.
+func (mr *MockOidcMockRecorder) LoginUrl() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUrl", reflect.TypeOf((*MockOidc)(nil).LoginUrl))
+}
+
+// MockPolicy is a mock of Policy interface.
+type MockPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyMockRecorder
+}
+
+// MockPolicyMockRecorder is the mock recorder for MockPolicy.
+type MockPolicyMockRecorder struct {
+	mock *MockPolicy
+}
+
+// NewMockPolicy creates a new mock instance.
+func NewMockPolicy(ctrl *gomock.Controller) *MockPolicy {
+	mock := &MockPolicy{ctrl: ctrl}
+	mock.recorder = &MockPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicy) EXPECT() *MockPolicyMockRecorder {
+	return m.recorder
+}
+
+// HasPermission mocks base method.
+func (m *MockPolicy) HasPermission(userId int, permission string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPermission", userId, permission)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPermission indicates an expected call of HasPermission.
+func (mr *MockPolicyMockRecorder) HasPermission(userId, permission interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockPolicy)(nil).HasPermission), userId, permission)
+}
+
+// MockAdmin is a mock of Admin interface.
+type MockAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminMockRecorder
+}
+
+// MockAdminMockRecorder is the mock recorder for MockAdmin.
+type MockAdminMockRecorder struct {
+	mock *MockAdmin
+}
+
+// NewMockAdmin creates a new mock instance.
+func NewMockAdmin(ctrl *gomock.Controller) *MockAdmin {
+	mock := &MockAdmin{ctrl: ctrl}
+	mock.recorder = &MockAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdmin) EXPECT() *MockAdminMockRecorder {
+	return m.recorder
+}
+
+// GetEvents mocks base method.
+func (m *MockAdmin) GetEvents() ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents")
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockAdminMockRecorder) GetEvents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockAdmin)(nil).GetEvents))
+}
+
+// GetUsers mocks base method.
+func (m *MockAdmin) GetUsers() ([]domain.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers")
+	ret0, _ := ret[0].([]domain.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockAdminMockRecorder) GetUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockAdmin)(nil).GetUsers))
+}
+
+// SetMfaRequired mocks base method.
+func (m *MockAdmin) SetMfaRequired(userId int, required bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMfaRequired", userId, required)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMfaRequired indicates an expected call of SetMfaRequired.
+func (mr *MockAdminMockRecorder) SetMfaRequired(userId, required interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMfaRequired", reflect.TypeOf((*MockAdmin)(nil).SetMfaRequired), userId, required)
+}
+
+// SetRole mocks base method.
+func (m *MockAdmin) SetRole(actorId, userId int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", actorId, userId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockAdminMockRecorder) SetRole(actorId, userId, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockAdmin)(nil).SetRole), actorId, userId, role)
+}
+
+// MockOrganizations is a mock of Organizations interface.
+type MockOrganizations struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationsMockRecorder
+}
+
+// MockOrganizationsMockRecorder is the mock recorder for MockOrganizations.
+type MockOrganizationsMockRecorder struct {
+	mock *MockOrganizations
+}
+
+// NewMockOrganizations creates a new mock instance.
+func NewMockOrganizations(ctrl *gomock.Controller) *MockOrganizations {
+	mock := &MockOrganizations{ctrl: ctrl}
+	mock.recorder = &MockOrganizationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizations) EXPECT() *MockOrganizationsMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockOrganizations) AddMember(userId, organizationId int, request domain.AddMemberRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", userId, organizationId, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockOrganizationsMockRecorder) AddMember(userId, organizationId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockOrganizations)(nil).AddMember), userId, organizationId, request)
+}
+
+// Create mocks base method.
+func (m *MockOrganizations) Create(userId int, request domain.CreateOrganizationRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, request)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationsMockRecorder) Create(userId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganizations)(nil).Create), userId, request)
+}
+
+// GetAll mocks base method.
+func (m *MockOrganizations) GetAll(userId int) ([]domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockOrganizationsMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockOrganizations)(nil).GetAll), userId)
+}
+
+// GetMembers mocks base method.
+func (m *MockOrganizations) GetMembers(userId, organizationId int) ([]domain.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", userId, organizationId)
+	ret0, _ := ret[0].([]domain.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockOrganizationsMockRecorder) GetMembers(userId, organizationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockOrganizations)(nil).GetMembers), userId, organizationId)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganizations) RemoveMember(userId, organizationId, memberId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", userId, organizationId, memberId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationsMockRecorder) RemoveMember(userId, organizationId, memberId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizations)(nil).RemoveMember), userId, organizationId, memberId)
+}
+
+// MockEventGrants is a mock of EventGrants interface.
+type MockEventGrants struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventGrantsMockRecorder
+}
+
+// MockEventGrantsMockRecorder is the mock recorder for MockEventGrants.
+type MockEventGrantsMockRecorder struct {
+	mock *MockEventGrants
+}
+
+// NewMockEventGrants creates a new mock instance.
+func NewMockEventGrants(ctrl *gomock.Controller) *MockEventGrants {
+	mock := &MockEventGrants{ctrl: ctrl}
+	mock.recorder = &MockEventGrantsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventGrants) EXPECT() *MockEventGrantsMockRecorder {
+	return m.recorder
+}
+
+// DeleteGrant mocks base method.
+func (m *MockEventGrants) DeleteGrant(actor domain.Actor, eventId, grantId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGrant", actor, eventId, grantId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGrant indicates an expected call of DeleteGrant.
+func (mr *MockEventGrantsMockRecorder) DeleteGrant(actor, eventId, grantId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGrant", reflect.TypeOf((*MockEventGrants)(nil).DeleteGrant), actor, eventId, grantId)
+}
+
+// GetGrants mocks base method.
+func (m *MockEventGrants) GetGrants(actor domain.Actor, eventId int) ([]domain.EventGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrants", actor, eventId)
+	ret0, _ := ret[0].([]domain.EventGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrants indicates an expected call of GetGrants.
+func (mr *MockEventGrantsMockRecorder) GetGrants(actor, eventId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrants", reflect.TypeOf((*MockEventGrants)(nil).GetGrants), actor, eventId)
+}
+
+// SaveGrant mocks base method.
+func (m *MockEventGrants) SaveGrant(actor domain.Actor, eventId int, request domain.SaveGrantRequest) (domain.EventGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGrant", actor, eventId, request)
+	ret0, _ := ret[0].(domain.EventGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveGrant indicates an expected call of SaveGrant.
+func (mr *MockEventGrantsMockRecorder) SaveGrant(actor, eventId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGrant", reflect.TypeOf((*MockEventGrants)(nil).SaveGrant), actor, eventId, request)
+}
+
+// MockFreeBusy is a mock of FreeBusy interface.
+type MockFreeBusy struct {
+	ctrl     *gomock.Controller
+	recorder *MockFreeBusyMockRecorder
+}
+
+// MockFreeBusyMockRecorder is the mock recorder for MockFreeBusy.
+type MockFreeBusyMockRecorder struct {
+	mock *MockFreeBusy
+}
+
+// NewMockFreeBusy creates a new mock instance.
+func NewMockFreeBusy(ctrl *gomock.Controller) *MockFreeBusy {
+	mock := &MockFreeBusy{ctrl: ctrl}
+	mock.recorder = &MockFreeBusyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFreeBusy) EXPECT() *MockFreeBusyMockRecorder {
+	return m.recorder
+}
+
+// GetFreeBusy mocks base method.
+func (m *MockFreeBusy) GetFreeBusy(actor domain.Actor, request domain.FreeBusyRequest) ([]domain.UserFreeBusy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFreeBusy", actor, request)
+	ret0, _ := ret[0].([]domain.UserFreeBusy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFreeBusy indicates an expected call of GetFreeBusy.
+func (mr *MockFreeBusyMockRecorder) GetFreeBusy(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFreeBusy", reflect.TypeOf((*MockFreeBusy)(nil).GetFreeBusy), actor, request)
+}
+
+// MockScheduling is a mock of Scheduling interface.
+type MockScheduling struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulingMockRecorder
+}
+
+// MockSchedulingMockRecorder is the mock recorder for MockScheduling.
+type MockSchedulingMockRecorder struct {
+	mock *MockScheduling
+}
+
+// NewMockScheduling creates a new mock instance.
+func NewMockScheduling(ctrl *gomock.Controller) *MockScheduling {
+	mock := &MockScheduling{ctrl: ctrl}
+	mock.recorder = &MockSchedulingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduling) EXPECT() *MockSchedulingMockRecorder {
+	return m.recorder
+}
+
+// SuggestSlots mocks base method.
+func (m *MockScheduling) SuggestSlots(actor domain.Actor, request domain.SuggestSlotsRequest) ([]domain.SuggestedSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestSlots", actor, request)
+	ret0, _ := ret[0].([]domain.SuggestedSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestSlots indicates an expected call of SuggestSlots.
+func (mr *MockSchedulingMockRecorder) SuggestSlots(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestSlots", reflect.TypeOf((*MockScheduling)(nil).SuggestSlots), actor, request)
+}
+
+// MockGroups is a mock of Groups interface.
+type MockGroups struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupsMockRecorder
+}
+
+// MockGroupsMockRecorder is the mock recorder for MockGroups.
+type MockGroupsMockRecorder struct {
+	mock *MockGroups
+}
+
+// NewMockGroups creates a new mock instance.
+func NewMockGroups(ctrl *gomock.Controller) *MockGroups {
+	mock := &MockGroups{ctrl: ctrl}
+	mock.recorder = &MockGroupsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroups) EXPECT() *MockGroupsMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockGroups) AddMember(actor domain.Actor, groupId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", actor, groupId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockGroupsMockRecorder) AddMember(actor, groupId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockGroups)(nil).AddMember), actor, groupId, userId)
+}
+
+// Create mocks base method.
+func (m *MockGroups) Create(actor domain.Actor, request domain.CreateGroupRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", actor, request)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGroupsMockRecorder) Create(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGroups)(nil).Create), actor, request)
+}
+
+// GetAll mocks base method.
+func (m *MockGroups) GetAll(actor domain.Actor) ([]domain.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", actor)
+	ret0, _ := ret[0].([]domain.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockGroupsMockRecorder) GetAll(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockGroups)(nil).GetAll), actor)
+}
+
+// GetMembers mocks base method.
+func (m *MockGroups) GetMembers(actor domain.Actor, groupId int) ([]domain.GroupMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", actor, groupId)
+	ret0, _ := ret[0].([]domain.GroupMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockGroupsMockRecorder) GetMembers(actor, groupId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockGroups)(nil).GetMembers), actor, groupId)
+}
+
+// RemoveMember mocks base method.
+func (m *MockGroups) RemoveMember(actor domain.Actor, groupId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", actor, groupId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockGroupsMockRecorder) RemoveMember(actor, groupId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockGroups)(nil).RemoveMember), actor, groupId, userId)
+}
+
+// MockDelegations is a mock of Delegations interface.
+type MockDelegations struct {
+	ctrl     *gomock.Controller
+	recorder *MockDelegationsMockRecorder
+}
+
+// MockDelegationsMockRecorder is the mock recorder for MockDelegations.
+type MockDelegationsMockRecorder struct {
+	mock *MockDelegations
+}
+
+// NewMockDelegations creates a new mock instance.
+func NewMockDelegations(ctrl *gomock.Controller) *MockDelegations {
+	mock := &MockDelegations{ctrl: ctrl}
+	mock.recorder = &MockDelegationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDelegations) EXPECT() *MockDelegationsMockRecorder {
+	return m.recorder
+}
+
+// Access mocks base method.
+func (m *MockDelegations) Access(actor domain.Actor, principalId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Access", actor, principalId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Access indicates an expected call of Access.
+func (mr *MockDelegationsMockRecorder) Access(actor, principalId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Access", reflect.TypeOf((*MockDelegations)(nil).Access), actor, principalId)
+}
+
+// Delete mocks base method.
+func (m *MockDelegations) Delete(actor domain.Actor, delegationId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", actor, delegationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDelegationsMockRecorder) Delete(actor, delegationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDelegations)(nil).Delete), actor, delegationId)
+}
+
+// GetAll mocks base method.
+func (m *MockDelegations) GetAll(actor domain.Actor) ([]domain.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", actor)
+	ret0, _ := ret[0].([]domain.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDelegationsMockRecorder) GetAll(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDelegations)(nil).GetAll), actor)
+}
+
+// Save mocks base method.
+func (m *MockDelegations) Save(actor domain.Actor, request domain.SaveDelegationRequest) (domain.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", actor, request)
+	ret0, _ := ret[0].(domain.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockDelegationsMockRecorder) Save(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockDelegations)(nil).Save), actor, request)
+}
+
+// MockAudit is a mock of Audit interface.
+type MockAudit struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditMockRecorder
+}
+
+// MockAuditMockRecorder is the mock recorder for MockAudit.
+type MockAuditMockRecorder struct {
+	mock *MockAudit
+}
+
+// NewMockAudit creates a new mock instance.
+func NewMockAudit(ctrl *gomock.Controller) *MockAudit {
+	mock := &MockAudit{ctrl: ctrl}
+	mock.recorder = &MockAuditMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudit) EXPECT() *MockAuditMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockAudit) GetAll(actor domain.Actor) ([]domain.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", actor)
+	ret0, _ := ret[0].([]domain.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAuditMockRecorder) GetAll(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAudit)(nil).GetAll), actor)
+}
+
+// MockCalendars is a mock of Calendars interface.
+type MockCalendars struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarsMockRecorder
+}
+
+// MockCalendarsMockRecorder is the mock recorder for MockCalendars.
+type MockCalendarsMockRecorder struct {
+	mock *MockCalendars
+}
+
+// NewMockCalendars creates a new mock instance.
+func NewMockCalendars(ctrl *gomock.Controller) *MockCalendars {
+	mock := &MockCalendars{ctrl: ctrl}
+	mock.recorder = &MockCalendarsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendars) EXPECT() *MockCalendarsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCalendars) Create(actor domain.Actor, request domain.SaveCalendarRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", actor, request)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCalendarsMockRecorder) Create(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCalendars)(nil).Create), actor, request)
+}
+
+// Delete mocks base method.
+func (m *MockCalendars) Delete(actor domain.Actor, calendarId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", actor, calendarId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCalendarsMockRecorder) Delete(actor, calendarId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCalendars)(nil).Delete), actor, calendarId)
+}
+
+// GetAll mocks base method.
+func (m *MockCalendars) GetAll(actor domain.Actor) ([]domain.Calendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", actor)
+	ret0, _ := ret[0].([]domain.Calendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCalendarsMockRecorder) GetAll(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCalendars)(nil).GetAll), actor)
+}
+
+// GetById mocks base method.
+func (m *MockCalendars) GetById(actor domain.Actor, calendarId int) (domain.Calendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", actor, calendarId)
+	ret0, _ := ret[0].(domain.Calendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockCalendarsMockRecorder) GetById(actor, calendarId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockCalendars)(nil).GetById), actor, calendarId)
+}
+
+// Update mocks base method.
+func (m *MockCalendars) Update(actor domain.Actor, calendarId int, request domain.SaveCalendarRequest) (domain.Calendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", actor, calendarId, request)
+	ret0, _ := ret[0].(domain.Calendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCalendarsMockRecorder) Update(actor, calendarId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCalendars)(nil).Update), actor, calendarId, request)
+}
+
+// MockPublicEvents is a mock of PublicEvents interface.
+type MockPublicEvents struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublicEventsMockRecorder
+}
+
+// MockPublicEventsMockRecorder is the mock recorder for MockPublicEvents.
+type MockPublicEventsMockRecorder struct {
+	mock *MockPublicEvents
+}
+
+// NewMockPublicEvents creates a new mock instance.
+func NewMockPublicEvents(ctrl *gomock.Controller) *MockPublicEvents {
+	mock := &MockPublicEvents{ctrl: ctrl}
+	mock.recorder = &MockPublicEventsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublicEvents) EXPECT() *MockPublicEventsMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockPublicEvents) GetAll() ([]domain.PublicEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]domain.PublicEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPublicEventsMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPublicEvents)(nil).GetAll))
+}
+
+// GetBySlug mocks base method.
+func (m *MockPublicEvents) GetBySlug(slug string) (domain.PublicEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", slug)
+	ret0, _ := ret[0].(domain.PublicEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockPublicEventsMockRecorder) GetBySlug(slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockPublicEvents)(nil).GetBySlug), slug)
+}
+
+// MockRateLimit is a mock of RateLimit interface.
+type MockRateLimit struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitMockRecorder
+}
+
+// MockRateLimitMockRecorder is the mock recorder for MockRateLimit.
+type MockRateLimitMockRecorder struct {
+	mock *MockRateLimit
+}
+
+// NewMockRateLimit creates a new mock instance.
+func NewMockRateLimit(ctrl *gomock.Controller) *MockRateLimit {
+	mock := &MockRateLimit{ctrl: ctrl}
+	mock.recorder = &MockRateLimitMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimit) EXPECT() *MockRateLimitMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockRateLimit) Allow(key string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", key)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockRateLimitMockRecorder) Allow(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockRateLimit)(nil).Allow), key)
+}
+
+// MockAvailability is a mock of Availability interface.
+type MockAvailability struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvailabilityMockRecorder
+}
+
+// MockAvailabilityMockRecorder is the mock recorder for MockAvailability.
+type MockAvailabilityMockRecorder struct {
+	mock *MockAvailability
+}
+
+// NewMockAvailability creates a new mock instance.
+func NewMockAvailability(ctrl *gomock.Controller) *MockAvailability {
+	mock := &MockAvailability{ctrl: ctrl}
+	mock.recorder = &MockAvailabilityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvailability) EXPECT() *MockAvailabilityMockRecorder {
+	return m.recorder
+}
+
+// AddOutOfOffice mocks base method.
+func (m *MockAvailability) AddOutOfOffice(actor domain.Actor, request domain.SaveOutOfOfficeRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOutOfOffice", actor, request)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOutOfOffice indicates an expected call of AddOutOfOffice.
+func (mr *MockAvailabilityMockRecorder) AddOutOfOffice(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOutOfOffice", reflect.TypeOf((*MockAvailability)(nil).AddOutOfOffice), actor, request)
+}
+
+// DeleteOutOfOffice mocks base method.
+func (m *MockAvailability) DeleteOutOfOffice(actor domain.Actor, outOfOfficeId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutOfOffice", actor, outOfOfficeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOutOfOffice indicates an expected call of DeleteOutOfOffice.
+func (mr *MockAvailabilityMockRecorder) DeleteOutOfOffice(actor, outOfOfficeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutOfOffice", reflect.TypeOf((*MockAvailability)(nil).DeleteOutOfOffice), actor, outOfOfficeId)
+}
+
+// Get mocks base method.
+func (m *MockAvailability) Get(actor domain.Actor) (domain.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", actor)
+	ret0, _ := ret[0].(domain.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAvailabilityMockRecorder) Get(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAvailability)(nil).Get), actor)
+}
+
+// Save mocks base method.
+func (m *MockAvailability) Save(actor domain.Actor, request domain.SaveAvailabilityRequest) (domain.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", actor, request)
+	ret0, _ := ret[0].(domain.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAvailabilityMockRecorder) Save(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAvailability)(nil).Save), actor, request)
+}
+
+// MockBookingPages is a mock of BookingPages interface.
+type MockBookingPages struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingPagesMockRecorder
+}
+
+// MockBookingPagesMockRecorder is the mock recorder for MockBookingPages.
+type MockBookingPagesMockRecorder struct {
+	mock *MockBookingPages
+}
+
+// NewMockBookingPages creates a new mock instance.
+func NewMockBookingPages(ctrl *gomock.Controller) *MockBookingPages {
+	mock := &MockBookingPages{ctrl: ctrl}
+	mock.recorder = &MockBookingPagesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingPages) EXPECT() *MockBookingPagesMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBookingPages) Create(actor domain.Actor, request domain.SaveBookingPageRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", actor, request)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBookingPagesMockRecorder) Create(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookingPages)(nil).Create), actor, request)
+}
+
+// Delete mocks base method.
+func (m *MockBookingPages) Delete(actor domain.Actor, pageId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", actor, pageId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBookingPagesMockRecorder) Delete(actor, pageId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBookingPages)(nil).Delete), actor, pageId)
+}
+
+// GetAll mocks base method.
+func (m *MockBookingPages) GetAll(actor domain.Actor) ([]domain.BookingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", actor)
+	ret0, _ := ret[0].([]domain.BookingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockBookingPagesMockRecorder) GetAll(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockBookingPages)(nil).GetAll), actor)
+}
+
+// GetById mocks base method.
+func (m *MockBookingPages) GetById(actor domain.Actor, pageId int) (domain.BookingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", actor, pageId)
+	ret0, _ := ret[0].(domain.BookingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockBookingPagesMockRecorder) GetById(actor, pageId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockBookingPages)(nil).GetById), actor, pageId)
+}
+
+// Update mocks base method.
+func (m *MockBookingPages) Update(actor domain.Actor, pageId int, request domain.SaveBookingPageRequest) (domain.BookingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", actor, pageId, request)
+	ret0, _ := ret[0].(domain.BookingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBookingPagesMockRecorder) Update(actor, pageId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookingPages)(nil).Update), actor, pageId, request)
+}
+
+// MockBookings is a mock of Bookings interface.
+type MockBookings struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingsMockRecorder
+}
+
+// MockBookingsMockRecorder is the mock recorder for MockBookings.
+type MockBookingsMockRecorder struct {
+	mock *MockBookings
+}
+
+// NewMockBookings creates a new mock instance.
+func NewMockBookings(ctrl *gomock.Controller) *MockBookings {
+	mock := &MockBookings{ctrl: ctrl}
+	mock.recorder = &MockBookingsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookings) EXPECT() *MockBookingsMockRecorder {
+	return m.recorder
+}
+
+// Book mocks base method.
+func (m *MockBookings) Book(slug string, request domain.BookingRequest) (domain.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Book", slug, request)
+	ret0, _ := ret[0].(domain.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Book indicates an expected call of Book.
+func (mr *MockBookingsMockRecorder) Book(slug, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Book", reflect.TypeOf((*MockBookings)(nil).Book), slug, request)
+}
+
+// GetPage mocks base method.
+func (m *MockBookings) GetPage(slug string) (domain.PublicBookingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", slug)
+	ret0, _ := ret[0].(domain.PublicBookingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockBookingsMockRecorder) GetPage(slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockBookings)(nil).GetPage), slug)
+}
+
+// GetSlots mocks base method.
+func (m *MockBookings) GetSlots(slug string, from, to time.Time) ([]domain.BookingSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlots", slug, from, to)
+	ret0, _ := ret[0].([]domain.BookingSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlots indicates an expected call of GetSlots.
+func (mr *MockBookingsMockRecorder) GetSlots(slug, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlots", reflect.TypeOf((*MockBookings)(nil).GetSlots), slug, from, to)
+}
+
+// MockResources is a mock of Resources interface.
+type MockResources struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourcesMockRecorder
+}
+
+// MockResourcesMockRecorder is the mock recorder for MockResources.
+type MockResourcesMockRecorder struct {
+	mock *MockResources
+}
+
+// NewMockResources creates a new mock instance.
+func NewMockResources(ctrl *gomock.Controller) *MockResources {
+	mock := &MockResources{ctrl: ctrl}
+	mock.recorder = &MockResourcesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResources) EXPECT() *MockResourcesMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockResources) Create(actor domain.Actor, request domain.SaveResourceRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", actor, request)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockResourcesMockRecorder) Create(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockResources)(nil).Create), actor, request)
+}
+
+// Delete mocks base method.
+func (m *MockResources) Delete(actor domain.Actor, resourceId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", actor, resourceId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockResourcesMockRecorder) Delete(actor, resourceId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockResources)(nil).Delete), actor, resourceId)
+}
+
+// GetAll mocks base method.
+func (m *MockResources) GetAll(actor domain.Actor) ([]domain.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", actor)
+	ret0, _ := ret[0].([]domain.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockResourcesMockRecorder) GetAll(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockResources)(nil).GetAll), actor)
+}
+
+// GetAvailability mocks base method.
+func (m *MockResources) GetAvailability(actor domain.Actor, resourceId int, filter domain.TimeRangeFilter) (domain.ResourceAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailability", actor, resourceId, filter)
+	ret0, _ := ret[0].(domain.ResourceAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailability indicates an expected call of GetAvailability.
+func (mr *MockResourcesMockRecorder) GetAvailability(actor, resourceId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailability", reflect.TypeOf((*MockResources)(nil).GetAvailability), actor, resourceId, filter)
+}
+
+// GetUtilization mocks base method.
+func (m *MockResources) GetUtilization(actor domain.Actor, filter domain.TimeRangeFilter) ([]domain.ResourceUtilization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUtilization", actor, filter)
+	ret0, _ := ret[0].([]domain.ResourceUtilization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUtilization indicates an expected call of GetUtilization.
+func (mr *MockResourcesMockRecorder) GetUtilization(actor, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUtilization", reflect.TypeOf((*MockResources)(nil).GetUtilization), actor, filter)
+}
+
+// Update mocks base method.
+func (m *MockResources) Update(actor domain.Actor, resourceId int, request domain.SaveResourceRequest) (domain.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", actor, resourceId, request)
+	ret0, _ := ret[0].(domain.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockResourcesMockRecorder) Update(actor, resourceId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockResources)(nil).Update), actor, resourceId, request)
+}
+
+// MockTags is a mock of Tags interface.
+type MockTags struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagsMockRecorder
+}
+
+// MockTagsMockRecorder is the mock recorder for MockTags.
+type MockTagsMockRecorder struct {
+	mock *MockTags
+}
+
+// NewMockTags creates a new mock instance.
+func NewMockTags(ctrl *gomock.Controller) *MockTags {
+	mock := &MockTags{ctrl: ctrl}
+	mock.recorder = &MockTagsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTags) EXPECT() *MockTagsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTags) Create(actor domain.Actor, request domain.SaveTagRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", actor, request)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTagsMockRecorder) Create(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTags)(nil).Create), actor, request)
+}
+
+// Delete mocks base method.
+func (m *MockTags) Delete(actor domain.Actor, tagId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", actor, tagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagsMockRecorder) Delete(actor, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTags)(nil).Delete), actor, tagId)
+}
+
+// GetAll mocks base method.
+func (m *MockTags) GetAll(actor domain.Actor) ([]domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", actor)
+	ret0, _ := ret[0].([]domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTagsMockRecorder) GetAll(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTags)(nil).GetAll), actor)
+}
+
+// Update mocks base method.
+func (m *MockTags) Update(actor domain.Actor, tagId int, request domain.SaveTagRequest) (domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", actor, tagId, request)
+	ret0, _ := ret[0].(domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTagsMockRecorder) Update(actor, tagId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTags)(nil).Update), actor, tagId, request)
+}
+
+// MockCustomFields is a mock of CustomFields interface.
+type MockCustomFields struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldsMockRecorder
+}
+
+// MockCustomFieldsMockRecorder is the mock recorder for MockCustomFields.
+type MockCustomFieldsMockRecorder struct {
+	mock *MockCustomFields
+}
+
+// NewMockCustomFields creates a new mock instance.
+func NewMockCustomFields(ctrl *gomock.Controller) *MockCustomFields {
+	mock := &MockCustomFields{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomFields) EXPECT() *MockCustomFieldsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomFields) Create(actor domain.Actor, request domain.SaveCustomFieldRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", actor, request)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomFieldsMockRecorder) Create(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomFields)(nil).Create), actor, request)
+}
+
+// Delete mocks base method.
+func (m *MockCustomFields) Delete(actor domain.Actor, fieldId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", actor, fieldId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomFieldsMockRecorder) Delete(actor, fieldId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomFields)(nil).Delete), actor, fieldId)
+}
+
+// GetAll mocks base method.
+func (m *MockCustomFields) GetAll(actor domain.Actor) ([]domain.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", actor)
+	ret0, _ := ret[0].([]domain.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCustomFieldsMockRecorder) GetAll(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCustomFields)(nil).GetAll), actor)
+}
+
+// Update mocks base method.
+func (m *MockCustomFields) Update(actor domain.Actor, fieldId int, request domain.SaveCustomFieldRequest) (domain.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", actor, fieldId, request)
+	ret0, _ := ret[0].(domain.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCustomFieldsMockRecorder) Update(actor, fieldId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomFields)(nil).Update), actor, fieldId, request)
+}
+
+// MockReminders is a mock of Reminders interface.
+type MockReminders struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemindersMockRecorder
+}
+
+// MockRemindersMockRecorder is the mock recorder for MockReminders.
+type MockRemindersMockRecorder struct {
+	mock *MockReminders
+}
+
+// NewMockReminders creates a new mock instance.
+func NewMockReminders(ctrl *gomock.Controller) *MockReminders {
+	mock := &MockReminders{ctrl: ctrl}
+	mock.recorder = &MockRemindersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminders) EXPECT() *MockRemindersMockRecorder {
+	return m.recorder
+}
+
+// GetDefaults mocks base method.
+func (m *MockReminders) GetDefaults(actor domain.Actor) (domain.ReminderDefaults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaults", actor)
+	ret0, _ := ret[0].(domain.ReminderDefaults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaults indicates an expected call of GetDefaults.
+func (mr *MockRemindersMockRecorder) GetDefaults(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaults", reflect.TypeOf((*MockReminders)(nil).GetDefaults), actor)
+}
+
+// RunScheduler mocks base method.
+func (m *MockReminders) RunScheduler(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunScheduler", ctx)
+}
+
+// RunScheduler indicates an expected call of RunScheduler.
+func (mr *MockRemindersMockRecorder) RunScheduler(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunScheduler", reflect.TypeOf((*MockReminders)(nil).RunScheduler), ctx)
+}
+
+// SaveDefaults mocks base method.
+func (m *MockReminders) SaveDefaults(actor domain.Actor, request domain.ReminderDefaults) (domain.ReminderDefaults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDefaults", actor, request)
+	ret0, _ := ret[0].(domain.ReminderDefaults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveDefaults indicates an expected call of SaveDefaults.
+func (mr *MockRemindersMockRecorder) SaveDefaults(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDefaults", reflect.TypeOf((*MockReminders)(nil).SaveDefaults), actor, request)
+}
+
+// MockDigests is a mock of Digests interface.
+type MockDigests struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestsMockRecorder
+}
+
+// MockDigestsMockRecorder is the mock recorder for MockDigests.
+type MockDigestsMockRecorder struct {
+	mock *MockDigests
+}
+
+// NewMockDigests creates a new mock instance.
+func NewMockDigests(ctrl *gomock.Controller) *MockDigests {
+	mock := &MockDigests{ctrl: ctrl}
+	mock.recorder = &MockDigestsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigests) EXPECT() *MockDigestsMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockDigests) Get(actor domain.Actor) (domain.DigestPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", actor)
+	ret0, _ := ret[0].(domain.DigestPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDigestsMockRecorder) Get(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDigests)(nil).Get), actor)
+}
+
+// RunScheduler mocks base method.
+func (m *MockDigests) RunScheduler(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunScheduler", ctx)
+}
+
+// RunScheduler indicates an expected call of RunScheduler.
+func (mr *MockDigestsMockRecorder) RunScheduler(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunScheduler", reflect.TypeOf((*MockDigests)(nil).RunScheduler), ctx)
+}
+
+// Save mocks base method.
+func (m *MockDigests) Save(actor domain.Actor, request domain.SaveDigestRequest) (domain.DigestPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", actor, request)
+	ret0, _ := ret[0].(domain.DigestPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockDigestsMockRecorder) Save(actor, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockDigests)(nil).Save), actor, request)
+}
+
+// MockNotifications is a mock of Notifications interface.
+type MockNotifications struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationsMockRecorder
+}
+
+// MockNotificationsMockRecorder is the mock recorder for MockNotifications.
+type MockNotificationsMockRecorder struct {
+	mock *MockNotifications
+}
+
+// NewMockNotifications creates a new mock instance.
+func NewMockNotifications(ctrl *gomock.Controller) *MockNotifications {
+	mock := &MockNotifications{ctrl: ctrl}
+	mock.recorder = &MockNotificationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifications) EXPECT() *MockNotificationsMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotifications) GetPreferences(actor domain.Actor) (domain.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", actor)
+	ret0, _ := ret[0].(domain.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationsMockRecorder) GetPreferences(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotifications)(nil).GetPreferences), actor)
+}
+
+// SavePreferences mocks base method.
+func (m *MockNotifications) SavePreferences(actor domain.Actor, request domain.NotificationPreferences) (domain.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreferences", actor, request)
+	ret0, _ := ret[0].(domain.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePreferences indicates an expected call of SavePreferences.
+func (mr *MockNotificationsMockRecorder) SavePreferences(actor, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEvents)(nil).Update), userId, eventId, event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferences", reflect.TypeOf((*MockNotifications)(nil).SavePreferences), actor, request)
 }
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/salesforceanton/events-api/config"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/oidc"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+const (
+	OIDC_LOGIN_STATE_TTL = time.Minute * 10
+	// Attempts to pick free username by adding random suffix to the one from provider
+	OIDC_USERNAME_ATTEMPTS = 5
+)
+
+var (
+	ErrOidcNotConfigured   = errors.New("OpenID Connect login is not configured")
+	ErrOidcInvalidState    = errors.New("Login state is invalid or expired")
+	ErrOidcAccountConflict = errors.New("Account with this email already exists and provider has not verified the email")
+	ErrOidcUsernameTaken   = errors.New("Username from identity provider is already taken")
+	ErrOidcNotProvisioned  = errors.New("No registered User for this identity")
+)
+
+type OidcService struct {
+	client *oidc.Client
+	repo   repository.Oidc
+	users  repository.Authorization
+	auth   *AuthService
+	cfg    *config.Config
+}
+
+func NewOidcService(repo repository.Oidc, users repository.Authorization, auth *AuthService, cfg *config.Config) *OidcService {
+	var client *oidc.Client
+	if cfg.OidcIssuer != "" {
+		client = oidc.NewClient(oidc.Config{
+			Issuer:       cfg.OidcIssuer,
+			ClientId:     cfg.OidcClientId,
+			ClientSecret: cfg.OidcClientSecret,
+			RedirectUrl:  cfg.OidcRedirectUrl,
+			Scopes:       cfg.OidcScopes,
+		})
+	}
+
+	return &OidcService{
+		client: client,
+		repo:   repo,
+		users:  users,
+		auth:   auth,
+		cfg:    cfg,
+	}
+}
+
+// Start authorization code flow - state, nonce and PKCE verifier are stored until the callback.
+// State is returned along with the URL to bind it to the browser which started the login
+func (s *OidcService) LoginUrl() (string, string, error) {
+	if s.client == nil {
+		return "", "", ErrOidcNotConfigured
+	}
+
+	var state domain.OidcLoginState
+	for _, value := range []*string{&state.State, &state.Nonce, &state.CodeVerifier} {
+		random, err := oidc.RandomString()
+		if err != nil {
+			return "", "", err
+		}
+		*value = random
+	}
+	state.ExpiresAt = time.Now().Add(OIDC_LOGIN_STATE_TTL)
+
+	if err := s.repo.SaveLoginState(state); err != nil {
+		return "", "", err
+	}
+
+	url, err := s.client.AuthCodeURL(context.Background(), state.State, state.Nonce, state.CodeVerifier)
+	return url, state.State, err
+}
+
+// Exchange authorization code and sign in the user linked to the external identity.
+// MFA rules apply to these users the same way as to password sign-in
+func (s *OidcService) Callback(code, state string) (domain.SignInResult, error) {
+	if s.client == nil {
+		return domain.SignInResult{}, ErrOidcNotConfigured
+	}
+
+	loginState, err := s.repo.ConsumeLoginState(state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.SignInResult{}, ErrOidcInvalidState
+	}
+	if err != nil {
+		return domain.SignInResult{}, err
+	}
+
+	claims, err := s.client.Exchange(context.Background(), code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return domain.SignInResult{}, err
+	}
+
+	user, err := s.resolveUser(claims)
+	if err != nil {
+		return domain.SignInResult{}, err
+	}
+
+	return s.auth.signIn(user)
+}
+
+func (s *OidcService) resolveUser(claims oidc.Claims) (domain.User, error) {
+	issuer := s.client.Issuer()
+
+	user, err := s.repo.GetUserByIdentity(issuer, claims.Subject)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return domain.User{}, err
+	}
+
+	user, err = s.findOrCreateUser(claims)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	identity := domain.UserIdentity{
+		UserId:  user.Id,
+		Issuer:  issuer,
+		Subject: claims.Subject,
+		Email:   claims.Email,
+	}
+	if err := s.repo.LinkIdentity(identity); err != nil {
+		return domain.User{}, err
+	}
+
+	return user, nil
+}
+
+// Existing account is linked only when provider has verified the email,
+// otherwise anyone could take over the account by registering the same email at the provider
+func (s *OidcService) findOrCreateUser(claims oidc.Claims) (domain.User, error) {
+	if claims.Email != "" {
+		user, err := s.users.GetUserByEmail(claims.Email)
+		if err == nil {
+			if !claims.EmailVerified {
+				return domain.User{}, ErrOidcAccountConflict
+			}
+			return user, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, err
+		}
+	}
+
+	if !s.cfg.OidcAutoProvision {
+		return domain.User{}, ErrOidcNotProvisioned
+	}
+
+	// Password is random so the account can only be accessed via provider until password is reset
+	password, err := oidc.RandomString()
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	username := claims.PreferredUsername
+	if username == "" {
+		username = claims.Email
+	}
+
+	username, err = s.uniqueUsername(username)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	user := domain.User{
+		Email:    claims.Email,
+		Username: username,
+		Password: s.auth.generatePasswordHash(password),
+	}
+
+	user.Id, err = s.users.CreateUser(user)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	if claims.EmailVerified {
+		if err := s.users.SetEmailVerified(user.Id); err != nil {
+			return domain.User{}, err
+		}
+		user.EmailVerified = true
+	}
+
+	return user, nil
+}
+
+// Provider username may belong to another user, which would make sign-in and throttling by username ambiguous
+func (s *OidcService) uniqueUsername(username string) (string, error) {
+	candidate := username
+	for attempt := 0; attempt < OIDC_USERNAME_ATTEMPTS; attempt++ {
+		exists, err := s.users.UsernameExists(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			return "", err
+		}
+		candidate = username + "-" + hex.EncodeToString(suffix)
+	}
+
+	return "", ErrOidcUsernameTaken
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("Organization is not found")
+	ErrMemberNotFound       = errors.New("Member is not found")
+	ErrUnknownOrgRole       = errors.New("Unknown organization role")
+	ErrOwnerRemoval         = errors.New("Organization owner can not be removed")
+)
+
+type OrganizationsService struct {
+	repo  repository.Organizations
+	users repository.Authorization
+}
+
+func NewOrganizationsService(repo repository.Organizations, users repository.Authorization) *OrganizationsService {
+	return &OrganizationsService{
+		repo:  repo,
+		users: users,
+	}
+}
+
+func (s *OrganizationsService) GetAll(userId int) ([]domain.Organization, error) {
+	return s.repo.GetByUser(userId)
+}
+
+func (s *OrganizationsService) Create(userId int, request domain.CreateOrganizationRequest) (int, error) {
+	return s.repo.Create(request.Name, userId)
+}
+
+func (s *OrganizationsService) GetMembers(userId, organizationId int) ([]domain.OrganizationMember, error) {
+	if _, err := s.membership(userId, organizationId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMembers(organizationId)
+}
+
+// Add registered user by email or change role of existing member, only owners and admins manage members.
+// Only owner can grant owner role
+func (s *OrganizationsService) AddMember(userId, organizationId int, request domain.AddMemberRequest) error {
+	if request.Role == "" {
+		request.Role = domain.ORG_ROLE_MEMBER
+	}
+	if !isKnownOrgRole(request.Role) {
+		return ErrUnknownOrgRole
+	}
+
+	actor, err := s.manager(userId, organizationId)
+	if err != nil {
+		return err
+	}
+	if request.Role == domain.ORG_ROLE_OWNER && actor.Role != domain.ORG_ROLE_OWNER {
+		return ErrForbidden
+	}
+
+	user, err := s.users.GetUserByEmail(request.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if current, err := s.repo.GetMember(organizationId, user.Id); err == nil && current.Role == domain.ORG_ROLE_OWNER {
+		return ErrOwnerRemoval
+	}
+
+	return s.repo.SaveMember(organizationId, user.Id, request.Role)
+}
+
+// Managers remove other members, any member can leave organization except the owner
+func (s *OrganizationsService) RemoveMember(userId, organizationId, memberId int) error {
+	if userId != memberId {
+		if _, err := s.manager(userId, organizationId); err != nil {
+			return err
+		}
+	}
+
+	member, err := s.repo.GetMember(organizationId, memberId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrMemberNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if member.Role == domain.ORG_ROLE_OWNER {
+		return ErrOwnerRemoval
+	}
+
+	_, err = s.repo.RemoveMember(organizationId, memberId)
+
+	return err
+}
+
+// Organizations of other users are reported as not found
+func (s *OrganizationsService) membership(userId, organizationId int) (domain.OrganizationMember, error) {
+	member, err := s.repo.GetMember(organizationId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.OrganizationMember{}, ErrOrganizationNotFound
+	}
+
+	return member, err
+}
+
+func (s *OrganizationsService) manager(userId, organizationId int) (domain.OrganizationMember, error) {
+	member, err := s.membership(userId, organizationId)
+	if err != nil {
+		return member, err
+	}
+
+	if member.Role != domain.ORG_ROLE_OWNER && member.Role != domain.ORG_ROLE_ADMIN {
+		return member, ErrForbidden
+	}
+
+	return member, nil
+}
+
+func isKnownOrgRole(role string) bool {
+	return role == domain.ORG_ROLE_OWNER || role == domain.ORG_ROLE_ADMIN || role == domain.ORG_ROLE_MEMBER
+}
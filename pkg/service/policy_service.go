@@ -0,0 +1,107 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+var ErrForbidden = errors.New("Not enough permissions for this operation")
+
+// Actions on events, each action needs event permission of some level or role permission
+const (
+	EVENT_ACTION_READ   = "read"
+	EVENT_ACTION_UPDATE = "update"
+	EVENT_ACTION_DELETE = "delete"
+	EVENT_ACTION_SHARE  = "share"
+	// Change status of the event - publish, cancel, reopen
+	EVENT_ACTION_PUBLISH = "publish"
+)
+
+var eventPermissionLevels = map[string]int{
+	domain.EVENT_PERMISSION_VIEWER:       1,
+	domain.EVENT_PERMISSION_EDITOR:       2,
+	domain.EVENT_PERMISSION_CO_ORGANIZER: 3,
+	domain.EVENT_PERMISSION_ORGANIZER:    4,
+}
+
+var eventActionLevels = map[string]int{
+	EVENT_ACTION_READ:    1,
+	EVENT_ACTION_UPDATE:  2,
+	EVENT_ACTION_DELETE:  3,
+	EVENT_ACTION_SHARE:   3,
+	EVENT_ACTION_PUBLISH: 3,
+}
+
+var eventActionRolePermissions = map[string]string{
+	EVENT_ACTION_READ:    domain.PERMISSION_EVENTS_READ_ANY,
+	EVENT_ACTION_UPDATE:  domain.PERMISSION_EVENTS_WRITE_ANY,
+	EVENT_ACTION_DELETE:  domain.PERMISSION_EVENTS_DELETE_ANY,
+	EVENT_ACTION_SHARE:   domain.PERMISSION_EVENTS_WRITE_ANY,
+	EVENT_ACTION_PUBLISH: domain.PERMISSION_EVENTS_WRITE_ANY,
+}
+
+// Role based and event grants access checks. Role is read on each check so changes apply to issued tokens immediately
+type PolicyService struct {
+	users  repository.Authorization
+	grants repository.EventGrants
+}
+
+func NewPolicyService(users repository.Authorization, grants repository.EventGrants) *PolicyService {
+	return &PolicyService{
+		users:  users,
+		grants: grants,
+	}
+}
+
+func (s *PolicyService) HasPermission(userId int, permission string) (bool, error) {
+	user, err := s.users.GetUserById(userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return roleHasPermission(user.Role, permission), nil
+}
+
+// Effective permission of the user on the event - organizer or the highest grant, empty if event is not shared
+func (s *PolicyService) EventPermission(userId int, event domain.Event) (string, error) {
+	if event.OrganizerId == userId {
+		return domain.EVENT_PERMISSION_ORGANIZER, nil
+	}
+
+	return s.grants.GetPermission(event.Id, userId)
+}
+
+// Event permission resolved for the user is checked first, role permission allows actions on any event.
+// Role permissions of the principal are not delegated
+func (s *PolicyService) CanOnEvent(actor domain.Actor, event domain.Event, action string) (bool, error) {
+	if eventPermissionLevels[event.Permission] >= eventActionLevels[action] {
+		return true, nil
+	}
+
+	if actor.PrincipalId != 0 {
+		return false, nil
+	}
+
+	return s.HasPermission(actor.UserId, eventActionRolePermissions[action])
+}
+
+func roleHasPermission(role, permission string) bool {
+	for _, granted := range domain.RolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isKnownRole(role string) bool {
+	_, ok := domain.RolePermissions[role]
+	return ok
+}
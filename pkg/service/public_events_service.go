@@ -0,0 +1,94 @@
+package service
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+const (
+	PUBLIC_EVENTS_LIMIT = 100
+	// Title part of slug is shortened, random suffix keeps slugs unique and not guessable,
+	// so unlisted events and booking pages are available only by link
+	SLUG_TITLE_LENGTH  = 60
+	SLUG_RANDOM_LENGTH = 16
+)
+
+var (
+	ErrUnknownVisibility = errors.New("Unknown event visibility")
+	slugSeparators       = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Discovery of public events without authentication
+type PublicEventsService struct {
+	repo repository.Events
+}
+
+func NewPublicEventsService(repo repository.Events) *PublicEventsService {
+	return &PublicEventsService{repo: repo}
+}
+
+func (s *PublicEventsService) GetAll() ([]domain.PublicEvent, error) {
+	return s.repo.GetPublic(PUBLIC_EVENTS_LIMIT)
+}
+
+func (s *PublicEventsService) GetBySlug(slug string) (domain.PublicEvent, error) {
+	result, err := s.repo.GetPublicBySlug(slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.PublicEvent{}, ErrEventNotFound
+	}
+
+	return result, err
+}
+
+// Set visibility and slug of saved event, event keeps its slug when it becomes private again
+func applyVisibility(request *domain.SaveEventRequest, current domain.Event) error {
+	if request.Visibility == "" {
+		request.Visibility = current.Visibility
+	}
+	if request.Visibility == "" {
+		request.Visibility = domain.EVENT_VISIBILITY_PRIVATE
+	}
+
+	switch request.Visibility {
+	case domain.EVENT_VISIBILITY_PRIVATE, domain.EVENT_VISIBILITY_UNLISTED, domain.EVENT_VISIBILITY_PUBLIC:
+	default:
+		return ErrUnknownVisibility
+	}
+
+	request.Slug = current.Slug
+	if request.Slug != "" || request.Visibility == domain.EVENT_VISIBILITY_PRIVATE {
+		return nil
+	}
+
+	slug, err := generateSlug(request.Title)
+	if err != nil {
+		return err
+	}
+	request.Slug = slug
+
+	return nil
+}
+
+func generateSlug(title string) (string, error) {
+	raw := make([]byte, SLUG_RANDOM_LENGTH)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	base := strings.Trim(slugSeparators.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(base) > SLUG_TITLE_LENGTH {
+		base = strings.Trim(base[:SLUG_TITLE_LENGTH], "-")
+	}
+	if base == "" {
+		base = "event"
+	}
+
+	return base + "-" + hex.EncodeToString(raw), nil
+}
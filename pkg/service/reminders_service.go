@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"time"
+
+	"github.com/salesforceanton/events-api/config"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/notifier"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+const (
+	// Jobs are created ahead of time, so reminders are sent on the first check after they are due
+	REMINDER_ENQUEUE_AHEAD = time.Hour
+	// Reminders delivered by one check of the server, the rest is left to next check or to other servers
+	REMINDER_BATCH_SIZE   = 100
+	REMINDER_MAX_ATTEMPTS = 5
+	REMINDER_RETRY_DELAY  = time.Minute
+
+	NOTIFICATION_TYPE_REMINDER = "event.reminder"
+)
+
+type RemindersService struct {
+	repo     repository.Reminders
+	notifier notifier.Notifier
+	cfg      *config.Config
+}
+
+func NewRemindersService(repo repository.Reminders, notifier notifier.Notifier, cfg *config.Config) *RemindersService {
+	return &RemindersService{repo: repo, notifier: notifier, cfg: cfg}
+}
+
+func (s *RemindersService) GetDefaults(actor domain.Actor) (domain.ReminderDefaults, error) {
+	minutes, err := s.repo.GetDefaults(actor.UserId)
+	if minutes == nil {
+		minutes = []int{}
+	}
+
+	return domain.ReminderDefaults{Minutes: minutes}, err
+}
+
+// Default reminders are replaced, they are used for events which have no own reminders
+func (s *RemindersService) SaveDefaults(actor domain.Actor, request domain.ReminderDefaults) (domain.ReminderDefaults, error) {
+	minutes := reminderMinutes(request.Minutes)
+	if minutes == nil {
+		minutes = []int{}
+	}
+
+	if err := s.repo.SaveDefaults(actor.UserId, minutes); err != nil {
+		return domain.ReminderDefaults{}, err
+	}
+
+	return domain.ReminderDefaults{Minutes: minutes}, nil
+}
+
+// Background job - deliver due reminders until context is cancelled
+func (s *RemindersService) RunScheduler(ctx context.Context) {
+	if s.cfg.ReminderCheckInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.ReminderCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.deliverDue(ctx); err != nil {
+			logger.LogServiceIssue("reminders", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *RemindersService) deliverDue(ctx context.Context) error {
+	if _, err := s.repo.Enqueue(time.Now().Add(REMINDER_ENQUEUE_AHEAD)); err != nil {
+		return err
+	}
+
+	for i := 0; i < REMINDER_BATCH_SIZE && ctx.Err() == nil; i++ {
+		found, err := s.repo.DeliverDue(s.deliver)
+		if err != nil || !found {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reminder about moved or cancelled event is skipped. Failed delivery is retried with growing delay
+// while the event has not started
+func (s *RemindersService) deliver(job domain.ReminderJob) domain.ReminderJob {
+	if !job.Valid {
+		job.Status = domain.REMINDER_STATUS_SKIPPED
+		return job
+	}
+
+	job.Attempts++
+	if err := s.notifier.Notify(reminderNotification(job)); err != nil {
+		logger.LogServiceIssue("reminders", err)
+
+		job.LastError = err.Error()
+		if job.Attempts >= REMINDER_MAX_ATTEMPTS {
+			job.Status = domain.REMINDER_STATUS_FAILED
+		} else {
+			job.RemindAt = time.Now().Add(time.Duration(job.Attempts) * REMINDER_RETRY_DELAY)
+		}
+
+		return job
+	}
+
+	job.Status = domain.REMINDER_STATUS_SENT
+	job.LastError = ""
+
+	return job
+}
+
+// Unique minutes in ascending order, nil is kept so reminders of updated event are not changed
+func reminderMinutes(minutes []int) []int {
+	result := uniqueInts(minutes)
+	sort.Ints(result)
+
+	return result
+}
+
+// Start of the event is shown in timezone of the event
+func reminderNotification(job domain.ReminderJob) notifier.Notification {
+	location, err := time.LoadLocation(job.TimezoneId)
+	if err != nil {
+		location = time.UTC
+	}
+	startsAt := job.StartsAt.In(location).Format("Mon, 02 Jan 2006 15:04 MST")
+
+	return notifier.Notification{
+		Type:     NOTIFICATION_TYPE_REMINDER,
+		UserId:   job.UserId,
+		Email:    job.Email,
+		EventId:  job.EventId,
+		Title:    job.Title,
+		StartsAt: job.StartsAt,
+		Subject:  fmt.Sprintf("Reminder: %s", job.Title),
+		Text:     fmt.Sprintf("%s starts on %s.\n", job.Title, startsAt),
+		HTML:     fmt.Sprintf("<p><b>%s</b> starts on %s.</p>", html.EscapeString(job.Title), html.EscapeString(startsAt)),
+	}
+}
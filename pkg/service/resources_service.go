@@ -0,0 +1,164 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+const RESOURCE_MAX_RANGE = 93 * 24 * time.Hour
+
+var (
+	ErrResourceNotFound    = errors.New("Resource is not found")
+	ErrResourceUnavailable = errors.New("Resource is already reserved at this time")
+	ErrInvalidTimeRange    = errors.New("Time range is invalid, to should be after from and not later than 93 days")
+)
+
+// Meeting rooms and equipment of the active organization, they are managed by organization owners and admins.
+// Events reserve resources for their time, overlapping reservations are rejected by database
+type ResourcesService struct {
+	repo repository.Resources
+	orgs *OrganizationsService
+}
+
+func NewResourcesService(repo repository.Resources, orgs *OrganizationsService) *ResourcesService {
+	return &ResourcesService{
+		repo: repo,
+		orgs: orgs,
+	}
+}
+
+func (s *ResourcesService) GetAll(actor domain.Actor) ([]domain.Resource, error) {
+	return s.repo.GetAll(actor.OrganizationId)
+}
+
+func (s *ResourcesService) Create(actor domain.Actor, request domain.SaveResourceRequest) (int, error) {
+	if _, err := s.orgs.manager(actor.UserId, actor.OrganizationId); err != nil {
+		return 0, err
+	}
+
+	return s.repo.Create(domain.Resource{
+		OrganizationId: actor.OrganizationId,
+		Name:           request.Name,
+		Capacity:       request.Capacity,
+		Location:       request.Location,
+	})
+}
+
+func (s *ResourcesService) Update(
+	actor domain.Actor, resourceId int, request domain.SaveResourceRequest,
+) (domain.Resource, error) {
+	if _, err := s.orgs.manager(actor.UserId, actor.OrganizationId); err != nil {
+		return domain.Resource{}, err
+	}
+
+	result, err := s.repo.Update(domain.Resource{
+		Id:             resourceId,
+		OrganizationId: actor.OrganizationId,
+		Name:           request.Name,
+		Capacity:       request.Capacity,
+		Location:       request.Location,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, ErrResourceNotFound
+	}
+
+	return result, err
+}
+
+func (s *ResourcesService) Delete(actor domain.Actor, resourceId int) error {
+	if _, err := s.orgs.manager(actor.UserId, actor.OrganizationId); err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.Delete(actor.OrganizationId, resourceId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func (s *ResourcesService) GetAvailability(
+	actor domain.Actor, resourceId int, filter domain.TimeRangeFilter,
+) (domain.ResourceAvailability, error) {
+	if err := checkTimeRange(filter); err != nil {
+		return domain.ResourceAvailability{}, err
+	}
+
+	if err := s.checkResources(actor.OrganizationId, []int{resourceId}); err != nil {
+		return domain.ResourceAvailability{}, err
+	}
+
+	busy, err := s.repo.GetReservations(resourceId, filter.From, filter.To)
+	if err != nil {
+		return domain.ResourceAvailability{}, err
+	}
+	if busy == nil {
+		busy = []domain.BusyInterval{}
+	}
+
+	return domain.ResourceAvailability{ResourceId: resourceId, Busy: busy}, nil
+}
+
+// Utilization is a share of the time range, rounded to hundredths
+func (s *ResourcesService) GetUtilization(
+	actor domain.Actor, filter domain.TimeRangeFilter,
+) ([]domain.ResourceUtilization, error) {
+	if err := checkTimeRange(filter); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.GetUtilization(actor.OrganizationId, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	rangeMinutes := filter.To.Sub(filter.From).Minutes()
+	for i := range result {
+		result[i].Utilization = math.Round(float64(result[i].ReservedMinutes)/rangeMinutes*100) / 100
+	}
+
+	return result, nil
+}
+
+// All resources should belong to the organization
+func (s *ResourcesService) checkResources(organizationId int, resourceIds []int) error {
+	if len(resourceIds) == 0 {
+		return nil
+	}
+
+	resources, err := s.repo.GetByIds(organizationId, resourceIds)
+	if err != nil {
+		return err
+	}
+	if len(resources) != len(resourceIds) {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func checkTimeRange(filter domain.TimeRangeFilter) error {
+	if !filter.To.After(filter.From) || filter.To.Sub(filter.From) > RESOURCE_MAX_RANGE {
+		return ErrInvalidTimeRange
+	}
+
+	return nil
+}
+
+// Overlapping reservation is reported by repository, it is a conflict of the event
+func reservationError(err error) error {
+	if errors.Is(err, repository.ErrReservationConflict) {
+		return ErrResourceUnavailable
+	}
+
+	return err
+}
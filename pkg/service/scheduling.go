@@ -0,0 +1,206 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+)
+
+const (
+	SUGGEST_SLOTS_LIMIT = 10
+	SUGGEST_SLOT_STEP   = 15 * time.Minute
+	WORKDAY_START       = "09:00"
+	WORKDAY_END         = "17:00"
+	// Working hours are given as wall time of a day
+	WORKING_HOURS_LAYOUT = "15:04"
+)
+
+var (
+	ErrInvalidWorkingHours = errors.New("Working hours are invalid, use HH:MM and end after start")
+	ErrUnknownRequiredUser = errors.New("Required users should be listed in participants")
+)
+
+// Ranked slots in working hours of the window when required participants have no events and work
+func (s *EventsService) SuggestSlots(actor domain.Actor, request domain.SuggestSlotsRequest) ([]domain.SuggestedSlot, error) {
+	result, err := s.suggestSlots(actor, request)
+	s.audit.Record(actor, AUDIT_SCHEDULING_SUGGEST, 0, err)
+
+	return result, err
+}
+
+func (s *EventsService) suggestSlots(actor domain.Actor, request domain.SuggestSlotsRequest) ([]domain.SuggestedSlot, error) {
+	if request.TimezoneId == "" {
+		request.TimezoneId = "UTC"
+	}
+	location, err := time.LoadLocation(request.TimezoneId)
+	if err != nil {
+		return nil, ErrUnknownTimezone
+	}
+
+	dayStart, dayEnd, err := parseWorkingHours(request.WorkingHours)
+	if err != nil {
+		return nil, err
+	}
+
+	requiredIds := request.RequiredIds
+	if len(requiredIds) == 0 {
+		requiredIds = request.ParticipantIds
+	}
+	required := make(map[int]bool)
+	for _, userId := range requiredIds {
+		if !containsInt(request.ParticipantIds, userId) {
+			return nil, ErrUnknownRequiredUser
+		}
+		required[userId] = true
+	}
+
+	userIds, err := s.freeBusyMembers(actor, domain.FreeBusyRequest{
+		UserIds: request.ParticipantIds,
+		TimeMin: request.TimeMin,
+		TimeMax: request.TimeMax,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Intervals are not returned to the caller, so busy time of users who do not share it is used too
+	busy, err := s.busyIntervals(actor.OrganizationId, userIds, request.TimeMin, request.TimeMax)
+	if err != nil {
+		return nil, err
+	}
+
+	freeBusy := make([]domain.UserFreeBusy, 0, len(userIds))
+	for _, userId := range userIds {
+		freeBusy = append(freeBusy, domain.UserFreeBusy{UserId: userId, Busy: busy[userId]})
+	}
+
+	schedules, err := s.availability.schedules(request.ParticipantIds)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = SUGGEST_SLOTS_LIMIT
+	}
+	duration := time.Duration(request.DurationMinutes) * time.Minute
+
+	var result []domain.SuggestedSlot
+	first := request.TimeMin.In(location)
+	day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, location)
+	for ; day.Before(request.TimeMax); day = day.AddDate(0, 0, 1) {
+		if !request.IncludeWeekends && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+			continue
+		}
+
+		// Working hours are set on each day, so they stay the same when DST changes
+		from := onDay(day, dayStart)
+		to := onDay(day, dayEnd)
+		for start := from; !start.Add(duration).After(to); start = start.Add(SUGGEST_SLOT_STEP) {
+			end := start.Add(duration)
+			if start.Before(request.TimeMin) || end.After(request.TimeMax) {
+				continue
+			}
+
+			slot, ok := freeSlot(freeBusy, schedules, required, start, end)
+			if ok {
+				result = append(result, slot)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return len(result[i].UnavailableIds) < len(result[j].UnavailableIds)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	if result == nil {
+		result = []domain.SuggestedSlot{}
+	}
+
+	return result, nil
+}
+
+// Slot is suggested when all required participants are free and work at this time,
+// unavailable optional participants are listed
+func freeSlot(
+	freeBusy []domain.UserFreeBusy, schedules map[int]workSchedule, required map[int]bool, start, end time.Time,
+) (domain.SuggestedSlot, bool) {
+	slot := domain.SuggestedSlot{Start: start, End: end, UnavailableIds: []int{}}
+
+	for _, user := range freeBusy {
+		if isFree(user.Busy, start, end) && schedules[user.UserId].covers(start, end) {
+			continue
+		}
+		if required[user.UserId] {
+			return domain.SuggestedSlot{}, false
+		}
+
+		slot.UnavailableIds = append(slot.UnavailableIds, user.UserId)
+	}
+
+	return slot, true
+}
+
+// Busy intervals are merged and ordered, so only the first interval ending after start can overlap
+func isFree(busy []domain.BusyInterval, start, end time.Time) bool {
+	i := sort.Search(len(busy), func(i int) bool {
+		return busy[i].End.After(start)
+	})
+
+	return i == len(busy) || !busy[i].Start.Before(end)
+}
+
+// Start and end clock time of working hours
+func parseWorkingHours(hours domain.WorkingHours) (time.Time, time.Time, error) {
+	if hours.Start == "" {
+		hours.Start = WORKDAY_START
+	}
+	if hours.End == "" {
+		hours.End = WORKDAY_END
+	}
+
+	start, err := time.Parse(WORKING_HOURS_LAYOUT, hours.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidWorkingHours
+	}
+	end, err := time.Parse(WORKING_HOURS_LAYOUT, hours.End)
+	if err != nil || !end.After(start) {
+		return time.Time{}, time.Time{}, ErrInvalidWorkingHours
+	}
+
+	return start, end, nil
+}
+
+func onDay(day, clock time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, day.Location())
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Values in order of first occurrence, nil stays nil
+func uniqueInts(values []int) []int {
+	if values == nil {
+		return nil
+	}
+
+	result := make([]int, 0, len(values))
+	for _, value := range values {
+		if !containsInt(result, value) {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
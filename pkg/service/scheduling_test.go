@@ -0,0 +1,333 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var testServiceActor = domain.Actor{UserId: 1, OrganizationId: 1}
+
+// Repositories with data of the test case, other methods are not used by tested functions
+type stubEventsRepo struct {
+	repository.Events
+	busy []domain.EventConflict
+}
+
+func (r stubEventsRepo) GetOverlapping(
+	organizationId int, userIds []int, startsAt, endsAt time.Time, excludeEventId int,
+) ([]domain.EventConflict, error) {
+	var result []domain.EventConflict
+	for _, event := range r.busy {
+		if containsInt(userIds, event.UserId) && event.StartsAt.Before(endsAt) && event.EndsAt.After(startsAt) {
+			result = append(result, event)
+		}
+	}
+
+	return result, nil
+}
+
+func (r stubEventsRepo) GetBusy(userId int, startsAt, endsAt time.Time) ([]domain.BusyInterval, error) {
+	var result []domain.BusyInterval
+	for _, event := range r.busy {
+		if event.UserId == userId && event.StartsAt.Before(endsAt) && event.EndsAt.After(startsAt) {
+			result = append(result, domain.BusyInterval{Start: event.StartsAt, End: event.EndsAt})
+		}
+	}
+
+	return result, nil
+}
+
+type stubOrganizationsRepo struct {
+	repository.Organizations
+}
+
+func (r stubOrganizationsRepo) GetMember(organizationId, userId int) (domain.OrganizationMember, error) {
+	return domain.OrganizationMember{UserId: userId}, nil
+}
+
+type stubAvailabilityRepo struct {
+	repository.Availability
+	users       []domain.Availability
+	outOfOffice []domain.OutOfOffice
+}
+
+func (r stubAvailabilityRepo) GetAvailability(userIds []int) ([]domain.Availability, error) {
+	result := []domain.Availability{}
+	for _, userId := range userIds {
+		user := domain.Availability{UserId: userId, TimezoneId: "UTC", FreeBusyShared: true}
+		for _, stub := range r.users {
+			if stub.UserId == userId {
+				user = stub
+			}
+		}
+		result = append(result, user)
+	}
+
+	return result, nil
+}
+
+func (r stubAvailabilityRepo) GetOutOfOffice(userIds []int, startsAt, endsAt time.Time) ([]domain.OutOfOffice, error) {
+	var result []domain.OutOfOffice
+	for _, absence := range r.outOfOffice {
+		if containsInt(userIds, absence.UserId) && absence.StartsAt.Before(endsAt) && absence.EndsAt.After(startsAt) {
+			result = append(result, absence)
+		}
+	}
+
+	return result, nil
+}
+
+func testTime(value string) time.Time {
+	result, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+func TestEventsService_suggestSlots(t *testing.T) {
+	// Init Test Table
+	tests := []struct {
+		name          string
+		request       domain.SuggestSlotsRequest
+		busy          []domain.EventConflict
+		availability  []domain.Availability
+		expectedSlots []domain.SuggestedSlot
+		expectedError error
+	}{
+		{
+			name: "All Free",
+			request: domain.SuggestSlotsRequest{
+				ParticipantIds:  []int{2},
+				DurationMinutes: 60,
+				TimeMin:         testTime("2024-05-06T09:00:00Z"),
+				TimeMax:         testTime("2024-05-06T10:30:00Z"),
+			},
+			expectedSlots: []domain.SuggestedSlot{
+				{Start: testTime("2024-05-06T09:00:00Z"), End: testTime("2024-05-06T10:00:00Z"), UnavailableIds: []int{}},
+				{Start: testTime("2024-05-06T09:15:00Z"), End: testTime("2024-05-06T10:15:00Z"), UnavailableIds: []int{}},
+				{Start: testTime("2024-05-06T09:30:00Z"), End: testTime("2024-05-06T10:30:00Z"), UnavailableIds: []int{}},
+			},
+		},
+		{
+			name: "Busy Required Participant",
+			request: domain.SuggestSlotsRequest{
+				ParticipantIds:  []int{2},
+				DurationMinutes: 60,
+				TimeMin:         testTime("2024-05-06T09:00:00Z"),
+				TimeMax:         testTime("2024-05-06T12:00:00Z"),
+			},
+			busy: []domain.EventConflict{
+				{UserId: 2, StartsAt: testTime("2024-05-06T09:30:00Z"), EndsAt: testTime("2024-05-06T10:30:00Z")},
+			},
+			expectedSlots: []domain.SuggestedSlot{
+				{Start: testTime("2024-05-06T10:30:00Z"), End: testTime("2024-05-06T11:30:00Z"), UnavailableIds: []int{}},
+				{Start: testTime("2024-05-06T10:45:00Z"), End: testTime("2024-05-06T11:45:00Z"), UnavailableIds: []int{}},
+				{Start: testTime("2024-05-06T11:00:00Z"), End: testTime("2024-05-06T12:00:00Z"), UnavailableIds: []int{}},
+			},
+		},
+		{
+			name: "Busy Optional Participant",
+			request: domain.SuggestSlotsRequest{
+				ParticipantIds:  []int{2, 3},
+				RequiredIds:     []int{2},
+				DurationMinutes: 60,
+				TimeMin:         testTime("2024-05-06T09:00:00Z"),
+				TimeMax:         testTime("2024-05-06T11:00:00Z"),
+				Limit:           3,
+			},
+			busy: []domain.EventConflict{
+				{UserId: 3, StartsAt: testTime("2024-05-06T09:00:00Z"), EndsAt: testTime("2024-05-06T10:00:00Z")},
+			},
+			expectedSlots: []domain.SuggestedSlot{
+				{Start: testTime("2024-05-06T10:00:00Z"), End: testTime("2024-05-06T11:00:00Z"), UnavailableIds: []int{}},
+				{Start: testTime("2024-05-06T09:00:00Z"), End: testTime("2024-05-06T10:00:00Z"), UnavailableIds: []int{3}},
+				{Start: testTime("2024-05-06T09:15:00Z"), End: testTime("2024-05-06T10:15:00Z"), UnavailableIds: []int{3}},
+			},
+		},
+		{
+			name: "Working Hours Of Participant",
+			request: domain.SuggestSlotsRequest{
+				ParticipantIds:  []int{2},
+				DurationMinutes: 60,
+				TimeMin:         testTime("2024-05-06T06:00:00Z"),
+				TimeMax:         testTime("2024-05-06T12:00:00Z"),
+				TimezoneId:      "Europe/Berlin",
+			},
+			availability: []domain.Availability{
+				{
+					UserId:         2,
+					TimezoneId:     "Europe/Berlin",
+					FreeBusyShared: true,
+					WorkingHours:   []domain.WeekdayHours{{Weekday: 1, Start: "10:00", End: "11:00"}},
+				},
+			},
+			expectedSlots: []domain.SuggestedSlot{
+				{Start: testTime("2024-05-06T08:00:00Z"), End: testTime("2024-05-06T09:00:00Z"), UnavailableIds: []int{}},
+			},
+		},
+		{
+			name: "Required Participant Not Sharing Free Busy",
+			request: domain.SuggestSlotsRequest{
+				ParticipantIds:  []int{2},
+				DurationMinutes: 60,
+				TimeMin:         testTime("2024-05-06T09:00:00Z"),
+				TimeMax:         testTime("2024-05-06T11:00:00Z"),
+			},
+			busy: []domain.EventConflict{
+				{UserId: 2, StartsAt: testTime("2024-05-06T09:00:00Z"), EndsAt: testTime("2024-05-06T10:00:00Z")},
+			},
+			availability: []domain.Availability{
+				{UserId: 2, TimezoneId: "UTC", FreeBusyShared: false},
+			},
+			expectedSlots: []domain.SuggestedSlot{
+				{Start: testTime("2024-05-06T10:00:00Z"), End: testTime("2024-05-06T11:00:00Z"), UnavailableIds: []int{}},
+			},
+		},
+		{
+			name: "Weekend Is Skipped",
+			request: domain.SuggestSlotsRequest{
+				ParticipantIds:  []int{2},
+				DurationMinutes: 60,
+				TimeMin:         testTime("2024-05-04T09:00:00Z"),
+				TimeMax:         testTime("2024-05-06T10:00:00Z"),
+			},
+			expectedSlots: []domain.SuggestedSlot{
+				{Start: testTime("2024-05-06T09:00:00Z"), End: testTime("2024-05-06T10:00:00Z"), UnavailableIds: []int{}},
+			},
+		},
+		{
+			name: "No Free Slots",
+			request: domain.SuggestSlotsRequest{
+				ParticipantIds:  []int{2},
+				DurationMinutes: 120,
+				TimeMin:         testTime("2024-05-06T09:00:00Z"),
+				TimeMax:         testTime("2024-05-06T10:00:00Z"),
+			},
+			expectedSlots: []domain.SuggestedSlot{},
+		},
+		{
+			name: "Unknown Required User",
+			request: domain.SuggestSlotsRequest{
+				ParticipantIds:  []int{2},
+				RequiredIds:     []int{3},
+				DurationMinutes: 60,
+				TimeMin:         testTime("2024-05-06T09:00:00Z"),
+				TimeMax:         testTime("2024-05-06T17:00:00Z"),
+			},
+			expectedError: ErrUnknownRequiredUser,
+		},
+		{
+			name: "Invalid Working Hours",
+			request: domain.SuggestSlotsRequest{
+				ParticipantIds:  []int{2},
+				DurationMinutes: 60,
+				TimeMin:         testTime("2024-05-06T09:00:00Z"),
+				TimeMax:         testTime("2024-05-06T17:00:00Z"),
+				WorkingHours:    domain.WorkingHours{Start: "17:00", End: "09:00"},
+			},
+			expectedError: ErrInvalidWorkingHours,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			events := &EventsService{
+				repo:         stubEventsRepo{busy: test.busy},
+				orgs:         stubOrganizationsRepo{},
+				availability: NewAvailabilityService(stubAvailabilityRepo{users: test.availability}),
+			}
+
+			// Suggest Slots
+			result, err := events.suggestSlots(testServiceActor, test.request)
+			for i := range result {
+				result[i].Start, result[i].End = result[i].Start.UTC(), result[i].End.UTC()
+			}
+
+			// Assert
+			assert.ErrorIs(t, err, test.expectedError)
+			assert.Equal(t, test.expectedSlots, result)
+		})
+	}
+}
+
+func TestMergeIntervals(t *testing.T) {
+	// Init Test Table
+	timeMin, timeMax := testTime("2024-05-01T08:00:00Z"), testTime("2024-05-01T18:00:00Z")
+
+	tests := []struct {
+		name      string
+		intervals []domain.BusyInterval
+		expected  []domain.BusyInterval
+	}{
+		{
+			name:      "Empty",
+			intervals: []domain.BusyInterval{},
+			expected:  nil,
+		},
+		{
+			name: "Overlapping Intervals",
+			intervals: []domain.BusyInterval{
+				{Start: testTime("2024-05-01T09:00:00Z"), End: testTime("2024-05-01T10:30:00Z")},
+				{Start: testTime("2024-05-01T10:00:00Z"), End: testTime("2024-05-01T11:00:00Z")},
+			},
+			expected: []domain.BusyInterval{
+				{Start: testTime("2024-05-01T09:00:00Z"), End: testTime("2024-05-01T11:00:00Z")},
+			},
+		},
+		{
+			name: "Touching Intervals",
+			intervals: []domain.BusyInterval{
+				{Start: testTime("2024-05-01T09:00:00Z"), End: testTime("2024-05-01T10:00:00Z")},
+				{Start: testTime("2024-05-01T10:00:00Z"), End: testTime("2024-05-01T11:00:00Z")},
+			},
+			expected: []domain.BusyInterval{
+				{Start: testTime("2024-05-01T09:00:00Z"), End: testTime("2024-05-01T11:00:00Z")},
+			},
+		},
+		{
+			name: "Unordered Separate Intervals",
+			intervals: []domain.BusyInterval{
+				{Start: testTime("2024-05-01T14:00:00Z"), End: testTime("2024-05-01T15:00:00Z")},
+				{Start: testTime("2024-05-01T09:00:00Z"), End: testTime("2024-05-01T10:00:00Z")},
+			},
+			expected: []domain.BusyInterval{
+				{Start: testTime("2024-05-01T09:00:00Z"), End: testTime("2024-05-01T10:00:00Z")},
+				{Start: testTime("2024-05-01T14:00:00Z"), End: testTime("2024-05-01T15:00:00Z")},
+			},
+		},
+		{
+			name: "Nested Interval",
+			intervals: []domain.BusyInterval{
+				{Start: testTime("2024-05-01T09:00:00Z"), End: testTime("2024-05-01T12:00:00Z")},
+				{Start: testTime("2024-05-01T10:00:00Z"), End: testTime("2024-05-01T11:00:00Z")},
+			},
+			expected: []domain.BusyInterval{
+				{Start: testTime("2024-05-01T09:00:00Z"), End: testTime("2024-05-01T12:00:00Z")},
+			},
+		},
+		{
+			name: "Clipped To Window",
+			intervals: []domain.BusyInterval{
+				{Start: testTime("2024-04-30T22:00:00Z"), End: testTime("2024-05-01T09:00:00Z")},
+				{Start: testTime("2024-05-01T17:00:00Z"), End: testTime("2024-05-02T01:00:00Z")},
+			},
+			expected: []domain.BusyInterval{
+				{Start: timeMin, End: testTime("2024-05-01T09:00:00Z")},
+				{Start: testTime("2024-05-01T17:00:00Z"), End: timeMax},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, mergeIntervals(test.intervals, timeMin, timeMax))
+		})
+	}
+}
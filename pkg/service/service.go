@@ -1,9 +1,16 @@
 package service
 
 import (
+	"context"
+	"time"
+
 	"github.com/salesforceanton/events-api/config"
 	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/mailer"
+	"github.com/salesforceanton/events-api/pkg/notifier"
+	"github.com/salesforceanton/events-api/pkg/ratelimit"
 	"github.com/salesforceanton/events-api/pkg/repository"
+	"github.com/salesforceanton/events-api/pkg/signing"
 )
 
 //go:generate mockgen -source=service.go -destination=mocks/mock.go
@@ -11,25 +18,263 @@ import (
 type Service struct {
 	Authorization
 	Events
+	LoginGuard
+	AccessTokens
+	SigningKeys
+	Oidc
+	Policy
+	Admin
+	Organizations
+	EventGrants
+	FreeBusy
+	Scheduling
+	Groups
+	Delegations
+	Audit
+	Calendars
+	PublicEvents
+	RateLimit
+	Availability
+	BookingPages
+	Bookings
+	Resources
+	Tags
+	CustomFields
+	Reminders
+	Digests
+	Notifications
 }
 
 type Authorization interface {
 	CreateUser(user domain.User) (int, error)
-	GenerateToken(username, password string) (string, error)
-	ParseToken(accessToken string) (int, error)
+	GenerateToken(username, password string) (domain.SignInResult, error)
+	ParseToken(accessToken string) (domain.Actor, error)
+	SwitchOrganization(userId, organizationId int) (string, error)
+	VerifyEmail(token string) error
+	ResendVerification(email string) error
+	ForgotPassword(email string) error
+	ResetPassword(token, password string) error
+	VerifyMfaChallenge(challengeToken, code string) (string, error)
+	GetMfaChallengeUsername(challengeToken string) (string, error)
+	EnrollMfa(userId int) (domain.MfaEnrollment, error)
+	EnrollMfaWithChallenge(challengeToken string) (domain.MfaEnrollment, error)
+	ConfirmMfa(userId int, code string) error
+	DisableMfa(userId int, code string) error
 }
 
 type Events interface {
-	GetAll(userId int) ([]domain.Event, error)
-	GetById(userId, eventId int) (domain.Event, error)
-	Create(userId int, event domain.SaveEventRequest) (int, error)
-	Update(userId, eventId int, event domain.SaveEventRequest) (domain.Event, error)
-	Delete(userId, eventId int) error
+	GetAll(actor domain.Actor, filter domain.EventsFilter) ([]domain.Event, error)
+	GetById(actor domain.Actor, eventId int) (domain.Event, error)
+	Create(actor domain.Actor, event domain.SaveEventRequest, check domain.ConflictCheck) (int, []domain.AvailabilityWarning, error)
+	Update(actor domain.Actor, eventId int, event domain.SaveEventRequest, check domain.ConflictCheck) (domain.Event, error)
+	Delete(actor domain.Actor, eventId int) error
+	Publish(actor domain.Actor, eventId int) (domain.Event, error)
+	Cancel(actor domain.Actor, eventId int, reason string) (domain.Event, error)
+	Reopen(actor domain.Actor, eventId int) (domain.Event, error)
+	GetConflicts(actor domain.Actor) ([]domain.EventOverlap, error)
+	GetNearby(actor domain.Actor, filter domain.NearbyFilter) ([]domain.Event, error)
+}
+
+type LoginGuard interface {
+	Check(username, ip string) (time.Duration, error)
+	RegisterFailure(username, ip string) error
+	RegisterSuccess(username, ip string) error
+}
+
+type AccessTokens interface {
+	Create(actor domain.Actor, request domain.CreateAccessTokenRequest) (domain.CreatedAccessToken, error)
+	GetAll(userId int) ([]domain.AccessToken, error)
+	Revoke(userId, tokenId int) error
+	Authenticate(token string) (domain.AccessToken, error)
+}
+
+type SigningKeys interface {
+	JWKS() (signing.JWKSet, error)
+	RunRotation(ctx context.Context)
+}
+
+type Oidc interface {
+	LoginUrl() (string, string, error)
+	Callback(code, state string) (domain.SignInResult, error)
+}
+
+type Policy interface {
+	HasPermission(userId int, permission string) (bool, error)
+}
+
+type Admin interface {
+	GetUsers() ([]domain.UserInfo, error)
+	GetEvents() ([]domain.Event, error)
+	SetRole(actorId, userId int, role string) error
+	SetMfaRequired(userId int, required bool) error
+}
+
+type Organizations interface {
+	GetAll(userId int) ([]domain.Organization, error)
+	Create(userId int, request domain.CreateOrganizationRequest) (int, error)
+	GetMembers(userId, organizationId int) ([]domain.OrganizationMember, error)
+	AddMember(userId, organizationId int, request domain.AddMemberRequest) error
+	RemoveMember(userId, organizationId, memberId int) error
+}
+
+type EventGrants interface {
+	GetGrants(actor domain.Actor, eventId int) ([]domain.EventGrant, error)
+	SaveGrant(actor domain.Actor, eventId int, request domain.SaveGrantRequest) (domain.EventGrant, error)
+	DeleteGrant(actor domain.Actor, eventId, grantId int) error
+}
+
+type FreeBusy interface {
+	GetFreeBusy(actor domain.Actor, request domain.FreeBusyRequest) ([]domain.UserFreeBusy, error)
+}
+
+type Scheduling interface {
+	SuggestSlots(actor domain.Actor, request domain.SuggestSlotsRequest) ([]domain.SuggestedSlot, error)
+}
+
+type Groups interface {
+	GetAll(actor domain.Actor) ([]domain.Group, error)
+	Create(actor domain.Actor, request domain.CreateGroupRequest) (int, error)
+	GetMembers(actor domain.Actor, groupId int) ([]domain.GroupMember, error)
+	AddMember(actor domain.Actor, groupId, userId int) error
+	RemoveMember(actor domain.Actor, groupId, userId int) error
+}
+
+type Delegations interface {
+	Access(actor domain.Actor, principalId int) (string, error)
+	GetAll(actor domain.Actor) ([]domain.Delegation, error)
+	Save(actor domain.Actor, request domain.SaveDelegationRequest) (domain.Delegation, error)
+	Delete(actor domain.Actor, delegationId int) error
+}
+
+type Audit interface {
+	GetAll(actor domain.Actor) ([]domain.AuditEntry, error)
+}
+
+type Calendars interface {
+	GetAll(actor domain.Actor) ([]domain.Calendar, error)
+	GetById(actor domain.Actor, calendarId int) (domain.Calendar, error)
+	Create(actor domain.Actor, request domain.SaveCalendarRequest) (int, error)
+	Update(actor domain.Actor, calendarId int, request domain.SaveCalendarRequest) (domain.Calendar, error)
+	Delete(actor domain.Actor, calendarId int) error
+}
+
+type PublicEvents interface {
+	GetAll() ([]domain.PublicEvent, error)
+	GetBySlug(slug string) (domain.PublicEvent, error)
+}
+
+type RateLimit interface {
+	Allow(key string) time.Duration
+}
+
+type Availability interface {
+	Get(actor domain.Actor) (domain.Availability, error)
+	Save(actor domain.Actor, request domain.SaveAvailabilityRequest) (domain.Availability, error)
+	AddOutOfOffice(actor domain.Actor, request domain.SaveOutOfOfficeRequest) (int, error)
+	DeleteOutOfOffice(actor domain.Actor, outOfOfficeId int) error
+}
+
+type BookingPages interface {
+	GetAll(actor domain.Actor) ([]domain.BookingPage, error)
+	GetById(actor domain.Actor, pageId int) (domain.BookingPage, error)
+	Create(actor domain.Actor, request domain.SaveBookingPageRequest) (int, error)
+	Update(actor domain.Actor, pageId int, request domain.SaveBookingPageRequest) (domain.BookingPage, error)
+	Delete(actor domain.Actor, pageId int) error
+}
+
+type Bookings interface {
+	GetPage(slug string) (domain.PublicBookingPage, error)
+	GetSlots(slug string, from, to time.Time) ([]domain.BookingSlot, error)
+	Book(slug string, request domain.BookingRequest) (domain.Booking, error)
 }
 
-func NewService(repos *repository.Repository, cfg *config.Config) *Service {
+type Resources interface {
+	GetAll(actor domain.Actor) ([]domain.Resource, error)
+	Create(actor domain.Actor, request domain.SaveResourceRequest) (int, error)
+	Update(actor domain.Actor, resourceId int, request domain.SaveResourceRequest) (domain.Resource, error)
+	Delete(actor domain.Actor, resourceId int) error
+	GetAvailability(actor domain.Actor, resourceId int, filter domain.TimeRangeFilter) (domain.ResourceAvailability, error)
+	GetUtilization(actor domain.Actor, filter domain.TimeRangeFilter) ([]domain.ResourceUtilization, error)
+}
+
+type Tags interface {
+	GetAll(actor domain.Actor) ([]domain.Tag, error)
+	Create(actor domain.Actor, request domain.SaveTagRequest) (int, error)
+	Update(actor domain.Actor, tagId int, request domain.SaveTagRequest) (domain.Tag, error)
+	Delete(actor domain.Actor, tagId int) error
+}
+
+type CustomFields interface {
+	GetAll(actor domain.Actor) ([]domain.CustomField, error)
+	Create(actor domain.Actor, request domain.SaveCustomFieldRequest) (int, error)
+	Update(actor domain.Actor, fieldId int, request domain.SaveCustomFieldRequest) (domain.CustomField, error)
+	Delete(actor domain.Actor, fieldId int) error
+}
+
+type Reminders interface {
+	GetDefaults(actor domain.Actor) (domain.ReminderDefaults, error)
+	SaveDefaults(actor domain.Actor, request domain.ReminderDefaults) (domain.ReminderDefaults, error)
+	RunScheduler(ctx context.Context)
+}
+
+type Digests interface {
+	Get(actor domain.Actor) (domain.DigestPreferences, error)
+	Save(actor domain.Actor, request domain.SaveDigestRequest) (domain.DigestPreferences, error)
+	RunScheduler(ctx context.Context)
+}
+
+type Notifications interface {
+	GetPreferences(actor domain.Actor) (domain.NotificationPreferences, error)
+	SavePreferences(actor domain.Actor, request domain.NotificationPreferences) (domain.NotificationPreferences, error)
+}
+
+func NewService(
+	repos *repository.Repository, mailer mailer.Mailer, notifier notifier.Notifier, cfg *config.Config,
+) *Service {
+	signingKeys := NewSigningKeysService(repos.SigningKeys, cfg)
+	auth := NewAuthService(repos.Authorization, repos.UserTokens, repos.Mfa, repos.Organizations, signingKeys, mailer, cfg)
+	policy := NewPolicyService(repos.Authorization, repos.EventGrants)
+	audit := NewAuditService(repos.AuditLog)
+	calendars := NewCalendarsService(repos.Calendars)
+	availability := NewAvailabilityService(repos.Availability)
+	organizations := NewOrganizationsService(repos.Organizations, repos.Authorization)
+	resources := NewResourcesService(repos.Resources, organizations)
+	tags := NewTagsService(repos.Tags, organizations)
+	customFields := NewCustomFieldsService(repos.CustomFields, organizations)
+	notifications := NewEventNotificationsService(repos.Notifications, mailer, cfg)
+	events := NewEventsService(
+		repos.Events, repos.EventGrants, repos.Organizations, repos.Groups, policy, audit, calendars, availability,
+		resources, tags, customFields, notifications, cfg,
+	)
+	bookingPages := NewBookingPagesService(repos.BookingPages, repos.Events, availability, calendars)
+
 	return &Service{
-		Authorization: NewAuthService(repos.Authorization, cfg),
-		Events:        NewEventsService(repos.Events, cfg),
+		Authorization: auth,
+		Events:        events,
+		LoginGuard:    NewLoginGuardService(repos.LoginThrottles, cfg),
+		AccessTokens:  NewAccessTokensService(repos.AccessTokens, cfg),
+		SigningKeys:   signingKeys,
+		Oidc:          NewOidcService(repos.Oidc, repos.Authorization, auth, cfg),
+		Policy:        policy,
+		Admin:         NewAdminService(repos.Authorization, repos.Events),
+		Organizations: organizations,
+		EventGrants:   events,
+		FreeBusy:      events,
+		Scheduling:    events,
+		Groups:        NewGroupsService(repos.Groups, organizations),
+		Delegations:   NewDelegationsService(repos.Delegations, repos.Organizations),
+		Audit:         audit,
+		Calendars:     calendars,
+		PublicEvents:  NewPublicEventsService(repos.Events),
+		RateLimit:     ratelimit.New(cfg.PublicRateLimit, cfg.PublicRateWindow),
+		Availability:  availability,
+		BookingPages:  bookingPages,
+		Bookings:      bookingPages,
+		Resources:     resources,
+		Tags:          tags,
+		CustomFields:  customFields,
+		Reminders:     NewRemindersService(repos.Reminders, notifier, cfg),
+		Digests:       NewDigestsService(repos.Digests, repos.Events, mailer, cfg),
+		Notifications: notifications,
 	}
 }
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/salesforceanton/events-api/config"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/repository"
+	"github.com/salesforceanton/events-api/pkg/signing"
+)
+
+const (
+	// Keys are reloaded periodically so keys rotated by other instances are picked up,
+	// unknown key id forces reload but not more often than min reload interval
+	SIGNING_KEYS_CACHE_TTL  = time.Minute
+	SIGNING_KEYS_MIN_RELOAD = 5 * time.Second
+	KEY_ROTATION_CHECK      = time.Hour
+)
+
+var ErrUnknownSigningKey = errors.New("Token is signed with unknown key")
+
+type loadedKey struct {
+	alg         string
+	private     crypto.Signer
+	public      crypto.PublicKey
+	activatesAt time.Time
+	retired     bool
+}
+
+// Asymmetric signing keys of access tokens with scheduled rotation
+type SigningKeysService struct {
+	repo repository.SigningKeys
+	cfg  *config.Config
+
+	mu       sync.RWMutex
+	keys     map[string]loadedKey
+	order    []string
+	loadedAt time.Time
+}
+
+func NewSigningKeysService(repo repository.SigningKeys, cfg *config.Config) *SigningKeysService {
+	return &SigningKeysService{
+		repo: repo,
+		cfg:  cfg,
+		keys: map[string]loadedKey{},
+	}
+}
+
+// Current key to sign new tokens with, the first key is generated on demand
+func (s *SigningKeysService) SigningKey() (string, jwt.SigningMethod, crypto.Signer, error) {
+	method, err := signing.Method(s.cfg.TokenSigningAlg)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if err := s.ensureLoaded(SIGNING_KEYS_CACHE_TTL); err != nil {
+		return "", nil, nil, err
+	}
+
+	kid, key, ok := s.currentKey()
+	if !ok {
+		if err := s.Rotate(); err != nil {
+			return "", nil, nil, err
+		}
+		if kid, key, ok = s.currentKey(); !ok {
+			return "", nil, nil, errors.New("There is no active signing key")
+		}
+	}
+
+	return kid, method, key.private, nil
+}
+
+// Public key for token verification, algorithm should match the key to prevent algorithm confusion
+func (s *SigningKeysService) VerificationKey(kid, alg string) (crypto.PublicKey, error) {
+	if err := s.ensureLoaded(SIGNING_KEYS_CACHE_TTL); err != nil {
+		return nil, err
+	}
+
+	key, ok := s.lookup(kid)
+	if !ok {
+		// Key could be created by another instance after the last reload
+		if err := s.ensureLoaded(SIGNING_KEYS_MIN_RELOAD); err != nil {
+			return nil, err
+		}
+		if key, ok = s.lookup(kid); !ok {
+			return nil, ErrUnknownSigningKey
+		}
+	}
+
+	if key.alg != alg {
+		return nil, errors.New("Token algorithm does not match signing key")
+	}
+
+	return key.public, nil
+}
+
+func (s *SigningKeysService) JWKS() (signing.JWKSet, error) {
+	result := signing.JWKSet{Keys: []signing.JWK{}}
+
+	if !signing.IsAsymmetric(s.cfg.TokenSigningAlg) {
+		return result, nil
+	}
+
+	if err := s.ensureLoaded(SIGNING_KEYS_CACHE_TTL); err != nil {
+		return result, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, kid := range s.order {
+		key := s.keys[kid]
+		jwk, err := signing.ToJWK(kid, key.alg, key.public)
+		if err != nil {
+			return result, err
+		}
+		result.Keys = append(result.Keys, jwk)
+	}
+
+	return result, nil
+}
+
+// Retire keys replaced by the active one and publish the next key when rotation is due.
+// The next key is published ahead of activation so verifiers caching JWKS know it in advance,
+// the first key is activated right away
+func (s *SigningKeysService) Rotate() error {
+	if err := s.reload(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if _, current, ok := s.currentKey(); ok && s.hasReplacedKeys(current.activatesAt) {
+		if err := s.repo.RetireKeys(current.activatesAt, now.Add(s.cfg.RetiredKeyTTL)); err != nil {
+			return err
+		}
+	}
+
+	activatesAt, latestActivation, due := s.nextActivation(now)
+	if !due {
+		return nil
+	}
+
+	kid, private, public, err := signing.GenerateKey(s.cfg.TokenSigningAlg)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := signing.Encrypt(s.cfg.TokenSecret, private)
+	if err != nil {
+		return err
+	}
+
+	key := domain.SigningKey{
+		Kid:         kid,
+		Algorithm:   s.cfg.TokenSigningAlg,
+		PrivateKey:  sealed,
+		PublicKey:   public,
+		ActivatesAt: activatesAt,
+	}
+	if _, err := s.repo.PublishKey(key, latestActivation); err != nil {
+		return err
+	}
+
+	return s.reload()
+}
+
+// Activation time of the next key and activation of the current key it replaces,
+// nothing is due while the next key is already published or it is too early to publish it
+func (s *SigningKeysService) nextActivation(now time.Time) (time.Time, time.Time, bool) {
+	_, current, ok := s.currentKey()
+	if !ok {
+		return now, time.Time{}, true
+	}
+
+	if s.hasPendingKey(now) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	activatesAt := current.activatesAt.Add(s.cfg.KeyRotationInterval)
+	if now.Before(activatesAt.Add(-s.cfg.KeyPublishAhead)) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	// Rotation was missed, e.g. all instances were stopped - the current key is kept until the next one is known
+	if activatesAt.Before(now) {
+		activatesAt = now.Add(s.cfg.KeyPublishAhead)
+	}
+
+	return activatesAt, current.activatesAt, true
+}
+
+// Retired key is published until the last token signed with it expires,
+// the next key has to be published before the current one is due
+func ValidateSigningKeysConfig(cfg *config.Config) error {
+	if !signing.IsAsymmetric(cfg.TokenSigningAlg) {
+		return nil
+	}
+
+	if cfg.RetiredKeyTTL < ACCESS_TOKEN_TTL {
+		return fmt.Errorf("Retired key TTL must not be less than access token TTL (%s)", ACCESS_TOKEN_TTL)
+	}
+
+	if cfg.KeyPublishAhead <= 0 || cfg.KeyPublishAhead >= cfg.KeyRotationInterval {
+		return errors.New("Key publish ahead interval must be positive and less than rotation interval")
+	}
+
+	return nil
+}
+
+// Background job - rotate keys by schedule until context is cancelled
+func (s *SigningKeysService) RunRotation(ctx context.Context) {
+	if !signing.IsAsymmetric(s.cfg.TokenSigningAlg) {
+		return
+	}
+
+	ticker := time.NewTicker(KEY_ROTATION_CHECK)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Rotate(); err != nil {
+			logger.LogServiceIssue("signing-keys", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *SigningKeysService) ensureLoaded(maxAge time.Duration) error {
+	s.mu.RLock()
+	fresh := time.Since(s.loadedAt) < maxAge
+	s.mu.RUnlock()
+
+	if fresh {
+		return nil
+	}
+
+	return s.reload()
+}
+
+func (s *SigningKeysService) reload() error {
+	records, err := s.repo.GetKeys()
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]loadedKey, len(records))
+	order := make([]string, 0, len(records))
+
+	for _, record := range records {
+		public, err := signing.ParsePublicKey(record.PublicKey)
+		if err != nil {
+			return err
+		}
+
+		key := loadedKey{
+			alg:         record.Algorithm,
+			public:      public,
+			activatesAt: record.ActivatesAt,
+			retired:     record.RetiredAt != nil,
+		}
+
+		if !key.retired {
+			plain, err := signing.Decrypt(s.cfg.TokenSecret, record.PrivateKey)
+			if err != nil {
+				return err
+			}
+			if key.private, err = signing.ParsePrivateKey(plain); err != nil {
+				return err
+			}
+		}
+
+		keys[record.Kid] = key
+		order = append(order, record.Kid)
+	}
+
+	s.mu.Lock()
+	s.keys, s.order, s.loadedAt = keys, order, time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+// The newest activated key of configured algorithm, keys are ordered from the latest activation
+func (s *SigningKeysService) currentKey() (string, loadedKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	for _, kid := range s.order {
+		key := s.keys[kid]
+		if !key.retired && key.alg == s.cfg.TokenSigningAlg && !key.activatesAt.After(now) {
+			return kid, key, true
+		}
+	}
+
+	return "", loadedKey{}, false
+}
+
+func (s *SigningKeysService) hasPendingKey(now time.Time) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.keys {
+		if !key.retired && key.alg == s.cfg.TokenSigningAlg && key.activatesAt.After(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *SigningKeysService) hasReplacedKeys(activatedBefore time.Time) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.keys {
+		if !key.retired && key.activatesAt.Before(activatedBefore) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *SigningKeysService) lookup(kid string) (loadedKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[kid]
+
+	return key, ok
+}
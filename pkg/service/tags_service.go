@@ -0,0 +1,154 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+var (
+	ErrTagNotFound = errors.New("Tag is not found")
+	ErrTagExists   = errors.New("Tag with this name already exists")
+)
+
+// Vocabulary of tags available to a user is tags of the active organization and personal tags of the user.
+// Organization tags are managed by organization owners and admins
+type TagsService struct {
+	repo repository.Tags
+	orgs *OrganizationsService
+}
+
+func NewTagsService(repo repository.Tags, orgs *OrganizationsService) *TagsService {
+	return &TagsService{
+		repo: repo,
+		orgs: orgs,
+	}
+}
+
+func (s *TagsService) GetAll(actor domain.Actor) ([]domain.Tag, error) {
+	return s.repo.GetAll(actor.OrganizationId, actor.UserId)
+}
+
+func (s *TagsService) Create(actor domain.Actor, request domain.SaveTagRequest) (int, error) {
+	tag := domain.Tag{
+		OrganizationId: actor.OrganizationId,
+		OwnerId:        actor.UserId,
+		Name:           strings.TrimSpace(request.Name),
+	}
+
+	if request.Scope == domain.TAG_SCOPE_ORGANIZATION {
+		if _, err := s.orgs.manager(actor.UserId, actor.OrganizationId); err != nil {
+			return 0, err
+		}
+
+		tag.OwnerId = 0
+	}
+
+	result, err := s.repo.Create(tag)
+	if errors.Is(err, repository.ErrDuplicateTag) {
+		return 0, ErrTagExists
+	}
+
+	return result, err
+}
+
+func (s *TagsService) Update(actor domain.Actor, tagId int, request domain.SaveTagRequest) (domain.Tag, error) {
+	tag, err := s.managedTag(actor, tagId)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+
+	tag.Name = strings.TrimSpace(request.Name)
+
+	result, err := s.repo.Update(tag)
+	if errors.Is(err, repository.ErrDuplicateTag) {
+		return domain.Tag{}, ErrTagExists
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Tag{}, ErrTagNotFound
+	}
+
+	return result, err
+}
+
+func (s *TagsService) Delete(actor domain.Actor, tagId int) error {
+	if _, err := s.managedTag(actor, tagId); err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.Delete(actor.OrganizationId, tagId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrTagNotFound
+	}
+
+	return nil
+}
+
+// Personal tag is managed by its owner, tags of other users are not found
+func (s *TagsService) managedTag(actor domain.Actor, tagId int) (domain.Tag, error) {
+	tags, err := s.repo.GetByIds(actor.OrganizationId, actor.UserId, []int{tagId})
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	if len(tags) == 0 {
+		return domain.Tag{}, ErrTagNotFound
+	}
+
+	if tags[0].Scope == domain.TAG_SCOPE_ORGANIZATION {
+		if _, err := s.orgs.manager(actor.UserId, actor.OrganizationId); err != nil {
+			return domain.Tag{}, err
+		}
+	}
+
+	return tags[0], nil
+}
+
+// Tags of the event should be in vocabulary of the organizer
+func (s *TagsService) checkTags(organizationId, organizerId int, tagIds []int) error {
+	if len(tagIds) == 0 {
+		return nil
+	}
+
+	tags, err := s.repo.GetByIds(organizationId, organizerId, tagIds)
+	if err != nil {
+		return err
+	}
+	if len(tags) != len(tagIds) {
+		return ErrTagNotFound
+	}
+
+	return nil
+}
+
+// Tags available to the user are set to each of the events
+func (s *TagsService) loadTags(userId int, events []domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	eventIds := make([]int, 0, len(events))
+	for _, event := range events {
+		eventIds = append(eventIds, event.Id)
+	}
+
+	tags, err := s.repo.GetByEvents(userId, eventIds)
+	if err != nil {
+		return err
+	}
+
+	for i := range events {
+		for _, tag := range tags {
+			if tag.EventId == events[i].Id {
+				events[i].Tags = append(events[i].Tags, tag)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/repository"
+)
+
+const (
+	VERIFY_EMAIL_PURPOSE   = "verify-email"
+	RESET_PASSWORD_PURPOSE = "reset-password"
+)
+
+var ErrInvalidToken = errors.New("Token is invalid or expired")
+
+// Single-use tokens sent to users by email.
+// Token has format <random>.<signature> - signature binds random part to token purpose,
+// only hash of random part is persisted so leaked db rows can not be replayed
+type userTokens struct {
+	repo   repository.UserTokens
+	secret []byte
+}
+
+func newUserTokens(repo repository.UserTokens, secret string) *userTokens {
+	return &userTokens{repo: repo, secret: []byte(secret)}
+}
+
+func (t *userTokens) Issue(userId int, purpose string, ttl time.Duration) (string, error) {
+	nonce, err := t.IssueNonce(userId, purpose, ttl)
+	if err != nil {
+		return "", err
+	}
+
+	return nonce + "." + t.sign(purpose, nonce), nil
+}
+
+// Register single-use random value without signature, for tokens which are signed by the caller like JWT ids
+func (t *userTokens) IssueNonce(userId int, purpose string, ttl time.Duration) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	nonce := base64.RawURLEncoding.EncodeToString(raw)
+
+	err := t.repo.CreateToken(domain.UserToken{
+		UserId:    userId,
+		Purpose:   purpose,
+		TokenHash: hashToken(nonce),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return nonce, nil
+}
+
+// Check signature and burn the token, returns id of the token owner
+func (t *userTokens) Consume(token, purpose string) (int, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return 0, ErrInvalidToken
+	}
+
+	if !hmac.Equal([]byte(parts[1]), []byte(t.sign(purpose, parts[0]))) {
+		return 0, ErrInvalidToken
+	}
+
+	userId, err := t.repo.ConsumeToken(purpose, hashToken(parts[0]))
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+
+	return userId, nil
+}
+
+// Burn the value issued with IssueNonce, returns id of its owner
+func (t *userTokens) ConsumeNonce(nonce, purpose string) (int, error) {
+	if nonce == "" {
+		return 0, ErrInvalidToken
+	}
+
+	userId, err := t.repo.ConsumeToken(purpose, hashToken(nonce))
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+
+	return userId, nil
+}
+
+func (t *userTokens) Revoke(userId int, purpose string) error {
+	return t.repo.RevokeTokens(userId, purpose)
+}
+
+func (t *userTokens) sign(purpose, nonce string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(purpose + "." + nonce))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func hashToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
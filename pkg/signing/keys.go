@@ -0,0 +1,174 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	ALG_HS256 = "HS256"
+	ALG_RS256 = "RS256"
+	ALG_EDDSA = "EdDSA"
+
+	RSA_KEY_BITS = 2048
+)
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+func IsAsymmetric(alg string) bool {
+	return alg == ALG_RS256 || alg == ALG_EDDSA
+}
+
+func Method(alg string) (jwt.SigningMethod, error) {
+	switch alg {
+	case ALG_RS256:
+		return jwt.SigningMethodRS256, nil
+	case ALG_EDDSA:
+		return SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("Unsupported signing algorithm: %s", alg)
+	}
+}
+
+// Generate key pair with random key id, keys are returned in DER encoding (PKCS8 / PKIX)
+func GenerateKey(alg string) (kid string, private, public []byte, err error) {
+	var signer crypto.Signer
+
+	switch alg {
+	case ALG_RS256:
+		signer, err = rsa.GenerateKey(rand.Reader, RSA_KEY_BITS)
+	case ALG_EDDSA:
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		err = fmt.Errorf("Unsupported signing algorithm: %s", alg)
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if private, err = x509.MarshalPKCS8PrivateKey(signer); err != nil {
+		return "", nil, nil, err
+	}
+	if public, err = x509.MarshalPKIXPublicKey(signer.Public()); err != nil {
+		return "", nil, nil, err
+	}
+
+	raw := make([]byte, 8)
+	if _, err = rand.Read(raw); err != nil {
+		return "", nil, nil, err
+	}
+
+	return hex.EncodeToString(raw), private, public, nil
+}
+
+func ParsePrivateKey(der []byte) (crypto.Signer, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	// jwt-go expects RSA keys as pointers and Ed25519 keys as values
+	switch typed := key.(type) {
+	case *rsa.PrivateKey:
+		return typed, nil
+	case ed25519.PrivateKey:
+		return typed, nil
+	default:
+		return nil, errors.New("Unsupported private key type")
+	}
+}
+
+func ParsePublicKey(der []byte) (crypto.PublicKey, error) {
+	return x509.ParsePKIXPublicKey(der)
+}
+
+func ToJWK(kid, alg string, key crypto.PublicKey) (JWK, error) {
+	switch typed := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: alg,
+			N:   base64.RawURLEncoding.EncodeToString(typed.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(typed.E)).Bytes()),
+		}, nil
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Kid: kid,
+			Use: "sig",
+			Alg: alg,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(typed),
+		}, nil
+	default:
+		return JWK{}, errors.New("Unsupported public key type")
+	}
+}
+
+// Reverse of ToJWK, used to verify tokens of external issuers
+func FromJWK(jwk JWK) (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if jwk.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("Unsupported OKP key")
+		}
+		return ed25519.PublicKey(x), nil
+	case "EC":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if jwk.Crv != "P-256" {
+			return nil, errors.New("Unsupported EC curve")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, fmt.Errorf("Unsupported key type: %s", jwk.Kty)
+	}
+}
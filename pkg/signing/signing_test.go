@@ -0,0 +1,55 @@
+package signing
+
+import (
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	for _, alg := range []string{ALG_RS256, ALG_EDDSA} {
+		t.Run(alg, func(t *testing.T) {
+			kid, private, public, err := GenerateKey(alg)
+			assert.NoError(t, err)
+
+			signer, err := ParsePrivateKey(private)
+			assert.NoError(t, err)
+
+			method, err := Method(alg)
+			assert.NoError(t, err)
+
+			token := jwt.NewWithClaims(method, jwt.StandardClaims{Subject: "1"})
+			token.Header["kid"] = kid
+			signed, err := token.SignedString(signer)
+			assert.NoError(t, err)
+
+			// Public key should survive JWK round trip as external services use it
+			publicKey, err := ParsePublicKey(public)
+			assert.NoError(t, err)
+			jwk, err := ToJWK(kid, alg, publicKey)
+			assert.NoError(t, err)
+			restored, err := FromJWK(jwk)
+			assert.NoError(t, err)
+
+			parsed, err := jwt.ParseWithClaims(signed, &jwt.StandardClaims{}, func(t *jwt.Token) (interface{}, error) {
+				return restored, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, kid, parsed.Header["kid"])
+			assert.Equal(t, alg, parsed.Method.Alg())
+		})
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	sealed, err := Encrypt("secret", []byte("private key"))
+	assert.NoError(t, err)
+
+	plain, err := Decrypt("secret", sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "private key", string(plain))
+
+	_, err = Decrypt("another secret", sealed)
+	assert.Error(t, err)
+}
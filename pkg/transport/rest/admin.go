@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type UsersResponse struct {
+	Data []domain.UserInfo
+}
+
+// @Summary     Get users
+// @Tags        Admin
+// @Description Get all registered Users with their roles
+// @ID          admin-get-users
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} UsersResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/admin/users [get]
+func (h *Handler) AdminGetUsers(ctx *gin.Context) {
+	result, err := h.services.Admin.GetUsers()
+	if err != nil {
+		logger.LogHandlerIssue("admin-get-users", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, UsersResponse{result})
+}
+
+// @Summary     Get events
+// @Tags        Admin
+// @Description Get events of all Users
+// @ID          admin-get-events
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} EventsResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/admin/events [get]
+func (h *Handler) AdminGetEvents(ctx *gin.Context) {
+	result, err := h.services.Admin.GetEvents()
+	if err != nil {
+		logger.LogHandlerIssue("admin-get-events", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, EventsResponse{result})
+}
+
+// @Summary     Set role
+// @Tags        Admin
+// @Description Change role of defined User
+// @ID          admin-set-role
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int                   true "User Id"
+// @Param       input   body     domain.SetRoleRequest true "Role"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/admin/users/{id}/role [post]
+func (h *Handler) AdminSetRole(ctx *gin.Context) {
+	var request domain.SetRoleRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("admin-set-role", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actorId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	userId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("admin-set-role", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	if err := h.services.Admin.SetRole(actorId, userId, request.Role); err != nil {
+		logger.LogHandlerIssue("admin-set-role", err)
+		NewErrorResponse(ctx, adminErrorStatus(err), err.Error())
+		return
+	}
+
+	logger.LogSecurityEvent("role-changed", strconv.Itoa(userId), ctx.ClientIP(), fmt.Sprintf("role=%s actor=%d", request.Role, actorId))
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Role of User [id]:%d has been changed to %s", userId, request.Role),
+	})
+}
+
+// @Summary     Set MFA requirement
+// @Tags        Admin
+// @Description Enforce or relax MFA for defined User
+// @ID          admin-set-mfa-required
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int                          true "User Id"
+// @Param       input   body     domain.SetMfaRequiredRequest true "Requirement"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/admin/users/{id}/mfa-required [post]
+func (h *Handler) AdminSetMfaRequired(ctx *gin.Context) {
+	var request domain.SetMfaRequiredRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("admin-set-mfa-required", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	userId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("admin-set-mfa-required", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	if err := h.services.Admin.SetMfaRequired(userId, request.Required); err != nil {
+		logger.LogHandlerIssue("admin-set-mfa-required", err)
+		NewErrorResponse(ctx, adminErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("MFA requirement of User [id]:%d has been updated", userId),
+	})
+}
+
+func adminErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUnknownRole), errors.Is(err, service.ErrOwnRole):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_requirePermission(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockPolicy)
+
+	tests := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Allowed",
+			mockBehavior: func(r *service_mocks.MockPolicy) {
+				r.EXPECT().HasPermission(1, domain.PERMISSION_USERS_READ).Return(true, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Data":[{"id":2,"email":"user@example.com","username":"user","role":"user","emailVerified":true,"mfaEnabled":false,"mfaRequired":false}]}`,
+		},
+		{
+			name: "Not Allowed",
+			mockBehavior: func(r *service_mocks.MockPolicy) {
+				r.EXPECT().HasPermission(1, domain.PERMISSION_USERS_READ).Return(false, nil)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"User does not have required permission: users:read"}`,
+		},
+		{
+			name: "Service Error",
+			mockBehavior: func(r *service_mocks.MockPolicy) {
+				r.EXPECT().HasPermission(1, domain.PERMISSION_USERS_READ).Return(false, errors.New("Something went wrong"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"Something went wrong"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			policy := service_mocks.NewMockPolicy(c)
+			test.mockBehavior(policy)
+
+			admin := service_mocks.NewMockAdmin(c)
+			admin.EXPECT().GetUsers().Return([]domain.UserInfo{
+				{Id: 2, Email: "user@example.com", Username: "user", Role: domain.ROLE_USER, EmailVerified: true},
+			}, nil).AnyTimes()
+
+			services := &service.Service{Policy: policy, Admin: admin}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+			})
+			r.GET("/admin/users", handler.requirePermission(domain.PERMISSION_USERS_READ), handler.AdminGetUsers)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_adminSetRole(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAdmin)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"role":"moderator"}`,
+			mockBehavior: func(r *service_mocks.MockAdmin) {
+				r.EXPECT().SetRole(1, 2, domain.ROLE_MODERATOR).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Status":"Role of User [id]:2 has been changed to moderator"}`,
+		},
+		{
+			name:      "Unknown Role",
+			inputBody: `{"role":"owner"}`,
+			mockBehavior: func(r *service_mocks.MockAdmin) {
+				r.EXPECT().SetRole(1, 2, "owner").Return(service.ErrUnknownRole)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Unknown role"}`,
+		},
+		{
+			name:      "User Not Found",
+			inputBody: `{"role":"admin"}`,
+			mockBehavior: func(r *service_mocks.MockAdmin) {
+				r.EXPECT().SetRole(1, 2, domain.ROLE_ADMIN).Return(service.ErrUserNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"User is not found"}`,
+		},
+		{
+			name:                 "Invalid Request",
+			inputBody:            `{}`,
+			mockBehavior:         func(r *service_mocks.MockAdmin) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			admin := service_mocks.NewMockAdmin(c)
+			test.mockBehavior(admin)
+
+			services := &service.Service{Admin: admin}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+			})
+			r.POST("/admin/users/:id/role", handler.AdminSetRole)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/2/role", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
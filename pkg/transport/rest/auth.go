@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/salesforceanton/events-api/domain"
 	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
 )
 
 type SignInInput struct {
@@ -13,6 +17,19 @@ type SignInInput struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type TokenInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type EmailInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
 // @Summary     Registration
 // @Tags        Auth
 // @Description Register a new User in the system
@@ -47,13 +64,14 @@ func (h *Handler) SignUp(ctx *gin.Context) {
 
 // @Summary     Login
 // @Tags        Auth
-// @Description Login via Username and Password credentials
+// @Description Login via Username and Password credentials. Users with MFA receive challenge token for the second step
 // @ID          login
 // @Accept      json
 // @Produce     json
 // @Param       input   body     SignInInput  true   "Credentials"
-// @Success     200     {string} string       "token"
-// @Failure     400,404 {object} ErrorResponse
+// @Success     200     {object} domain.SignInResult
+// @Failure     400,401 {object} ErrorResponse
+// @Failure     429     {object} ErrorResponse
 // @Failure     500     {object} ErrorResponse
 // @Router      /auth/sign-in [post]
 func (h *Handler) SignIn(ctx *gin.Context) {
@@ -65,15 +83,178 @@ func (h *Handler) SignIn(ctx *gin.Context) {
 		return
 	}
 
-	token, err := h.services.Authorization.GenerateToken(request.Username, request.Password)
+	ip := ctx.ClientIP()
+	if !h.loginAllowed(ctx, request.Username, ip) {
+		return
+	}
+
+	result, err := h.services.Authorization.GenerateToken(request.Username, request.Password)
+	if errors.Is(err, service.ErrInvalidCredentials) {
+		if err := h.services.LoginGuard.RegisterFailure(request.Username, ip); err != nil {
+			logger.LogHandlerIssue("sign-in", err)
+		}
+		NewErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
 	if err != nil {
-		logger.LogHandlerIssue("sign-up", err)
+		logger.LogHandlerIssue("sign-in", err)
 		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, map[string]interface{}{
-		"token": token,
+	// Failures are kept until MFA step is passed, so password does not reset code guessing counter
+	if result.Token != "" {
+		if err := h.services.LoginGuard.RegisterSuccess(request.Username, ip); err != nil {
+			logger.LogHandlerIssue("sign-in", err)
+		}
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+
+}
+
+// @Summary     Verify email
+// @Tags        Auth
+// @Description Confirm User email address with token received by email
+// @ID          verify-email
+// @Accept      json
+// @Produce     json
+// @Param       input   body     TokenInput   true "Verification token"
+// @Success     200
+// @Failure     400,404 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /auth/verify-email [post]
+func (h *Handler) VerifyEmail(ctx *gin.Context) {
+	var request TokenInput
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("verify-email", err)
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	if err := h.services.Authorization.VerifyEmail(request.Token); err != nil {
+		logger.LogHandlerIssue("verify-email", err)
+		NewErrorResponse(ctx, tokenErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": "Email address has been verified successfully",
+	})
+}
+
+// @Summary     Resend verification
+// @Tags        Auth
+// @Description Send a new email verification link if address is registered and not verified yet
+// @ID          resend-verification
+// @Accept      json
+// @Produce     json
+// @Param       input   body     EmailInput   true "Email"
+// @Success     200
+// @Failure     400     {object} ErrorResponse
+// @Router      /auth/resend-verification [post]
+func (h *Handler) ResendVerification(ctx *gin.Context) {
+	var request EmailInput
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("resend-verification", err)
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	// Failure is only logged, otherwise the response would reveal that the address is registered
+	if err := h.services.Authorization.ResendVerification(request.Email); err != nil {
+		logger.LogHandlerIssue("resend-verification", err)
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": "If this address is registered, a verification link has been sent",
+	})
+}
+
+// @Summary     Forgot password
+// @Tags        Auth
+// @Description Send password reset link to the email if it is registered
+// @ID          forgot-password
+// @Accept      json
+// @Produce     json
+// @Param       input   body     EmailInput   true "Email"
+// @Success     200
+// @Failure     400     {object} ErrorResponse
+// @Router      /auth/forgot-password [post]
+func (h *Handler) ForgotPassword(ctx *gin.Context) {
+	var request EmailInput
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("forgot-password", err)
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	// Failure is only logged, otherwise the response would reveal that the address is registered
+	if err := h.services.Authorization.ForgotPassword(request.Email); err != nil {
+		logger.LogHandlerIssue("forgot-password", err)
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": "If this address is registered, a reset link has been sent",
+	})
+}
+
+// @Summary     Reset password
+// @Tags        Auth
+// @Description Set a new password using token received by email
+// @ID          reset-password
+// @Accept      json
+// @Produce     json
+// @Param       input   body     ResetPasswordInput true "Token and new password"
+// @Success     200
+// @Failure     400,404 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /auth/reset-password [post]
+func (h *Handler) ResetPassword(ctx *gin.Context) {
+	var request ResetPasswordInput
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("reset-password", err)
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	if err := h.services.Authorization.ResetPassword(request.Token, request.Password); err != nil {
+		logger.LogHandlerIssue("reset-password", err)
+		NewErrorResponse(ctx, tokenErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": "Password has been changed successfully",
 	})
+}
+
+func tokenErrorStatus(err error) int {
+	if errors.Is(err, service.ErrInvalidToken) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+// Reject attempt with Retry-After header if client has to wait after previous failures
+func (h *Handler) loginAllowed(ctx *gin.Context, username, ip string) bool {
+	retryAfter, err := h.services.LoginGuard.Check(username, ip)
+	if err != nil {
+		logger.LogHandlerIssue("sign-in", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	if retryAfter > 0 {
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		NewErrorResponse(ctx, http.StatusTooManyRequests, "Too many failed sign-in attempts, try again later")
+		return false
+	}
 
+	return true
 }
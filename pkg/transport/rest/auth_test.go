@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -15,6 +16,9 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// Remote address of requests built by httptest.NewRequest
+const testClientIp = "192.0.2.1"
+
 func TestHandler_signUp(t *testing.T) {
 	// Init Test Table
 	type mockBehavior func(r *service_mocks.MockAuthorization, user domain.User)
@@ -97,7 +101,7 @@ func TestHandler_signUp(t *testing.T) {
 
 func TestHandler_signIn(t *testing.T) {
 	// Init Test Table
-	type mockBehavior func(r *service_mocks.MockAuthorization, username, password string)
+	type mockBehavior func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, username, password string)
 
 	tests := []struct {
 		name                 string
@@ -106,6 +110,7 @@ func TestHandler_signIn(t *testing.T) {
 		inputBody            string
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
+		expectedRetryAfter   string
 		expectedResponseBody string
 	}{
 		{
@@ -113,18 +118,59 @@ func TestHandler_signIn(t *testing.T) {
 			username:  "username",
 			password:  "qwerty",
 			inputBody: `{"username": "username", "password": "qwerty"}`,
-			mockBehavior: func(r *service_mocks.MockAuthorization, username, password string) {
-				r.EXPECT().GenerateToken(username, password).Return("test_token", nil)
+			mockBehavior: func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, username, password string) {
+				g.EXPECT().Check(username, testClientIp).Return(time.Duration(0), nil)
+				r.EXPECT().GenerateToken(username, password).Return(domain.SignInResult{Token: "test_token"}, nil)
+				g.EXPECT().RegisterSuccess(username, testClientIp).Return(nil)
 			},
 			expectedStatusCode:   http.StatusCreated,
 			expectedResponseBody: `{"token":"test_token"}`,
 		},
+		{
+			name:      "MFA Required",
+			username:  "username",
+			password:  "qwerty",
+			inputBody: `{"username": "username", "password": "qwerty"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, username, password string) {
+				g.EXPECT().Check(username, testClientIp).Return(time.Duration(0), nil)
+				r.EXPECT().GenerateToken(username, password).Return(
+					domain.SignInResult{MfaRequired: true, ChallengeToken: "challenge_token"}, nil,
+				)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"mfaRequired":true,"challengeToken":"challenge_token"}`,
+		},
+		{
+			name:      "Wrong Credentials",
+			username:  "username",
+			password:  "wrong",
+			inputBody: `{"username": "username", "password": "wrong"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, username, password string) {
+				g.EXPECT().Check(username, testClientIp).Return(time.Duration(0), nil)
+				r.EXPECT().GenerateToken(username, password).Return(domain.SignInResult{}, service.ErrInvalidCredentials)
+				g.EXPECT().RegisterFailure(username, testClientIp).Return(nil)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"No registered User with this credentials"}`,
+		},
+		{
+			name:      "Throttled",
+			username:  "username",
+			password:  "qwerty",
+			inputBody: `{"username": "username", "password": "qwerty"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, username, password string) {
+				g.EXPECT().Check(username, testClientIp).Return(1500*time.Millisecond, nil)
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedRetryAfter:   "2",
+			expectedResponseBody: `{"message":"Too many failed sign-in attempts, try again later"}`,
+		},
 		{
 			name:                 "Invalid request",
 			username:             "username",
 			password:             "password",
 			inputBody:            `{"username": "username", "password": ""}`,
-			mockBehavior:         func(r *service_mocks.MockAuthorization, username, password string) {},
+			mockBehavior:         func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, username, password string) {},
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"message":"Request is invalid type"}`,
 		},
@@ -137,9 +183,10 @@ func TestHandler_signIn(t *testing.T) {
 			defer c.Finish()
 
 			authService := service_mocks.NewMockAuthorization(c)
-			test.mockBehavior(authService, test.username, test.password)
+			loginGuard := service_mocks.NewMockLoginGuard(c)
+			test.mockBehavior(authService, loginGuard, test.username, test.password)
 
-			services := &service.Service{Authorization: authService}
+			services := &service.Service{Authorization: authService, LoginGuard: loginGuard}
 			handler := Handler{services}
 
 			// Init Endpoint
@@ -153,6 +200,223 @@ func TestHandler_signIn(t *testing.T) {
 			// Make Request
 			r.ServeHTTP(resp, req)
 
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedRetryAfter, resp.Header().Get("Retry-After"))
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_verifyEmail(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAuthorization, token string)
+
+	tests := []struct {
+		name                 string
+		token                string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			token:     "test_token",
+			inputBody: `{"token": "test_token"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, token string) {
+				r.EXPECT().VerifyEmail(token).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Status":"Email address has been verified successfully"}`,
+		},
+		{
+			name:      "Invalid Token",
+			token:     "expired_token",
+			inputBody: `{"token": "expired_token"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, token string) {
+				r.EXPECT().VerifyEmail(token).Return(service.ErrInvalidToken)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Token is invalid or expired"}`,
+		},
+		{
+			name:                 "Invalid request",
+			inputBody:            `{}`,
+			mockBehavior:         func(r *service_mocks.MockAuthorization, token string) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			authService := service_mocks.NewMockAuthorization(c)
+			test.mockBehavior(authService, test.token)
+
+			services := &service.Service{Authorization: authService}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/verify-email", handler.VerifyEmail)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/verify-email", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_resetPassword(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAuthorization, token, password string)
+
+	tests := []struct {
+		name                 string
+		token                string
+		password             string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			token:     "test_token",
+			password:  "qwerty",
+			inputBody: `{"token": "test_token", "password": "qwerty"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, token, password string) {
+				r.EXPECT().ResetPassword(token, password).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Status":"Password has been changed successfully"}`,
+		},
+		{
+			name:      "Token Already Used",
+			token:     "test_token",
+			password:  "qwerty",
+			inputBody: `{"token": "test_token", "password": "qwerty"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, token, password string) {
+				r.EXPECT().ResetPassword(token, password).Return(service.ErrInvalidToken)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Token is invalid or expired"}`,
+		},
+		{
+			name:                 "Invalid request",
+			inputBody:            `{"token": "test_token"}`,
+			mockBehavior:         func(r *service_mocks.MockAuthorization, token, password string) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			authService := service_mocks.NewMockAuthorization(c)
+			test.mockBehavior(authService, test.token, test.password)
+
+			services := &service.Service{Authorization: authService}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/reset-password", handler.ResetPassword)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/reset-password", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_forgotPassword(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAuthorization, email string)
+
+	tests := []struct {
+		name                 string
+		email                string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			email:     "test@test.com",
+			inputBody: `{"email": "test@test.com"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, email string) {
+				r.EXPECT().ForgotPassword(email).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Status":"If this address is registered, a reset link has been sent"}`,
+		},
+		{
+			name:      "Mailer Failure",
+			email:     "test@test.com",
+			inputBody: `{"email": "test@test.com"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, email string) {
+				r.EXPECT().ForgotPassword(email).Return(errors.New("smtp is unavailable"))
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Status":"If this address is registered, a reset link has been sent"}`,
+		},
+		{
+			name:                 "Invalid request",
+			inputBody:            `{"email": ""}`,
+			mockBehavior:         func(r *service_mocks.MockAuthorization, email string) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			authService := service_mocks.NewMockAuthorization(c)
+			test.mockBehavior(authService, test.email)
+
+			services := &service.Service{Authorization: authService}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/forgot-password", handler.ForgotPassword)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/forgot-password", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
 			// Assert
 			assert.Equal(t, test.expectedStatusCode, resp.Code)
 			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+// @Summary     Get availability
+// @Tags        Availability
+// @Description Get timezone, working hours and upcoming out-of-office of current User
+// @ID          get-availability
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} domain.Availability
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/users/me/availability [get]
+func (h *Handler) GetAvailability(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Availability.Get(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-availability", err)
+		NewErrorResponse(ctx, availabilityErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Save availability
+// @Tags        Availability
+// @Description Replace timezone and working hours of current User, weekday is 0 for Sunday, days which are not listed are days off
+// @ID          save-availability
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.SaveAvailabilityRequest true "Request"
+// @Success     200     {object} domain.Availability
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/users/me/availability [post]
+func (h *Handler) SaveAvailability(ctx *gin.Context) {
+	var request domain.SaveAvailabilityRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("save-availability", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Availability.Save(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("save-availability", err)
+		NewErrorResponse(ctx, availabilityErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Add out-of-office
+// @Tags        Availability
+// @Description Add out-of-office range of current User, it is busy time in free/busy and invitations get a warning
+// @ID          add-out-of-office
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.SaveOutOfOfficeRequest true "Request"
+// @Success     201
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/users/me/availability/out-of-office [post]
+func (h *Handler) AddOutOfOffice(ctx *gin.Context) {
+	var request domain.SaveOutOfOfficeRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("add-out-of-office", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Availability.AddOutOfOffice(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("add-out-of-office", err)
+		NewErrorResponse(ctx, availabilityErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{
+		"id": result,
+	})
+}
+
+// @Summary     Delete out-of-office
+// @Tags        Availability
+// @Description Delete out-of-office range of current User
+// @ID          delete-out-of-office
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Out-of-office Id"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/users/me/availability/out-of-office/{id} [delete]
+func (h *Handler) DeleteOutOfOffice(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	outOfOfficeId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("delete-out-of-office", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	if err := h.services.Availability.DeleteOutOfOffice(actor, outOfOfficeId); err != nil {
+		logger.LogHandlerIssue("delete-out-of-office", err)
+		NewErrorResponse(ctx, availabilityErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Out-of-office [id]:%d has been deleted", outOfOfficeId),
+	})
+}
+
+func availabilityErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUnknownTimezone),
+		errors.Is(err, service.ErrInvalidWorkingHours),
+		errors.Is(err, service.ErrDuplicateWeekday),
+		errors.Is(err, service.ErrInvalidOutOfOffice):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrUserNotFound),
+		errors.Is(err, service.ErrOutOfOfficeNotFound):
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_saveAvailability(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAvailability, request domain.SaveAvailabilityRequest)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.SaveAvailabilityRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"timezoneId":"Europe/Berlin","workingHours":[{"weekday":1,"start":"09:00","end":"17:00"}]}`,
+			request: domain.SaveAvailabilityRequest{
+				TimezoneId:   "Europe/Berlin",
+				WorkingHours: []domain.WeekdayHours{{Weekday: 1, Start: "09:00", End: "17:00"}},
+			},
+			mockBehavior: func(r *service_mocks.MockAvailability, request domain.SaveAvailabilityRequest) {
+				r.EXPECT().Save(testActor, request).Return(domain.Availability{
+					TimezoneId:     "Europe/Berlin",
+					FreeBusyShared: true,
+					WorkingHours:   []domain.WeekdayHours{{Weekday: 1, Start: "09:00", End: "17:00"}},
+					OutOfOffice:    []domain.OutOfOffice{},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"timezoneId":"Europe/Berlin","freeBusyShared":true,` +
+				`"workingHours":[{"weekday":1,"start":"09:00","end":"17:00"}],"outOfOffice":[]}`,
+		},
+		{
+			name:      "Hide Free/Busy",
+			inputBody: `{"timezoneId":"UTC","freeBusyShared":false}`,
+			request: domain.SaveAvailabilityRequest{
+				TimezoneId:     "UTC",
+				FreeBusyShared: new(bool),
+			},
+			mockBehavior: func(r *service_mocks.MockAvailability, request domain.SaveAvailabilityRequest) {
+				r.EXPECT().Save(testActor, request).Return(domain.Availability{
+					TimezoneId:   "UTC",
+					WorkingHours: []domain.WeekdayHours{},
+					OutOfOffice:  []domain.OutOfOffice{},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"timezoneId":"UTC","freeBusyShared":false,"workingHours":[],"outOfOffice":[]}`,
+		},
+		{
+			name: "Duplicate Weekday",
+			inputBody: `{"workingHours":[{"weekday":1,"start":"09:00","end":"12:00"},` +
+				`{"weekday":1,"start":"13:00","end":"17:00"}]}`,
+			request: domain.SaveAvailabilityRequest{
+				WorkingHours: []domain.WeekdayHours{
+					{Weekday: 1, Start: "09:00", End: "12:00"},
+					{Weekday: 1, Start: "13:00", End: "17:00"},
+				},
+			},
+			mockBehavior: func(r *service_mocks.MockAvailability, request domain.SaveAvailabilityRequest) {
+				r.EXPECT().Save(testActor, request).Return(domain.Availability{}, service.ErrDuplicateWeekday)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Working hours are set twice for the same weekday"}`,
+		},
+		{
+			name:                 "Invalid Weekday",
+			inputBody:            `{"workingHours":[{"weekday":7,"start":"09:00","end":"17:00"}]}`,
+			mockBehavior:         func(r *service_mocks.MockAvailability, request domain.SaveAvailabilityRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			availability := service_mocks.NewMockAvailability(c)
+			test.mockBehavior(availability, test.request)
+
+			services := &service.Service{Availability: availability}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/availability", handler.SaveAvailability)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/availability", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteOutOfOffice(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAvailability)
+
+	tests := []struct {
+		name                 string
+		outOfOfficeId        string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:          "Ok",
+			outOfOfficeId: "2",
+			mockBehavior: func(r *service_mocks.MockAvailability) {
+				r.EXPECT().DeleteOutOfOffice(testActor, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Status":"Out-of-office [id]:2 has been deleted"}`,
+		},
+		{
+			name:          "Out-of-office Of Another User",
+			outOfOfficeId: "3",
+			mockBehavior: func(r *service_mocks.MockAvailability) {
+				r.EXPECT().DeleteOutOfOffice(testActor, 3).Return(service.ErrOutOfOfficeNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"Out-of-office is not found"}`,
+		},
+		{
+			name:                 "Invalid Id",
+			outOfOfficeId:        "vacation",
+			mockBehavior:         func(r *service_mocks.MockAvailability) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Invalid param in url: [id]"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			availability := service_mocks.NewMockAvailability(c)
+			test.mockBehavior(availability)
+
+			services := &service.Service{Availability: availability}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.DELETE("/out-of-office/:id", handler.DeleteOutOfOffice)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/out-of-office/"+test.outOfOfficeId, nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type BookingPagesResponse struct {
+	Data []domain.BookingPage
+}
+
+type BookingSlotsResponse struct {
+	Data []domain.BookingSlot
+}
+
+// @Summary     Get booking pages
+// @Tags        Booking Pages
+// @Description Get booking pages of current User in active organization
+// @ID          get-booking-pages
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} BookingPagesResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/booking-pages/ [get]
+func (h *Handler) GetBookingPages(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.BookingPages.GetAll(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-booking-pages", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, BookingPagesResponse{result})
+}
+
+// @Summary     Get booking page
+// @Tags        Booking Pages
+// @Description Get booking page of current User by defined Id
+// @ID          get-booking-page-by-id
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Booking Page Id"
+// @Success     200     {object} domain.BookingPage
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/booking-pages/{id} [get]
+func (h *Handler) GetBookingPageById(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	pageId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("get-booking-page-by-id", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.BookingPages.GetById(actor, pageId)
+	if err != nil {
+		logger.LogHandlerIssue("get-booking-page-by-id", err)
+		NewErrorResponse(ctx, bookingErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Create booking page
+// @Tags        Booking Pages
+// @Description Create booking page with duration, buffers, availability windows and max bookings per day
+// @ID          create-booking-page
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.SaveBookingPageRequest true "Request"
+// @Success     201
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/booking-pages/ [post]
+func (h *Handler) CreateBookingPage(ctx *gin.Context) {
+	var request domain.SaveBookingPageRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("create-booking-page", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.BookingPages.Create(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("create-booking-page", err)
+		NewErrorResponse(ctx, bookingErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{
+		"id": result,
+	})
+}
+
+// @Summary     Update booking page
+// @Tags        Booking Pages
+// @Description Update booking page, availability windows are replaced and slug is kept
+// @ID          update-booking-page
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int                           true "Booking Page Id"
+// @Param       input   body     domain.SaveBookingPageRequest true "Request"
+// @Success     200     {object} domain.BookingPage
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/booking-pages/{id} [post]
+func (h *Handler) UpdateBookingPage(ctx *gin.Context) {
+	var request domain.SaveBookingPageRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("update-booking-page", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	pageId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("update-booking-page", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.BookingPages.Update(actor, pageId, request)
+	if err != nil {
+		logger.LogHandlerIssue("update-booking-page", err)
+		NewErrorResponse(ctx, bookingErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Delete booking page
+// @Tags        Booking Pages
+// @Description Delete booking page, booked events stay in the calendar
+// @ID          delete-booking-page
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Booking Page Id"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/booking-pages/{id} [delete]
+func (h *Handler) DeleteBookingPage(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	pageId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("delete-booking-page", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	if err := h.services.BookingPages.Delete(actor, pageId); err != nil {
+		logger.LogHandlerIssue("delete-booking-page", err)
+		NewErrorResponse(ctx, bookingErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Booking page [id]:%d has been deleted", pageId),
+	})
+}
+
+// @Summary     Get booking page
+// @Tags        Public
+// @Description Get active booking page by slug, authentication is not required
+// @ID          get-booking-page
+// @Accept      json
+// @Produce     json
+// @Param       slug    path     string       true "Booking Page Slug"
+// @Success     200     {object} domain.PublicBookingPage
+// @Failure     404,429 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /public/booking/{slug} [get]
+func (h *Handler) GetBookingPage(ctx *gin.Context) {
+	result, err := h.services.Bookings.GetPage(ctx.Param("slug"))
+	if err != nil {
+		logger.LogHandlerIssue("get-booking-page", err)
+		NewErrorResponse(ctx, bookingErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Get booking slots
+// @Tags        Public
+// @Description Get open slots of booking page, a week from now by default, authentication is not required
+// @ID          get-booking-slots
+// @Accept      json
+// @Produce     json
+// @Param       slug    path     string       true  "Booking Page Slug"
+// @Param       from    query    string       false "Range start, RFC3339"
+// @Param       to      query    string       false "Range end, RFC3339"
+// @Success     200     {object} BookingSlotsResponse
+// @Failure     400     {object} ErrorResponse
+// @Failure     404,429 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /public/booking/{slug}/slots [get]
+func (h *Handler) GetBookingSlots(ctx *gin.Context) {
+	var filter domain.BookingSlotsFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		logger.LogHandlerIssue("get-booking-slots", errors.New("Invalid query params"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid query params")
+		return
+	}
+
+	result, err := h.services.Bookings.GetSlots(ctx.Param("slug"), filter.From, filter.To)
+	if err != nil {
+		logger.LogHandlerIssue("get-booking-slots", err)
+		NewErrorResponse(ctx, bookingErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, BookingSlotsResponse{result})
+}
+
+// @Summary     Book slot
+// @Tags        Public
+// @Description Book open slot of booking page, event is created in the calendar of the host, authentication is not required
+// @ID          book-slot
+// @Accept      json
+// @Produce     json
+// @Param       slug    path     string                true "Booking Page Slug"
+// @Param       input   body     domain.BookingRequest true "Request"
+// @Success     201     {object} domain.Booking
+// @Failure     400     {object} ErrorResponse
+// @Failure     404,429 {object} ErrorResponse
+// @Failure     409     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /public/booking/{slug} [post]
+func (h *Handler) BookSlot(ctx *gin.Context) {
+	var request domain.BookingRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("book-slot", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	result, err := h.services.Bookings.Book(ctx.Param("slug"), request)
+	if err != nil {
+		logger.LogHandlerIssue("book-slot", err)
+		NewErrorResponse(ctx, bookingErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+func bookingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUnknownTimezone),
+		errors.Is(err, service.ErrInvalidWorkingHours),
+		errors.Is(err, service.ErrOverlappingBookingWindow),
+		errors.Is(err, service.ErrInvalidBookingWindow):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrBookingPageNotFound),
+		errors.Is(err, service.ErrCalendarNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrSlotUnavailable):
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createBookingPage(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockBookingPages, request domain.SaveBookingPageRequest)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.SaveBookingPageRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			inputBody: `{"title":"Intro call","durationMinutes":30,"bufferAfterMinutes":10,"maxPerDay":4,` +
+				`"windows":[{"weekday":1,"start":"09:00","end":"12:00"}]}`,
+			request: domain.SaveBookingPageRequest{
+				Title:              "Intro call",
+				DurationMinutes:    30,
+				BufferAfterMinutes: 10,
+				MaxPerDay:          4,
+				Windows:            []domain.BookingWindow{{Weekday: 1, Start: "09:00", End: "12:00"}},
+			},
+			mockBehavior: func(r *service_mocks.MockBookingPages, request domain.SaveBookingPageRequest) {
+				r.EXPECT().Create(testActor, request).Return(3, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"id":3}`,
+		},
+		{
+			name: "Overlapping Windows",
+			inputBody: `{"title":"Intro call","durationMinutes":30,` +
+				`"windows":[{"weekday":1,"start":"09:00","end":"12:00"},{"weekday":1,"start":"11:00","end":"13:00"}]}`,
+			request: domain.SaveBookingPageRequest{
+				Title:           "Intro call",
+				DurationMinutes: 30,
+				Windows: []domain.BookingWindow{
+					{Weekday: 1, Start: "09:00", End: "12:00"},
+					{Weekday: 1, Start: "11:00", End: "13:00"},
+				},
+			},
+			mockBehavior: func(r *service_mocks.MockBookingPages, request domain.SaveBookingPageRequest) {
+				r.EXPECT().Create(testActor, request).Return(0, service.ErrOverlappingBookingWindow)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Availability windows of the same weekday overlap"}`,
+		},
+		{
+			name:                 "Without Windows",
+			inputBody:            `{"title":"Intro call","durationMinutes":30,"windows":[]}`,
+			mockBehavior:         func(r *service_mocks.MockBookingPages, request domain.SaveBookingPageRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			bookingPages := service_mocks.NewMockBookingPages(c)
+			test.mockBehavior(bookingPages, test.request)
+
+			services := &service.Service{BookingPages: bookingPages}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/booking-pages", handler.CreateBookingPage)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/booking-pages", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_bookSlot(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockBookings, slug string, request domain.BookingRequest)
+
+	start := time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		slug                 string
+		inputBody            string
+		request              domain.BookingRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			slug:      "intro-call-1a2b3c4d",
+			inputBody: `{"name":"Jane","email":"jane@example.com","start":"2024-05-06T09:00:00Z"}`,
+			request:   domain.BookingRequest{Name: "Jane", Email: "jane@example.com", Start: start},
+			mockBehavior: func(r *service_mocks.MockBookings, slug string, request domain.BookingRequest) {
+				r.EXPECT().Book(slug, request).Return(domain.Booking{
+					Id:         5,
+					GuestName:  "Jane",
+					GuestEmail: "jane@example.com",
+					StartsAt:   start,
+					EndsAt:     start.Add(30 * time.Minute),
+					CreatedAt:  start.Add(-time.Hour),
+				}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponseBody: `{"id":5,"name":"Jane","email":"jane@example.com","start":"2024-05-06T09:00:00Z",` +
+				`"end":"2024-05-06T09:30:00Z","createdAt":"2024-05-06T08:00:00Z"}`,
+		},
+		{
+			name:      "Slot Taken",
+			slug:      "intro-call-1a2b3c4d",
+			inputBody: `{"name":"Jane","email":"jane@example.com","start":"2024-05-06T09:00:00Z"}`,
+			request:   domain.BookingRequest{Name: "Jane", Email: "jane@example.com", Start: start},
+			mockBehavior: func(r *service_mocks.MockBookings, slug string, request domain.BookingRequest) {
+				r.EXPECT().Book(slug, request).Return(domain.Booking{}, service.ErrSlotUnavailable)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"Slot is not available"}`,
+		},
+		{
+			name:      "Inactive Page",
+			slug:      "paused-1a2b3c4d",
+			inputBody: `{"name":"Jane","email":"jane@example.com","start":"2024-05-06T09:00:00Z"}`,
+			request:   domain.BookingRequest{Name: "Jane", Email: "jane@example.com", Start: start},
+			mockBehavior: func(r *service_mocks.MockBookings, slug string, request domain.BookingRequest) {
+				r.EXPECT().Book(slug, request).Return(domain.Booking{}, service.ErrBookingPageNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"Booking page is not found"}`,
+		},
+		{
+			name:                 "Invalid Email",
+			slug:                 "intro-call-1a2b3c4d",
+			inputBody:            `{"name":"Jane","email":"jane","start":"2024-05-06T09:00:00Z"}`,
+			mockBehavior:         func(r *service_mocks.MockBookings, slug string, request domain.BookingRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			bookings := service_mocks.NewMockBookings(c)
+			test.mockBehavior(bookings, test.slug, test.request)
+
+			services := &service.Service{Bookings: bookings}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/booking/:slug", handler.BookSlot)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/booking/"+test.slug, bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_getBookingSlots(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockBookings)
+
+	from := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			query: "?from=2024-05-06T00:00:00Z&to=2024-05-07T00:00:00Z",
+			mockBehavior: func(r *service_mocks.MockBookings) {
+				start := from.Add(9 * time.Hour)
+				r.EXPECT().GetSlots("intro-call-1a2b3c4d", from, to).Return([]domain.BookingSlot{
+					{Start: start, End: start.Add(30 * time.Minute)},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Data":[{"start":"2024-05-06T09:00:00Z","end":"2024-05-06T09:30:00Z"}]}`,
+		},
+		{
+			name:  "Default Range",
+			query: "",
+			mockBehavior: func(r *service_mocks.MockBookings) {
+				r.EXPECT().GetSlots("intro-call-1a2b3c4d", time.Time{}, time.Time{}).Return([]domain.BookingSlot{}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Data":[]}`,
+		},
+		{
+			name:                 "Invalid Range",
+			query:                "?from=tomorrow",
+			mockBehavior:         func(r *service_mocks.MockBookings) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Invalid query params"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			bookings := service_mocks.NewMockBookings(c)
+			test.mockBehavior(bookings)
+
+			services := &service.Service{Bookings: bookings}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/booking/:slug/slots", handler.GetBookingSlots)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/booking/intro-call-1a2b3c4d/slots"+test.query, nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type CalendarsResponse struct {
+	Data []domain.Calendar
+}
+
+// @Summary     Get calendars
+// @Tags        Calendars
+// @Description Get calendars of current User in active organization, default calendar goes first
+// @ID          get-calendars
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} CalendarsResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/calendars/ [get]
+func (h *Handler) GetCalendars(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Calendars.GetAll(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-calendars", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CalendarsResponse{result})
+}
+
+// @Summary     Get calendar
+// @Tags        Calendars
+// @Description Get calendar of current User by defined Id
+// @ID          get-calendar
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Calendar Id"
+// @Success     200     {object} domain.Calendar
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/calendars/{id} [get]
+func (h *Handler) GetCalendar(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	calendarId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("get-calendar", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.Calendars.GetById(actor, calendarId)
+	if err != nil {
+		logger.LogHandlerIssue("get-calendar", err)
+		NewErrorResponse(ctx, calendarErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Create calendar
+// @Tags        Calendars
+// @Description Create calendar with color and default timezone of its events
+// @ID          create-calendar
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.SaveCalendarRequest true "Request"
+// @Success     201
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/calendars/ [post]
+func (h *Handler) CreateCalendar(ctx *gin.Context) {
+	var request domain.SaveCalendarRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("create-calendar", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Calendars.Create(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("create-calendar", err)
+		NewErrorResponse(ctx, calendarErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{
+		"id": result,
+	})
+}
+
+// @Summary     Update calendar
+// @Tags        Calendars
+// @Description Update name, color and default timezone of the calendar
+// @ID          update-calendar
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int                        true "Calendar Id"
+// @Param       input   body     domain.SaveCalendarRequest true "Request"
+// @Success     200     {object} domain.Calendar
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/calendars/{id} [post]
+func (h *Handler) UpdateCalendar(ctx *gin.Context) {
+	var request domain.SaveCalendarRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("update-calendar", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	calendarId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("update-calendar", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.Calendars.Update(actor, calendarId, request)
+	if err != nil {
+		logger.LogHandlerIssue("update-calendar", err)
+		NewErrorResponse(ctx, calendarErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Delete calendar
+// @Tags        Calendars
+// @Description Delete calendar with its events, default calendar can not be deleted
+// @ID          delete-calendar
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Calendar Id"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/calendars/{id} [delete]
+func (h *Handler) DeleteCalendar(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	calendarId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("delete-calendar", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	if err := h.services.Calendars.Delete(actor, calendarId); err != nil {
+		logger.LogHandlerIssue("delete-calendar", err)
+		NewErrorResponse(ctx, calendarErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Calendar [id]:%d has been deleted", calendarId),
+	})
+}
+
+func calendarErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidCalendarColor),
+		errors.Is(err, service.ErrUnknownTimezone),
+		errors.Is(err, service.ErrDefaultCalendarRemoval):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrCalendarNotFound):
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
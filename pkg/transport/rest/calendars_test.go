@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createCalendar(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockCalendars, request domain.SaveCalendarRequest)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.SaveCalendarRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"name":"Work","color":"#ff0000","timezoneId":"Europe/Berlin"}`,
+			request:   domain.SaveCalendarRequest{Name: "Work", Color: "#ff0000", TimezoneId: "Europe/Berlin"},
+			mockBehavior: func(r *service_mocks.MockCalendars, request domain.SaveCalendarRequest) {
+				r.EXPECT().Create(testActor, request).Return(2, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"id":2}`,
+		},
+		{
+			name:      "Invalid Color",
+			inputBody: `{"name":"Work","color":"red"}`,
+			request:   domain.SaveCalendarRequest{Name: "Work", Color: "red"},
+			mockBehavior: func(r *service_mocks.MockCalendars, request domain.SaveCalendarRequest) {
+				r.EXPECT().Create(testActor, request).Return(0, service.ErrInvalidCalendarColor)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Calendar color should be hex color like #3174ad"}`,
+		},
+		{
+			name:      "Read-Only Delegate",
+			inputBody: `{"name":"Work"}`,
+			request:   domain.SaveCalendarRequest{Name: "Work"},
+			mockBehavior: func(r *service_mocks.MockCalendars, request domain.SaveCalendarRequest) {
+				r.EXPECT().Create(testActor, request).Return(0, service.ErrForbidden)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"Not enough permissions for this operation"}`,
+		},
+		{
+			name:                 "Invalid Request",
+			inputBody:            `{"color":"#ff0000"}`,
+			mockBehavior:         func(r *service_mocks.MockCalendars, request domain.SaveCalendarRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			calendars := service_mocks.NewMockCalendars(c)
+			test.mockBehavior(calendars, test.request)
+
+			services := &service.Service{Calendars: calendars}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/calendars", handler.CreateCalendar)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/calendars", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteCalendar(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockCalendars)
+
+	tests := []struct {
+		name                 string
+		calendarId           string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Ok",
+			calendarId: "2",
+			mockBehavior: func(r *service_mocks.MockCalendars) {
+				r.EXPECT().Delete(testActor, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Status":"Calendar [id]:2 has been deleted"}`,
+		},
+		{
+			name:       "Default Calendar",
+			calendarId: "1",
+			mockBehavior: func(r *service_mocks.MockCalendars) {
+				r.EXPECT().Delete(testActor, 1).Return(service.ErrDefaultCalendarRemoval)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Default calendar can not be deleted"}`,
+		},
+		{
+			name:       "Calendar Of Another User",
+			calendarId: "3",
+			mockBehavior: func(r *service_mocks.MockCalendars) {
+				r.EXPECT().Delete(testActor, 3).Return(service.ErrCalendarNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"Calendar is not found"}`,
+		},
+		{
+			name:                 "Invalid Id",
+			calendarId:           "work",
+			mockBehavior:         func(r *service_mocks.MockCalendars) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Invalid param in url: [id]"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			calendars := service_mocks.NewMockCalendars(c)
+			test.mockBehavior(calendars)
+
+			services := &service.Service{Calendars: calendars}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.DELETE("/calendars/:id", handler.DeleteCalendar)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/calendars/"+test.calendarId, nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
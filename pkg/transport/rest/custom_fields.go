@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type CustomFieldsResponse struct {
+	Data []domain.CustomField
+}
+
+// @Summary     Get custom fields
+// @Tags        CustomFields
+// @Description Get custom field schema of active organization
+// @ID          get-custom-fields
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} CustomFieldsResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/custom-fields/ [get]
+func (h *Handler) GetCustomFields(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.CustomFields.GetAll(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-custom-fields", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CustomFieldsResponse{result})
+}
+
+// @Summary     Create custom field
+// @Tags        CustomFields
+// @Description Create custom field of events in active organization (organization owners and admins only)
+// @ID          create-custom-field
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.SaveCustomFieldRequest true "Request"
+// @Success     201
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     409     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/custom-fields/ [post]
+func (h *Handler) CreateCustomField(ctx *gin.Context) {
+	var request domain.SaveCustomFieldRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("create-custom-field", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.CustomFields.Create(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("create-custom-field", err)
+		NewErrorResponse(ctx, customFieldErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{
+		"id": result,
+	})
+}
+
+// @Summary     Update custom field
+// @Tags        CustomFields
+// @Description Update label, required flag and options of custom field (organization owners and admins only)
+// @ID          update-custom-field
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int                           true "Custom Field Id"
+// @Param       input   body     domain.SaveCustomFieldRequest true "Request"
+// @Success     200     {object} domain.CustomField
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404,409 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/custom-fields/{id} [post]
+func (h *Handler) UpdateCustomField(ctx *gin.Context) {
+	var request domain.SaveCustomFieldRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("update-custom-field", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	fieldId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("update-custom-field", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.CustomFields.Update(actor, fieldId, request)
+	if err != nil {
+		logger.LogHandlerIssue("update-custom-field", err)
+		NewErrorResponse(ctx, customFieldErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Delete custom field
+// @Tags        CustomFields
+// @Description Delete custom field and its values of events (organization owners and admins only)
+// @ID          delete-custom-field
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Custom Field Id"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/custom-fields/{id} [delete]
+func (h *Handler) DeleteCustomField(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	fieldId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("delete-custom-field", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	if err := h.services.CustomFields.Delete(actor, fieldId); err != nil {
+		logger.LogHandlerIssue("delete-custom-field", err)
+		NewErrorResponse(ctx, customFieldErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Custom field [id]:%d has been deleted successfully", fieldId),
+	})
+}
+
+func customFieldErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCustomFieldNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrCustomFieldExists):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidCustomFieldKey),
+		errors.Is(err, service.ErrInvalidCustomFieldOptions):
+		return http.StatusBadRequest
+	}
+
+	return organizationErrorStatus(err)
+}
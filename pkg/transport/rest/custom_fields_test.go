@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createCustomField(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockCustomFields, request domain.SaveCustomFieldRequest)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.SaveCustomFieldRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"key":"diet","label":"Dietary options","type":"enum","options":["vegan","vegetarian"]}`,
+			request: domain.SaveCustomFieldRequest{
+				Key:     "diet",
+				Label:   "Dietary options",
+				Type:    domain.CUSTOM_FIELD_TYPE_ENUM,
+				Options: []string{"vegan", "vegetarian"},
+			},
+			mockBehavior: func(r *service_mocks.MockCustomFields, request domain.SaveCustomFieldRequest) {
+				r.EXPECT().Create(testActor, request).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:      "Invalid Key",
+			inputBody: `{"key":"Budget Code","type":"string","required":true}`,
+			request:   domain.SaveCustomFieldRequest{Key: "Budget Code", Type: domain.CUSTOM_FIELD_TYPE_STRING, Required: true},
+			mockBehavior: func(r *service_mocks.MockCustomFields, request domain.SaveCustomFieldRequest) {
+				r.EXPECT().Create(testActor, request).Return(0, service.ErrInvalidCustomFieldKey)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Custom field key is invalid, use lowercase letters, digits and underscores"}`,
+		},
+		{
+			name:      "Duplicate Key",
+			inputBody: `{"key":"budget_code","type":"string"}`,
+			request:   domain.SaveCustomFieldRequest{Key: "budget_code", Type: domain.CUSTOM_FIELD_TYPE_STRING},
+			mockBehavior: func(r *service_mocks.MockCustomFields, request domain.SaveCustomFieldRequest) {
+				r.EXPECT().Create(testActor, request).Return(0, service.ErrCustomFieldExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"Custom field with this key already exists"}`,
+		},
+		{
+			name:      "Not Manager",
+			inputBody: `{"key":"budget_code","type":"string"}`,
+			request:   domain.SaveCustomFieldRequest{Key: "budget_code", Type: domain.CUSTOM_FIELD_TYPE_STRING},
+			mockBehavior: func(r *service_mocks.MockCustomFields, request domain.SaveCustomFieldRequest) {
+				r.EXPECT().Create(testActor, request).Return(0, service.ErrForbidden)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"Not enough permissions for this operation"}`,
+		},
+		{
+			name:                 "Unknown Type",
+			inputBody:            `{"key":"budget_code","type":"money"}`,
+			mockBehavior:         func(r *service_mocks.MockCustomFields, request domain.SaveCustomFieldRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			customFields := service_mocks.NewMockCustomFields(c)
+			test.mockBehavior(customFields, test.request)
+
+			services := &service.Service{CustomFields: customFields}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/custom-fields", handler.CreateCustomField)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/custom-fields", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
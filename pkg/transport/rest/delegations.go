@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type DelegationsResponse struct {
+	Data []domain.Delegation
+}
+
+type AuditLogResponse struct {
+	Data []domain.AuditEntry
+}
+
+// @Summary     Get delegations
+// @Tags        Delegations
+// @Description Get delegations given by the user and given to the user in active organization
+// @ID          get-delegations
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} DelegationsResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/delegations/ [get]
+func (h *Handler) GetDelegations(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Delegations.GetAll(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-delegations", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, DelegationsResponse{result})
+}
+
+// @Summary     Save delegation
+// @Tags        Delegations
+// @Description Delegate read or full access to own calendar to a member of active organization
+// @ID          save-delegation
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.SaveDelegationRequest true "Request"
+// @Success     201     {object} domain.Delegation
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/delegations/ [post]
+func (h *Handler) SaveDelegation(ctx *gin.Context) {
+	var request domain.SaveDelegationRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("save-delegation", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Delegations.Save(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("save-delegation", err)
+		NewErrorResponse(ctx, delegationErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+// @Summary     Delete delegation
+// @Tags        Delegations
+// @Description Revoke delegation given by the user or decline delegation given to the user
+// @ID          delete-delegation
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Delegation Id"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/delegations/{id} [delete]
+func (h *Handler) DeleteDelegation(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	delegationId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("delete-delegation", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	if err := h.services.Delegations.Delete(actor, delegationId); err != nil {
+		logger.LogHandlerIssue("delete-delegation", err)
+		NewErrorResponse(ctx, delegationErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Delegation [id]:%d has been deleted", delegationId),
+	})
+}
+
+// @Summary     Get audit log
+// @Tags        Delegations
+// @Description Get latest operations on events done by the user or on behalf of the user
+// @ID          get-audit-log
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} AuditLogResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/audit [get]
+func (h *Handler) GetAuditLog(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Audit.GetAll(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-audit-log", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, AuditLogResponse{result})
+}
+
+func delegationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUnknownDelegationAccess), errors.Is(err, service.ErrInvalidDelegation):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrDelegationNotFound), errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_saveDelegation(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockDelegations, request domain.SaveDelegationRequest)
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.SaveDelegationRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"delegateId":2,"access":"full"}`,
+			request:   domain.SaveDelegationRequest{DelegateId: 2, Access: domain.DELEGATION_ACCESS_FULL},
+			mockBehavior: func(r *service_mocks.MockDelegations, request domain.SaveDelegationRequest) {
+				r.EXPECT().Save(testActor, request).Return(domain.Delegation{
+					Id: 3, OrganizationId: 1, PrincipalId: 1, DelegateId: 2, Access: domain.DELEGATION_ACCESS_FULL, CreatedAt: createdAt,
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"id":3,"organizationId":1,"principalId":1,"delegateId":2,"access":"full","createdAt":"2024-01-01T00:00:00Z"}`,
+		},
+		{
+			name:      "Unknown Access",
+			inputBody: `{"delegateId":2,"access":"owner"}`,
+			request:   domain.SaveDelegationRequest{DelegateId: 2, Access: "owner"},
+			mockBehavior: func(r *service_mocks.MockDelegations, request domain.SaveDelegationRequest) {
+				r.EXPECT().Save(testActor, request).Return(domain.Delegation{}, service.ErrUnknownDelegationAccess)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Unknown delegation access"}`,
+		},
+		{
+			name:      "Delegate Outside Organization",
+			inputBody: `{"delegateId":2,"access":"read"}`,
+			request:   domain.SaveDelegationRequest{DelegateId: 2, Access: domain.DELEGATION_ACCESS_READ},
+			mockBehavior: func(r *service_mocks.MockDelegations, request domain.SaveDelegationRequest) {
+				r.EXPECT().Save(testActor, request).Return(domain.Delegation{}, service.ErrUserNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"User is not found"}`,
+		},
+		{
+			name:                 "Invalid Request",
+			inputBody:            `{"access":"read"}`,
+			mockBehavior:         func(r *service_mocks.MockDelegations, request domain.SaveDelegationRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			delegations := service_mocks.NewMockDelegations(c)
+			test.mockBehavior(delegations, test.request)
+
+			services := &service.Service{Delegations: delegations}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/delegations", handler.SaveDelegation)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/delegations", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_delegation(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockDelegations)
+
+	tests := []struct {
+		name                 string
+		headerValue          string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			headerValue: "5",
+			mockBehavior: func(r *service_mocks.MockDelegations) {
+				r.EXPECT().Access(testActor, 5).Return(domain.DELEGATION_ACCESS_READ, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1:5:read",
+		},
+		{
+			name:                 "Without Header",
+			mockBehavior:         func(r *service_mocks.MockDelegations) {},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1:0:",
+		},
+		{
+			name:        "Not Delegated",
+			headerValue: "5",
+			mockBehavior: func(r *service_mocks.MockDelegations) {
+				r.EXPECT().Access(testActor, 5).Return("", service.ErrDelegationNotFound)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"Calendar of user [id]:5 is not delegated"}`,
+		},
+		{
+			name:                 "Invalid Header",
+			headerValue:          "me",
+			mockBehavior:         func(r *service_mocks.MockDelegations) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"X-On-Behalf-Of Header is invalid"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			delegations := service_mocks.NewMockDelegations(c)
+			test.mockBehavior(delegations)
+
+			services := &service.Service{Delegations: delegations}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.GET("/events", handler.delegation, func(ctx *gin.Context) {
+				actor, _ := handler.getActor(ctx)
+				ctx.String(http.StatusOK, "%d:%d:%s", actor.UserId, actor.PrincipalId, actor.DelegatedAccess)
+			})
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/events", nil)
+			if test.headerValue != "" {
+				req.Header.Set(ON_BEHALF_OF_HEADER, test.headerValue)
+			}
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
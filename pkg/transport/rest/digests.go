@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+// @Summary     Get digest preferences
+// @Tags        Digests
+// @Description Get agenda digest preferences of current User, send time is wall time in timezone of the User
+// @ID          get-digest
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} domain.DigestPreferences
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/users/me/digest [get]
+func (h *Handler) GetDigest(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Digests.Get(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-digest", err)
+		NewErrorResponse(ctx, digestErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Save digest preferences
+// @Tags        Digests
+// @Description Choose frequency of agenda digest (off, daily, weekly), send time and weekday of weekly digest (0 is for Sunday)
+// @ID          save-digest
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.SaveDigestRequest true "Request"
+// @Success     200     {object} domain.DigestPreferences
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/users/me/digest [post]
+func (h *Handler) SaveDigest(ctx *gin.Context) {
+	var request domain.SaveDigestRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("save-digest", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Digests.Save(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("save-digest", err)
+		NewErrorResponse(ctx, digestErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func digestErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidSendTime):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_saveDigest(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockDigests, request domain.SaveDigestRequest)
+
+	weekday := 5
+	nextSendAt := time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.SaveDigestRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"frequency":"weekly","sendTime":"08:00","weekday":5}`,
+			request:   domain.SaveDigestRequest{Frequency: "weekly", SendTime: "08:00", Weekday: &weekday},
+			mockBehavior: func(r *service_mocks.MockDigests, request domain.SaveDigestRequest) {
+				r.EXPECT().Save(testActor, request).Return(domain.DigestPreferences{
+					Frequency: "weekly", SendTime: "08:00", Weekday: 5, TimezoneId: "Europe/Berlin", NextSendAt: &nextSendAt,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"frequency":"weekly","sendTime":"08:00","weekday":5,"timezoneId":"Europe/Berlin",` +
+				`"nextSendAt":"2024-03-01T07:00:00Z"}`,
+		},
+		{
+			name:      "Invalid Send Time",
+			inputBody: `{"frequency":"daily","sendTime":"8am"}`,
+			request:   domain.SaveDigestRequest{Frequency: "daily", SendTime: "8am"},
+			mockBehavior: func(r *service_mocks.MockDigests, request domain.SaveDigestRequest) {
+				r.EXPECT().Save(testActor, request).Return(domain.DigestPreferences{}, service.ErrInvalidSendTime)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Send time is invalid, use HH:MM"}`,
+		},
+		{
+			name:                 "Unknown Frequency",
+			inputBody:            `{"frequency":"hourly"}`,
+			mockBehavior:         func(r *service_mocks.MockDigests, request domain.SaveDigestRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+		{
+			name:                 "Invalid Weekday",
+			inputBody:            `{"frequency":"weekly","weekday":7}`,
+			mockBehavior:         func(r *service_mocks.MockDigests, request domain.SaveDigestRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			digests := service_mocks.NewMockDigests(c)
+			test.mockBehavior(digests, test.request)
+
+			services := &service.Service{Digests: digests}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/digest", handler.SaveDigest)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/digest", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+)
+
+type EventGrantsResponse struct {
+	Data []domain.EventGrant
+}
+
+// @Summary     Get event grants
+// @Tags        Events
+// @Description Get users and groups the Event is shared with (organizer and co-organizers only)
+// @ID          get-event-grants
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Event Id"
+// @Success     200     {object} EventGrantsResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/events/{id}/grants [get]
+func (h *Handler) GetEventGrants(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	eventId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("get-event-grants", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.EventGrants.GetGrants(actor, eventId)
+	if err != nil {
+		logger.LogHandlerIssue("get-event-grants", err)
+		NewErrorResponse(ctx, eventErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, EventGrantsResponse{result})
+}
+
+// @Summary     Share event
+// @Tags        Events
+// @Description Grant viewer, editor or co-organizer permission on the Event to a user or a group of the organization
+// @ID          save-event-grant
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int                     true "Event Id"
+// @Param       input   body     domain.SaveGrantRequest true "Request"
+// @Success     201     {object} domain.EventGrant
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/events/{id}/grants [post]
+func (h *Handler) SaveEventGrant(ctx *gin.Context) {
+	var request domain.SaveGrantRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("save-event-grant", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	eventId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("save-event-grant", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.EventGrants.SaveGrant(actor, eventId, request)
+	if err != nil {
+		logger.LogHandlerIssue("save-event-grant", err)
+		NewErrorResponse(ctx, eventErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+// @Summary     Unshare event
+// @Tags        Events
+// @Description Revoke permission granted on the Event
+// @ID          delete-event-grant
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Event Id"
+// @Param       grantId path     int          true "Grant Id"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/events/{id}/grants/{grantId} [delete]
+func (h *Handler) DeleteEventGrant(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	eventId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("delete-event-grant", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	grantId, err := h.getUrlParam(ctx, "grantId")
+	if err != nil {
+		logger.LogHandlerIssue("delete-event-grant", errors.New("Invalid param in url: [grantId]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [grantId]")
+		return
+	}
+
+	if err := h.services.EventGrants.DeleteGrant(actor, eventId, grantId); err != nil {
+		logger.LogHandlerIssue("delete-event-grant", err)
+		NewErrorResponse(ctx, eventErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Grant [id]:%d has been revoked successfully", grantId),
+	})
+}
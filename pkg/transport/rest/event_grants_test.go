@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_saveEventGrant(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockEventGrants, request domain.SaveGrantRequest)
+
+	userId := 2
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.SaveGrantRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"userId":2,"permission":"editor"}`,
+			request:   domain.SaveGrantRequest{UserId: &userId, Permission: domain.EVENT_PERMISSION_EDITOR},
+			mockBehavior: func(r *service_mocks.MockEventGrants, request domain.SaveGrantRequest) {
+				r.EXPECT().SaveGrant(testActor, 1, request).Return(domain.EventGrant{
+					Id: 5, EventId: 1, UserId: &userId, Permission: domain.EVENT_PERMISSION_EDITOR, CreatedAt: createdAt,
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"id":5,"eventId":1,"userId":2,"permission":"editor","createdAt":"2024-01-01T00:00:00Z"}`,
+		},
+		{
+			name:      "Unknown Permission",
+			inputBody: `{"userId":2,"permission":"owner"}`,
+			request:   domain.SaveGrantRequest{UserId: &userId, Permission: "owner"},
+			mockBehavior: func(r *service_mocks.MockEventGrants, request domain.SaveGrantRequest) {
+				r.EXPECT().SaveGrant(testActor, 1, request).Return(domain.EventGrant{}, service.ErrUnknownEventPermission)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Unknown event permission"}`,
+		},
+		{
+			name:      "Not Co-Organizer",
+			inputBody: `{"userId":2,"permission":"viewer"}`,
+			request:   domain.SaveGrantRequest{UserId: &userId, Permission: domain.EVENT_PERMISSION_VIEWER},
+			mockBehavior: func(r *service_mocks.MockEventGrants, request domain.SaveGrantRequest) {
+				r.EXPECT().SaveGrant(testActor, 1, request).Return(domain.EventGrant{}, service.ErrForbidden)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"Not enough permissions for this operation"}`,
+		},
+		{
+			name:      "User Outside Organization",
+			inputBody: `{"userId":2,"permission":"viewer"}`,
+			request:   domain.SaveGrantRequest{UserId: &userId, Permission: domain.EVENT_PERMISSION_VIEWER},
+			mockBehavior: func(r *service_mocks.MockEventGrants, request domain.SaveGrantRequest) {
+				r.EXPECT().SaveGrant(testActor, 1, request).Return(domain.EventGrant{}, service.ErrUserNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"User is not found"}`,
+		},
+		{
+			name:                 "Invalid Request",
+			inputBody:            `{"userId":2}`,
+			mockBehavior:         func(r *service_mocks.MockEventGrants, request domain.SaveGrantRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			grants := service_mocks.NewMockEventGrants(c)
+			test.mockBehavior(grants, test.request)
+
+			services := &service.Service{EventGrants: grants}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/events/:id/grants", handler.SaveEventGrant)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/events/1/grants", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
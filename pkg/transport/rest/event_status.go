@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+)
+
+// @Summary     Publish event
+// @Tags        Events
+// @Description Publish draft event (organizer and co-organizers)
+// @ID          publish-event
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Event Id"
+// @Success     200     {object} domain.Event
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404,409 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/events/{id}/publish [post]
+func (h *Handler) PublishEvent(ctx *gin.Context) {
+	actor, eventId, ok := h.eventStatusParams(ctx, "publish-event")
+	if !ok {
+		return
+	}
+
+	result, err := h.services.Events.Publish(actor, eventId)
+	if err != nil {
+		logger.LogHandlerIssue("publish-event", err)
+		NewErrorResponse(ctx, eventErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Cancel event
+// @Tags        Events
+// @Description Cancel published event with reason, cancelled event stays visible (organizer and co-organizers)
+// @ID          cancel-event
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int                       true "Event Id"
+// @Param       input   body     domain.CancelEventRequest true "Request"
+// @Success     200     {object} domain.Event
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404,409 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/events/{id}/cancel [post]
+func (h *Handler) CancelEvent(ctx *gin.Context) {
+	var request domain.CancelEventRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("cancel-event", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, eventId, ok := h.eventStatusParams(ctx, "cancel-event")
+	if !ok {
+		return
+	}
+
+	result, err := h.services.Events.Cancel(actor, eventId, request.Reason)
+	if err != nil {
+		logger.LogHandlerIssue("cancel-event", err)
+		NewErrorResponse(ctx, eventErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Reopen event
+// @Tags        Events
+// @Description Publish cancelled event again (organizer and co-organizers)
+// @ID          reopen-event
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Event Id"
+// @Success     200     {object} domain.Event
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404,409 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/events/{id}/reopen [post]
+func (h *Handler) ReopenEvent(ctx *gin.Context) {
+	actor, eventId, ok := h.eventStatusParams(ctx, "reopen-event")
+	if !ok {
+		return
+	}
+
+	result, err := h.services.Events.Reopen(actor, eventId)
+	if err != nil {
+		logger.LogHandlerIssue("reopen-event", err)
+		NewErrorResponse(ctx, eventErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) eventStatusParams(ctx *gin.Context, handler string) (domain.Actor, int, bool) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return actor, 0, false
+	}
+
+	eventId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue(handler, errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return actor, 0, false
+	}
+
+	return actor, eventId, true
+}
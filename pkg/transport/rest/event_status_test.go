@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_cancelEvent(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockEvents)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"reason":"Speaker is sick"}`,
+			mockBehavior: func(r *service_mocks.MockEvents) {
+				r.EXPECT().Cancel(testActor, 1, "Speaker is sick").Return(domain.Event{
+					Id:                 1,
+					Title:              "Go meetup",
+					OrganizerId:        1,
+					OrganizationId:     1,
+					CalendarId:         1,
+					Visibility:         domain.EVENT_VISIBILITY_PRIVATE,
+					Status:             domain.EVENT_STATUS_CANCELLED,
+					CancellationReason: "Speaker is sick",
+					Permission:         domain.EVENT_PERMISSION_ORGANIZER,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"id":1,"title":"Go meetup","startDatetime":"","endDatetime":"","timezoneId":"","organizerId":1,` +
+				`"organizationId":1,"description":"","calendarId":1,"visibility":"private","status":"cancelled",` +
+				`"cancellationReason":"Speaker is sick","permission":"organizer"}`,
+		},
+		{
+			name:      "Draft Event",
+			inputBody: `{"reason":"Speaker is sick"}`,
+			mockBehavior: func(r *service_mocks.MockEvents) {
+				r.EXPECT().Cancel(testActor, 1, "Speaker is sick").Return(domain.Event{}, service.ErrInvalidStatusTransition)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"Event status does not allow this transition"}`,
+		},
+		{
+			name:      "Editor",
+			inputBody: `{"reason":"Speaker is sick"}`,
+			mockBehavior: func(r *service_mocks.MockEvents) {
+				r.EXPECT().Cancel(testActor, 1, "Speaker is sick").Return(domain.Event{}, service.ErrForbidden)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"Not enough permissions for this operation"}`,
+		},
+		{
+			name:                 "Without Reason",
+			inputBody:            `{}`,
+			mockBehavior:         func(r *service_mocks.MockEvents) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			events := service_mocks.NewMockEvents(c)
+			test.mockBehavior(events)
+
+			services := &service.Service{Events: events}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/events/:id/cancel", handler.CancelEvent)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/events/1/cancel", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
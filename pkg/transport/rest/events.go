@@ -4,38 +4,61 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/salesforceanton/events-api/domain"
 	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
 )
 
 type EventsResponse struct {
 	Data []domain.Event
 }
 
+type ConflictResponse struct {
+	Message   string                 `json:"message"`
+	Conflicts []domain.EventConflict `json:"conflicts"`
+}
+
+type EventOverlapsResponse struct {
+	Data []domain.EventOverlap
+}
+
 // @Summary     Get all
 // @Tags        Events
-// @Description Get all events available for current user
+// @Description Get events organized by current User and events shared with the User, with effective permission
 // @ID          get-all
 // @Accept      json
 // @Produce     json
+// @Param       calendarId  query    []int  false "Calendar Ids" collectionFormat(multi)
+// @Param       tagId       query    []int  false "Tag Ids" collectionFormat(multi)
+// @Param       tagMatch    query    string false "Events with any of the tags or with all of them" Enums(any, all)
+// @Param       customField query    object false "Custom field values, e.g. customField[budget_code]=B42"
 // @Success     200     {array}  domain.Event
 // @Failure     400,404 {object} ErrorResponse
 // @Failure     500     {object} ErrorResponse
 // @Router      /api/events/ [get]
 func (h *Handler) GetAll(ctx *gin.Context) {
-	userId, err := h.getUserContext(ctx)
+	actor, err := h.getActor(ctx)
 	if err != nil {
 		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	filter, err := getEventsFilter(ctx)
+	if err != nil {
+		logger.LogHandlerIssue("get-all", err)
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// TODO: Handle sql: no rows error and return user-friendly error message
-	result, err := h.services.Events.GetAll(userId)
+	result, err := h.services.Events.GetAll(actor, filter)
 	if err != nil {
 		logger.LogHandlerIssue("get-all", err)
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		NewErrorResponse(ctx, eventErrorStatus(err), err.Error())
 		return
 	}
 
@@ -56,7 +79,7 @@ func (h *Handler) GetAll(ctx *gin.Context) {
 // @Failure     500     {object} ErrorResponse
 // @Router      /api/events/{id} [get]
 func (h *Handler) GetById(ctx *gin.Context) {
-	userId, err := h.getUserContext(ctx)
+	actor, err := h.getActor(ctx)
 	if err != nil {
 		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -69,11 +92,10 @@ func (h *Handler) GetById(ctx *gin.Context) {
 		return
 	}
 
-	// TODO: Handle sql: no rows error and return user-friendly error message
-	result, err := h.services.Events.GetById(userId, eventId)
+	result, err := h.services.Events.GetById(actor, eventId)
 	if err != nil {
 		logger.LogHandlerIssue("get-by-id", err)
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		NewErrorResponse(ctx, eventErrorStatus(err), err.Error())
 		return
 	}
 
@@ -82,13 +104,17 @@ func (h *Handler) GetById(ctx *gin.Context) {
 
 // @Summary     Create
 // @Tags        Events
-// @Description Create Event record with current User as Organizer
+// @Description Create Event record with current User as Organizer, overlapping events of participants are rejected.
+// @Description Attendees invited outside of working hours or during out-of-office are listed in Warnings
 // @ID          create
 // @Accept      json
 // @Produce     json
-// @Param       input   body     domain.SaveEventRequest true "Request"
+// @Param       input          body     domain.SaveEventRequest true  "Request"
+// @Param       allowConflicts query    bool                    false "Save event even if it overlaps other events"
+// @Param       checkAttendees query    bool                    false "Check conflicts of attendees too"
 // @Success     201
 // @Failure     400,404 {object} ErrorResponse
+// @Failure     409     {object} ConflictResponse
 // @Failure     500     {object} ErrorResponse
 // @Router      /api/events/ [post]
 func (h *Handler) Create(ctx *gin.Context) {
@@ -100,22 +126,34 @@ func (h *Handler) Create(ctx *gin.Context) {
 		return
 	}
 
-	userId, err := h.getUserContext(ctx)
+	actor, err := h.getActor(ctx)
 	if err != nil {
 		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	result, err := h.services.Events.Create(userId, request)
+	var check domain.ConflictCheck
+	if err := ctx.ShouldBindQuery(&check); err != nil {
+		logger.LogHandlerIssue("create", errors.New("Invalid query params"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid query params")
+		return
+	}
+
+	result, warnings, err := h.services.Events.Create(actor, request, check)
 	if err != nil {
 		logger.LogHandlerIssue("create", err)
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newEventErrorResponse(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, map[string]interface{}{
+	response := map[string]interface{}{
 		"Status": fmt.Sprintf("Event record [id]:%d has been saved successfully", result),
-	})
+	}
+	if len(warnings) > 0 {
+		response["Warnings"] = warnings
+	}
+
+	ctx.JSON(http.StatusCreated, response)
 }
 
 // @Summary     Update
@@ -124,10 +162,13 @@ func (h *Handler) Create(ctx *gin.Context) {
 // @ID          update
 // @Accept      json
 // @Produce     json
-// @Param       id      path     int                     true "Event Id"
-// @Param       input   body     domain.SaveEventRequest true "Request"
+// @Param       id             path     int                     true  "Event Id"
+// @Param       input          body     domain.SaveEventRequest true  "Request"
+// @Param       allowConflicts query    bool                    false "Save event even if it overlaps other events"
+// @Param       checkAttendees query    bool                    false "Check conflicts of attendees too"
 // @Success     201     {object} domain.Event
 // @Failure     400,404 {object} ErrorResponse
+// @Failure     409     {object} ConflictResponse
 // @Failure     500     {object} ErrorResponse
 // @Router      /api/events/{id} [post]
 func (h *Handler) Update(ctx *gin.Context) {
@@ -139,7 +180,7 @@ func (h *Handler) Update(ctx *gin.Context) {
 		return
 	}
 
-	userId, err := h.getUserContext(ctx)
+	actor, err := h.getActor(ctx)
 	if err != nil {
 		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -152,10 +193,17 @@ func (h *Handler) Update(ctx *gin.Context) {
 		return
 	}
 
-	result, err := h.services.Events.Update(userId, eventId, request)
+	var check domain.ConflictCheck
+	if err := ctx.ShouldBindQuery(&check); err != nil {
+		logger.LogHandlerIssue("update", errors.New("Invalid query params"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid query params")
+		return
+	}
+
+	result, err := h.services.Events.Update(actor, eventId, request, check)
 	if err != nil {
 		logger.LogHandlerIssue("update", err)
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newEventErrorResponse(ctx, err)
 		return
 	}
 
@@ -174,7 +222,7 @@ func (h *Handler) Update(ctx *gin.Context) {
 // @Failure     500     {object} ErrorResponse
 // @Router      /api/events/{id} [delete]
 func (h *Handler) Delete(ctx *gin.Context) {
-	userId, err := h.getUserContext(ctx)
+	actor, err := h.getActor(ctx)
 	if err != nil {
 		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -187,10 +235,10 @@ func (h *Handler) Delete(ctx *gin.Context) {
 		return
 	}
 
-	err = h.services.Events.Delete(userId, eventId)
+	err = h.services.Events.Delete(actor, eventId)
 	if err != nil {
 		logger.LogHandlerIssue("delete", err)
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		NewErrorResponse(ctx, eventErrorStatus(err), err.Error())
 		return
 	}
 
@@ -198,3 +246,157 @@ func (h *Handler) Delete(ctx *gin.Context) {
 		"Status": fmt.Sprintf("Event record [id]:%d has been deleted successfully", eventId),
 	})
 }
+
+// @Summary     Get conflicts
+// @Tags        Events
+// @Description Get pairs of overlapping upcoming events which current User organizes or attends
+// @ID          get-conflicts
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} EventOverlapsResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/events/conflicts [get]
+func (h *Handler) GetConflicts(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Events.GetConflicts(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-conflicts", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, EventOverlapsResponse{result})
+}
+
+// @Summary     Get nearby
+// @Tags        Events
+// @Description Get upcoming events organized by current User or shared with the User within radius of the point,
+// @Description nearest first. Events without coordinates are not found
+// @ID          get-nearby
+// @Accept      json
+// @Produce     json
+// @Param       lat     query    number       true  "Latitude"
+// @Param       lng     query    number       true  "Longitude"
+// @Param       radius  query    number       false "Radius in kilometers, 10 by default, up to 500"
+// @Success     200     {object} EventsResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/events/nearby [get]
+func (h *Handler) GetNearby(ctx *gin.Context) {
+	var filter domain.NearbyFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		logger.LogHandlerIssue("get-nearby", errors.New("Invalid query params"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid query params")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Events.GetNearby(actor, filter)
+	if err != nil {
+		logger.LogHandlerIssue("get-nearby", err)
+		NewErrorResponse(ctx, eventErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, EventsResponse{result})
+}
+
+// Conflicts of saved event are listed in the response
+func newEventErrorResponse(ctx *gin.Context, err error) {
+	var conflictErr *service.ConflictError
+	if errors.As(err, &conflictErr) {
+		ctx.AbortWithStatusJSON(http.StatusConflict, ConflictResponse{
+			Message:   conflictErr.Error(),
+			Conflicts: conflictErr.Conflicts,
+		})
+		return
+	}
+
+	NewErrorResponse(ctx, eventErrorStatus(err), err.Error())
+}
+
+// Calendars are given as repeated or comma separated query param: ?calendarId=1&calendarId=2 or ?calendarId=1,2
+func getEventsFilter(ctx *gin.Context) (domain.EventsFilter, error) {
+	var filter domain.EventsFilter
+	var err error
+
+	if filter.CalendarIds, err = getIdsQuery(ctx, "calendarId"); err != nil {
+		return filter, err
+	}
+
+	if filter.TagIds, err = getIdsQuery(ctx, "tagId"); err != nil {
+		return filter, err
+	}
+
+	filter.TagMatch = ctx.Query("tagMatch")
+
+	if values := ctx.QueryMap("customField"); len(values) > 0 {
+		filter.CustomFields = values
+	}
+
+	return filter, nil
+}
+
+// Ids are given as repeated param or comma separated
+func getIdsQuery(ctx *gin.Context, param string) ([]int, error) {
+	var result []int
+
+	for _, values := range ctx.QueryArray(param) {
+		for _, value := range strings.Split(values, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("Invalid query param: [%s]", param)
+			}
+
+			result = append(result, id)
+		}
+	}
+
+	return result, nil
+}
+
+func eventErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrEventNotFound),
+		errors.Is(err, service.ErrCalendarNotFound),
+		errors.Is(err, service.ErrGrantNotFound),
+		errors.Is(err, service.ErrUserNotFound),
+		errors.Is(err, service.ErrGroupNotFound),
+		errors.Is(err, service.ErrResourceNotFound),
+		errors.Is(err, service.ErrTagNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidGrant),
+		errors.Is(err, service.ErrUnknownEventPermission),
+		errors.Is(err, service.ErrUnknownVisibility),
+		errors.Is(err, service.ErrUnknownEventStatus),
+		errors.Is(err, service.ErrUnknownTimezone),
+		errors.Is(err, service.ErrInvalidEventTime),
+		errors.Is(err, service.ErrInvalidLocation),
+		errors.Is(err, service.ErrUnknownTagMatch),
+		errors.Is(err, service.ErrUnknownCustomField),
+		errors.Is(err, service.ErrMissingCustomField),
+		errors.Is(err, service.ErrInvalidCustomValue):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrInvalidStatusTransition),
+		errors.Is(err, service.ErrEventCancelled),
+		errors.Is(err, service.ErrEventConflict),
+		errors.Is(err, service.ErrResourceUnavailable):
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
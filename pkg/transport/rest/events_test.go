@@ -19,14 +19,17 @@ import (
 )
 
 var testEvent = domain.Event{
-	Id:            1,
-	Title:         "go to golang",
-	StartDatetime: time.Now().Local().String(),
-	TimezoneId:    "America/Los_Angeles",
-	OrganizerId:   1,
-	Description:   "Free meeting",
+	Id:             1,
+	Title:          "go to golang",
+	StartDatetime:  time.Now().Local().String(),
+	TimezoneId:     "America/Los_Angeles",
+	OrganizerId:    1,
+	OrganizationId: 1,
+	Description:    "Free meeting",
 }
 
+var testActor = domain.Actor{UserId: 1, OrganizationId: 1}
+
 var blankEventRecord domain.Event
 
 var testSaveRequest = domain.SaveEventRequest{
@@ -50,7 +53,7 @@ var invalidTestSaveRequest = domain.SaveEventRequest{
 
 func TestHandler_getAll(t *testing.T) {
 	// Init Test Table
-	type mockBehavior func(r *service_mocks.MockEvents, userId int)
+	type mockBehavior func(r *service_mocks.MockEvents, actor domain.Actor, filter domain.EventsFilter)
 
 	responseBody, _ := json.Marshal(EventsResponse{
 		[]domain.Event{testEvent},
@@ -58,25 +61,98 @@ func TestHandler_getAll(t *testing.T) {
 
 	tests := []struct {
 		name                 string
-		userId               int
+		query                string
+		actor                domain.Actor
+		filter               domain.EventsFilter
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
 		expectedResponseBody string
 	}{
 		{
-			name:   "Ok",
-			userId: 1,
-			mockBehavior: func(r *service_mocks.MockEvents, userId int) {
-				r.EXPECT().GetAll(userId).Return([]domain.Event{testEvent}, nil)
+			name:  "Ok",
+			actor: testActor,
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, filter domain.EventsFilter) {
+				r.EXPECT().GetAll(actor, filter).Return([]domain.Event{testEvent}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: string(responseBody),
+		},
+		{
+			name:   "Filter By Calendars",
+			query:  "?calendarId=1,2&calendarId=5",
+			actor:  testActor,
+			filter: domain.EventsFilter{CalendarIds: []int{1, 2, 5}},
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, filter domain.EventsFilter) {
+				r.EXPECT().GetAll(actor, filter).Return([]domain.Event{testEvent}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: string(responseBody),
+		},
+		{
+			name:   "Filter By Tags",
+			query:  "?tagId=3,4&tagMatch=all",
+			actor:  testActor,
+			filter: domain.EventsFilter{TagIds: []int{3, 4}, TagMatch: domain.TAG_MATCH_ALL},
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, filter domain.EventsFilter) {
+				r.EXPECT().GetAll(actor, filter).Return([]domain.Event{testEvent}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: string(responseBody),
 		},
 		{
-			name:   "Service Error",
-			userId: 1,
-			mockBehavior: func(r *service_mocks.MockEvents, userId int) {
-				r.EXPECT().GetAll(userId).Return(nil, errors.New("Something went wrong"))
+			name:   "Unknown Tag Match",
+			query:  "?tagId=3&tagMatch=some",
+			actor:  testActor,
+			filter: domain.EventsFilter{TagIds: []int{3}, TagMatch: "some"},
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, filter domain.EventsFilter) {
+				r.EXPECT().GetAll(actor, filter).Return(nil, service.ErrUnknownTagMatch)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Unknown tag match, use any or all"}`,
+		},
+		{
+			name:   "Filter By Custom Fields",
+			query:  "?customField[budget_code]=B42&customField[catering]=true",
+			actor:  testActor,
+			filter: domain.EventsFilter{CustomFields: map[string]string{"budget_code": "B42", "catering": "true"}},
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, filter domain.EventsFilter) {
+				r.EXPECT().GetAll(actor, filter).Return([]domain.Event{testEvent}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: string(responseBody),
+		},
+		{
+			name:   "Unknown Custom Field",
+			query:  "?customField[color]=red",
+			actor:  testActor,
+			filter: domain.EventsFilter{CustomFields: map[string]string{"color": "red"}},
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, filter domain.EventsFilter) {
+				r.EXPECT().GetAll(actor, filter).Return(nil, fmt.Errorf("%w: %s", service.ErrUnknownCustomField, "color"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Unknown custom field: color"}`,
+		},
+		{
+			name:                 "Invalid Tag Filter",
+			query:                "?tagId=onboarding",
+			actor:                testActor,
+			mockBehavior:         func(r *service_mocks.MockEvents, actor domain.Actor, filter domain.EventsFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Invalid query param: [tagId]"}`,
+		},
+		{
+			name:                 "Invalid Calendar Filter",
+			query:                "?calendarId=work",
+			actor:                testActor,
+			mockBehavior:         func(r *service_mocks.MockEvents, actor domain.Actor, filter domain.EventsFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Invalid query param: [calendarId]"}`,
+		},
+		{
+			name:  "Service Error",
+			actor: testActor,
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, filter domain.EventsFilter) {
+				r.EXPECT().GetAll(actor, filter).Return(nil, errors.New("Something went wrong"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"message":"Something went wrong"}`,
@@ -90,7 +166,7 @@ func TestHandler_getAll(t *testing.T) {
 			defer c.Finish()
 
 			eventsService := service_mocks.NewMockEvents(c)
-			test.mockBehavior(eventsService, test.userId)
+			test.mockBehavior(eventsService, test.actor, test.filter)
 
 			services := &service.Service{Events: eventsService}
 			handler := Handler{services}
@@ -104,13 +180,14 @@ func TestHandler_getAll(t *testing.T) {
 
 			r.Use(func(ctx *gin.Context) {
 				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
 			})
 
 			// Configure router
 			r.GET("/events", handler.GetAll)
 
 			// Do request
-			ctx.Request, _ = http.NewRequest(http.MethodGet, "/events", nil)
+			ctx.Request, _ = http.NewRequest(http.MethodGet, "/events"+test.query, nil)
 			r.ServeHTTP(resp, ctx.Request)
 
 			// Assert
@@ -120,13 +197,107 @@ func TestHandler_getAll(t *testing.T) {
 	}
 }
 
+func TestHandler_getNearby(t *testing.T) {
+	lat, lng := 52.52, 13.405
+	distance := 1.25
+
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockEvents)
+
+	tests := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			query: "?lat=52.52&lng=13.405&radius=5",
+			mockBehavior: func(r *service_mocks.MockEvents) {
+				r.EXPECT().GetNearby(testActor, domain.NearbyFilter{Lat: &lat, Lng: &lng, Radius: 5}).Return(
+					[]domain.Event{{
+						Id:            2,
+						Title:         "Meetup",
+						EventLocation: domain.EventLocation{Address: "Alexanderplatz 1", Latitude: &lat, Longitude: &lng},
+						DistanceKm:    &distance,
+					}}, nil,
+				)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"Data":[{"id":2,"title":"Meetup","startDatetime":"","endDatetime":"","timezoneId":"",` +
+				`"organizerId":0,"organizationId":0,"description":"","calendarId":0,"visibility":"","status":"",` +
+				`"address":"Alexanderplatz 1","latitude":52.52,"longitude":13.405,"distanceKm":1.25}]}`,
+		},
+		{
+			name:  "Default Radius",
+			query: "?lat=52.52&lng=13.405",
+			mockBehavior: func(r *service_mocks.MockEvents) {
+				r.EXPECT().GetNearby(testActor, domain.NearbyFilter{Lat: &lat, Lng: &lng}).Return([]domain.Event{}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Data":[]}`,
+		},
+		{
+			name:                 "Missing Point",
+			query:                "?lat=52.52",
+			mockBehavior:         func(r *service_mocks.MockEvents) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Invalid query params"}`,
+		},
+		{
+			name:                 "Radius Too Large",
+			query:                "?lat=52.52&lng=13.405&radius=1000",
+			mockBehavior:         func(r *service_mocks.MockEvents) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Invalid query params"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			eventsService := service_mocks.NewMockEvents(c)
+			test.mockBehavior(eventsService)
+
+			services := &service.Service{Events: eventsService}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.GET("/events/nearby", handler.GetNearby)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/events/nearby"+test.query, nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
 func TestHandler_Create(t *testing.T) {
 	// Init Test Table
-	type mockBehavior func(r *service_mocks.MockEvents, userId int, request domain.SaveEventRequest)
+	type mockBehavior func(r *service_mocks.MockEvents, actor domain.Actor, request domain.SaveEventRequest)
+
+	conflictStart := time.Date(2024, 5, 1, 16, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name                 string
-		userId               int
+		query                string
+		actor                domain.Actor
 		saveRequest          domain.SaveEventRequest
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
@@ -134,19 +305,81 @@ func TestHandler_Create(t *testing.T) {
 	}{
 		{
 			name:        "Ok",
-			userId:      1,
+			actor:       testActor,
 			saveRequest: testSaveRequest,
-			mockBehavior: func(r *service_mocks.MockEvents, userId int, request domain.SaveEventRequest) {
-				r.EXPECT().Create(userId, testSaveRequest).Return(1, nil)
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, request domain.SaveEventRequest) {
+				r.EXPECT().Create(actor, testSaveRequest, domain.ConflictCheck{}).Return(1, nil, nil)
 			},
 			expectedStatusCode:   http.StatusCreated,
 			expectedResponseBody: `{"Status":"Event record [id]:1 has been saved successfully"}`,
 		},
+		{
+			name:        "Attendee Out of Office",
+			actor:       testActor,
+			saveRequest: testSaveRequest,
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, request domain.SaveEventRequest) {
+				r.EXPECT().Create(actor, testSaveRequest, domain.ConflictCheck{}).Return(1, []domain.AvailabilityWarning{
+					{UserId: 2, Reason: domain.AVAILABILITY_WARNING_OUT_OF_OFFICE},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponseBody: `{"Status":"Event record [id]:1 has been saved successfully",` +
+				`"Warnings":[{"userId":2,"reason":"out_of_office"}]}`,
+		},
+		{
+			name:        "Conflicts",
+			query:       "?checkAttendees=true",
+			actor:       testActor,
+			saveRequest: testSaveRequest,
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, request domain.SaveEventRequest) {
+				r.EXPECT().Create(actor, testSaveRequest, domain.ConflictCheck{CheckAttendees: true}).Return(0, nil, &service.ConflictError{
+					Conflicts: []domain.EventConflict{
+						{UserId: 1, EventId: 7, StartsAt: conflictStart, EndsAt: conflictStart.Add(time.Hour)},
+						{UserId: 2, StartsAt: conflictStart, EndsAt: conflictStart.Add(30 * time.Minute)},
+					},
+				})
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponseBody: `{"message":"Event conflicts with other events of participants","conflicts":[` +
+				`{"userId":1,"eventId":7,"startsAt":"2024-05-01T16:00:00Z","endsAt":"2024-05-01T17:00:00Z"},` +
+				`{"userId":2,"startsAt":"2024-05-01T16:00:00Z","endsAt":"2024-05-01T16:30:00Z"}]}`,
+		},
+		{
+			name:        "Conflicts Allowed",
+			query:       "?allowConflicts=true",
+			actor:       testActor,
+			saveRequest: testSaveRequest,
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, request domain.SaveEventRequest) {
+				r.EXPECT().Create(actor, testSaveRequest, domain.ConflictCheck{AllowConflicts: true}).Return(1, nil, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"Status":"Event record [id]:1 has been saved successfully"}`,
+		},
+		{
+			name:        "Invalid Time",
+			actor:       testActor,
+			saveRequest: testSaveRequest,
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, request domain.SaveEventRequest) {
+				r.EXPECT().Create(actor, testSaveRequest, domain.ConflictCheck{}).Return(0, nil, service.ErrInvalidEventTime)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Event time is invalid, end should be after start"}`,
+		},
+		{
+			name:        "Resource Unavailable",
+			actor:       testActor,
+			saveRequest: testSaveRequest,
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, request domain.SaveEventRequest) {
+				r.EXPECT().Create(actor, testSaveRequest, domain.ConflictCheck{}).Return(0, nil, service.ErrResourceUnavailable)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"Resource is already reserved at this time"}`,
+		},
 		{
 			name:                 "Invalid Request",
-			userId:               1,
+			actor:                testActor,
 			saveRequest:          invalidTestSaveRequest,
-			mockBehavior:         func(r *service_mocks.MockEvents, userId int, request domain.SaveEventRequest) {},
+			mockBehavior:         func(r *service_mocks.MockEvents, actor domain.Actor, request domain.SaveEventRequest) {},
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"message":"Request is invalid type"}`,
 		},
@@ -159,7 +392,7 @@ func TestHandler_Create(t *testing.T) {
 			defer c.Finish()
 
 			eventsService := service_mocks.NewMockEvents(c)
-			test.mockBehavior(eventsService, test.userId, test.saveRequest)
+			test.mockBehavior(eventsService, test.actor, test.saveRequest)
 
 			services := &service.Service{Events: eventsService}
 			handler := Handler{services}
@@ -173,6 +406,7 @@ func TestHandler_Create(t *testing.T) {
 
 			r.Use(func(ctx *gin.Context) {
 				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
 			})
 
 			// Configure router
@@ -181,7 +415,7 @@ func TestHandler_Create(t *testing.T) {
 			// Do request
 
 			reqBody, _ := json.Marshal(test.saveRequest)
-			ctx.Request, _ = http.NewRequest(http.MethodGet, "/events", bytes.NewBuffer(reqBody))
+			ctx.Request, _ = http.NewRequest(http.MethodGet, "/events"+test.query, bytes.NewBuffer(reqBody))
 			r.ServeHTTP(resp, ctx.Request)
 
 			// Assert
@@ -193,13 +427,13 @@ func TestHandler_Create(t *testing.T) {
 
 func TestHandler_getById(t *testing.T) {
 	// Init Test Table
-	type mockBehavior func(r *service_mocks.MockEvents, userId, eventId int)
+	type mockBehavior func(r *service_mocks.MockEvents, actor domain.Actor, eventId int)
 
 	responseBody, _ := json.Marshal(testEvent)
 
 	tests := []struct {
 		name                 string
-		userId               int
+		actor                domain.Actor
 		eventId              int
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
@@ -207,24 +441,34 @@ func TestHandler_getById(t *testing.T) {
 	}{
 		{
 			name:    "Ok",
-			userId:  1,
+			actor:   testActor,
 			eventId: 1,
-			mockBehavior: func(r *service_mocks.MockEvents, userId, eventId int) {
-				r.EXPECT().GetById(userId, eventId).Return(testEvent, nil)
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, eventId int) {
+				r.EXPECT().GetById(actor, eventId).Return(testEvent, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: string(responseBody),
 		},
 		{
 			name:    "Event Record does not exist",
-			userId:  1,
+			actor:   testActor,
 			eventId: 448,
-			mockBehavior: func(r *service_mocks.MockEvents, userId, eventId int) {
-				r.EXPECT().GetById(userId, eventId).Return(blankEventRecord, errors.New("sql: no rows in result set"))
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, eventId int) {
+				r.EXPECT().GetById(actor, eventId).Return(blankEventRecord, errors.New("sql: no rows in result set"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"message":"sql: no rows in result set"}`,
 		},
+		{
+			name:    "Event Not Accessible",
+			actor:   testActor,
+			eventId: 2,
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, eventId int) {
+				r.EXPECT().GetById(actor, eventId).Return(blankEventRecord, service.ErrEventNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"Event is not found"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -234,7 +478,7 @@ func TestHandler_getById(t *testing.T) {
 			defer c.Finish()
 
 			eventsService := service_mocks.NewMockEvents(c)
-			test.mockBehavior(eventsService, test.userId, test.eventId)
+			test.mockBehavior(eventsService, test.actor, test.eventId)
 
 			services := &service.Service{Events: eventsService}
 			handler := Handler{services}
@@ -248,6 +492,7 @@ func TestHandler_getById(t *testing.T) {
 
 			r.Use(func(ctx *gin.Context) {
 				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
 			})
 
 			// Configure router
@@ -266,11 +511,11 @@ func TestHandler_getById(t *testing.T) {
 
 func TestHandler_delete(t *testing.T) {
 	// Init Test Table
-	type mockBehavior func(r *service_mocks.MockEvents, userId, eventId int)
+	type mockBehavior func(r *service_mocks.MockEvents, actor domain.Actor, eventId int)
 
 	tests := []struct {
 		name                 string
-		userId               int
+		actor                domain.Actor
 		eventId              int
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
@@ -278,24 +523,34 @@ func TestHandler_delete(t *testing.T) {
 	}{
 		{
 			name:    "Ok",
-			userId:  1,
+			actor:   testActor,
 			eventId: 1,
-			mockBehavior: func(r *service_mocks.MockEvents, userId, eventId int) {
-				r.EXPECT().Delete(userId, eventId).Return(nil)
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, eventId int) {
+				r.EXPECT().Delete(actor, eventId).Return(nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"Status":"Event record [id]:1 has been deleted successfully"}`,
 		},
 		{
 			name:    "Event Record does not exist",
-			userId:  1,
+			actor:   testActor,
 			eventId: 448,
-			mockBehavior: func(r *service_mocks.MockEvents, userId, eventId int) {
-				r.EXPECT().Delete(userId, eventId).Return(errors.New("sql: no rows in result set"))
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, eventId int) {
+				r.EXPECT().Delete(actor, eventId).Return(errors.New("sql: no rows in result set"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"message":"sql: no rows in result set"}`,
 		},
+		{
+			name:    "Not Enough Permissions",
+			actor:   testActor,
+			eventId: 2,
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, eventId int) {
+				r.EXPECT().Delete(actor, eventId).Return(service.ErrForbidden)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"Not enough permissions for this operation"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -305,7 +560,7 @@ func TestHandler_delete(t *testing.T) {
 			defer c.Finish()
 
 			eventsService := service_mocks.NewMockEvents(c)
-			test.mockBehavior(eventsService, test.userId, test.eventId)
+			test.mockBehavior(eventsService, test.actor, test.eventId)
 
 			services := &service.Service{Events: eventsService}
 			handler := Handler{services}
@@ -319,6 +574,7 @@ func TestHandler_delete(t *testing.T) {
 
 			r.Use(func(ctx *gin.Context) {
 				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
 			})
 
 			// Configure router
@@ -337,7 +593,7 @@ func TestHandler_delete(t *testing.T) {
 
 func TestHandler_update(t *testing.T) {
 	// Init Test Table
-	type mockBehavior func(r *service_mocks.MockEvents, userId, eventId int, request domain.SaveEventRequest)
+	type mockBehavior func(r *service_mocks.MockEvents, actor domain.Actor, eventId int, request domain.SaveEventRequest)
 
 	updatedEvent := domain.Event{
 		Id:            1,
@@ -351,7 +607,7 @@ func TestHandler_update(t *testing.T) {
 
 	tests := []struct {
 		name                 string
-		userId               int
+		actor                domain.Actor
 		eventId              int
 		updateRequest        domain.SaveEventRequest
 		mockBehavior         mockBehavior
@@ -360,24 +616,46 @@ func TestHandler_update(t *testing.T) {
 	}{
 		{
 			name:          "Ok",
-			userId:        1,
+			actor:         testActor,
 			eventId:       1,
 			updateRequest: testUpdateRequest,
-			mockBehavior: func(r *service_mocks.MockEvents, userId, eventId int, request domain.SaveEventRequest) {
-				r.EXPECT().Update(userId, eventId, request).Return(updatedEvent, nil)
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, eventId int, request domain.SaveEventRequest) {
+				r.EXPECT().Update(actor, eventId, request, domain.ConflictCheck{}).Return(updatedEvent, nil)
 			},
 			expectedStatusCode:   http.StatusCreated,
 			expectedResponseBody: string(updateResponse),
 		},
 		{
 			name:                 "Invalid Update Request",
-			userId:               1,
+			actor:                testActor,
 			eventId:              1,
 			updateRequest:        invalidTestSaveRequest,
-			mockBehavior:         func(r *service_mocks.MockEvents, userId, eventId int, request domain.SaveEventRequest) {},
+			mockBehavior:         func(r *service_mocks.MockEvents, actor domain.Actor, eventId int, request domain.SaveEventRequest) {},
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"message":"Request is invalid type"}`,
 		},
+		{
+			name:          "Not Enough Permissions",
+			actor:         testActor,
+			eventId:       2,
+			updateRequest: testUpdateRequest,
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, eventId int, request domain.SaveEventRequest) {
+				r.EXPECT().Update(actor, eventId, request, domain.ConflictCheck{}).Return(blankEventRecord, service.ErrForbidden)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"Not enough permissions for this operation"}`,
+		},
+		{
+			name:          "Resource Unavailable",
+			actor:         testActor,
+			eventId:       1,
+			updateRequest: testUpdateRequest,
+			mockBehavior: func(r *service_mocks.MockEvents, actor domain.Actor, eventId int, request domain.SaveEventRequest) {
+				r.EXPECT().Update(actor, eventId, request, domain.ConflictCheck{}).Return(blankEventRecord, service.ErrResourceUnavailable)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"Resource is already reserved at this time"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -387,7 +665,7 @@ func TestHandler_update(t *testing.T) {
 			defer c.Finish()
 
 			eventsService := service_mocks.NewMockEvents(c)
-			test.mockBehavior(eventsService, test.userId, test.eventId, test.updateRequest)
+			test.mockBehavior(eventsService, test.actor, test.eventId, test.updateRequest)
 
 			services := &service.Service{Events: eventsService}
 			handler := Handler{services}
@@ -401,6 +679,7 @@ func TestHandler_update(t *testing.T) {
 
 			r.Use(func(ctx *gin.Context) {
 				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
 			})
 
 			// Configure router
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type FreeBusyResponse struct {
+	Data []domain.UserFreeBusy
+}
+
+// @Summary     Get free/busy
+// @Tags        Events
+// @Description Get merged busy intervals of members of the active organization in the time window, events are not disclosed
+// @ID          get-freebusy
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.FreeBusyRequest true "Request"
+// @Success     200     {object} FreeBusyResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/freebusy [post]
+func (h *Handler) GetFreeBusy(ctx *gin.Context) {
+	var request domain.FreeBusyRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("get-freebusy", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.FreeBusy.GetFreeBusy(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("get-freebusy", err)
+		NewErrorResponse(ctx, freeBusyErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, FreeBusyResponse{result})
+}
+
+func freeBusyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidFreeBusyWindow),
+		errors.Is(err, service.ErrTooManyFreeBusyUsers):
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_getFreeBusy(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockFreeBusy, request domain.FreeBusyRequest)
+
+	timeMin := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	timeMax := time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.FreeBusyRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"userIds":[2,3],"timeMin":"2024-05-01T08:00:00Z","timeMax":"2024-05-01T18:00:00Z"}`,
+			request:   domain.FreeBusyRequest{UserIds: []int{2, 3}, TimeMin: timeMin, TimeMax: timeMax},
+			mockBehavior: func(r *service_mocks.MockFreeBusy, request domain.FreeBusyRequest) {
+				r.EXPECT().GetFreeBusy(testActor, request).Return([]domain.UserFreeBusy{
+					{UserId: 2, Busy: []domain.BusyInterval{{Start: timeMin.Add(time.Hour), End: timeMin.Add(3 * time.Hour)}}},
+					{UserId: 3, Busy: []domain.BusyInterval{}},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"Data":[{"userId":2,"busy":[{"start":"2024-05-01T09:00:00Z","end":"2024-05-01T11:00:00Z"}]},` +
+				`{"userId":3,"busy":[]}]}`,
+		},
+		{
+			name:      "Private Free/Busy",
+			inputBody: `{"userIds":[2],"timeMin":"2024-05-01T08:00:00Z","timeMax":"2024-05-01T18:00:00Z"}`,
+			request:   domain.FreeBusyRequest{UserIds: []int{2}, TimeMin: timeMin, TimeMax: timeMax},
+			mockBehavior: func(r *service_mocks.MockFreeBusy, request domain.FreeBusyRequest) {
+				r.EXPECT().GetFreeBusy(testActor, request).Return([]domain.UserFreeBusy{
+					{UserId: 2, Busy: []domain.BusyInterval{}, Private: true},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Data":[{"userId":2,"busy":[],"private":true}]}`,
+		},
+		{
+			name:      "Invalid Window",
+			inputBody: `{"userIds":[2],"timeMin":"2024-05-01T18:00:00Z","timeMax":"2024-05-01T08:00:00Z"}`,
+			request:   domain.FreeBusyRequest{UserIds: []int{2}, TimeMin: timeMax, TimeMax: timeMin},
+			mockBehavior: func(r *service_mocks.MockFreeBusy, request domain.FreeBusyRequest) {
+				r.EXPECT().GetFreeBusy(testActor, request).Return(nil, service.ErrInvalidFreeBusyWindow)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Time window is invalid, timeMax should be after timeMin and not later than 93 days"}`,
+		},
+		{
+			name:      "Not a Member",
+			inputBody: `{"userIds":[5],"timeMin":"2024-05-01T08:00:00Z","timeMax":"2024-05-01T18:00:00Z"}`,
+			request:   domain.FreeBusyRequest{UserIds: []int{5}, TimeMin: timeMin, TimeMax: timeMax},
+			mockBehavior: func(r *service_mocks.MockFreeBusy, request domain.FreeBusyRequest) {
+				r.EXPECT().GetFreeBusy(testActor, request).Return(nil, service.ErrUserNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"User is not found"}`,
+		},
+		{
+			name:                 "Invalid Request",
+			inputBody:            `{"userIds":[],"timeMin":"2024-05-01T08:00:00Z","timeMax":"2024-05-01T18:00:00Z"}`,
+			mockBehavior:         func(r *service_mocks.MockFreeBusy, request domain.FreeBusyRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			freeBusy := service_mocks.NewMockFreeBusy(c)
+			test.mockBehavior(freeBusy, test.request)
+
+			services := &service.Service{FreeBusy: freeBusy}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/freebusy", handler.GetFreeBusy)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/freebusy", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type GroupsResponse struct {
+	Data []domain.Group
+}
+
+type GroupMembersResponse struct {
+	Data []domain.GroupMember
+}
+
+// @Summary     Get groups
+// @Tags        Groups
+// @Description Get groups of active organization
+// @ID          get-groups
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} GroupsResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/groups/ [get]
+func (h *Handler) GetGroups(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Groups.GetAll(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-groups", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, GroupsResponse{result})
+}
+
+// @Summary     Create group
+// @Tags        Groups
+// @Description Create group in active organization (organization owners and admins only)
+// @ID          create-group
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.CreateGroupRequest true "Request"
+// @Success     201
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/groups/ [post]
+func (h *Handler) CreateGroup(ctx *gin.Context) {
+	var request domain.CreateGroupRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("create-group", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Groups.Create(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("create-group", err)
+		NewErrorResponse(ctx, groupErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{
+		"id": result,
+	})
+}
+
+// @Summary     Get group members
+// @Tags        Groups
+// @Description Get members of the group
+// @ID          get-group-members
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Group Id"
+// @Success     200     {object} GroupMembersResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/groups/{id}/members [get]
+func (h *Handler) GetGroupMembers(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	groupId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("get-group-members", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.Groups.GetMembers(actor, groupId)
+	if err != nil {
+		logger.LogHandlerIssue("get-group-members", err)
+		NewErrorResponse(ctx, groupErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, GroupMembersResponse{result})
+}
+
+// @Summary     Add group member
+// @Tags        Groups
+// @Description Add member of the organization to the group (organization owners and admins only)
+// @ID          add-group-member
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int                       true "Group Id"
+// @Param       input   body     domain.GroupMemberRequest true "Request"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/groups/{id}/members [post]
+func (h *Handler) AddGroupMember(ctx *gin.Context) {
+	var request domain.GroupMemberRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("add-group-member", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	groupId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("add-group-member", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	if err := h.services.Groups.AddMember(actor, groupId, request.UserId); err != nil {
+		logger.LogHandlerIssue("add-group-member", err)
+		NewErrorResponse(ctx, groupErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("User [id]:%d has been added to group [id]:%d", request.UserId, groupId),
+	})
+}
+
+// @Summary     Remove group member
+// @Tags        Groups
+// @Description Remove member from the group (organization owners and admins only)
+// @ID          remove-group-member
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Group Id"
+// @Param       userId  path     int          true "User Id"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/groups/{id}/members/{userId} [delete]
+func (h *Handler) RemoveGroupMember(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	groupId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("remove-group-member", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	userId, err := h.getUrlParam(ctx, "userId")
+	if err != nil {
+		logger.LogHandlerIssue("remove-group-member", errors.New("Invalid param in url: [userId]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [userId]")
+		return
+	}
+
+	if err := h.services.Groups.RemoveMember(actor, groupId, userId); err != nil {
+		logger.LogHandlerIssue("remove-group-member", err)
+		NewErrorResponse(ctx, groupErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("User [id]:%d has been removed from group [id]:%d", userId, groupId),
+	})
+}
+
+func groupErrorStatus(err error) int {
+	if errors.Is(err, service.ErrGroupNotFound) {
+		return http.StatusNotFound
+	}
+
+	return organizationErrorStatus(err)
+}
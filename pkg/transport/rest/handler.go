@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
 	"github.com/salesforceanton/events-api/pkg/service"
 
 	swaggerFiles "github.com/swaggo/files"
@@ -20,28 +21,183 @@ func NewHandler(services *service.Service) *Handler {
 	return &Handler{services: services}
 }
 
-func (h *Handler) InitRoutes() *gin.Engine {
+func (h *Handler) InitRoutes(trustedProxies []string) (*gin.Engine, error) {
 	router := gin.New()
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
+
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/.well-known/jwks.json", h.JWKS)
 
 	auth := router.Group("auth")
 	{
 		auth.POST("/sign-up", h.SignUp)
 		auth.POST("/sign-in", h.SignIn)
+		auth.POST("/sign-in/mfa", h.SignInMfa)
+		auth.POST("/mfa/enroll", h.EnrollMfaChallenge)
+		auth.POST("/verify-email", h.VerifyEmail)
+		auth.POST("/resend-verification", h.ResendVerification)
+		auth.POST("/forgot-password", h.ForgotPassword)
+		auth.POST("/reset-password", h.ResetPassword)
+		auth.GET("/oidc/login", h.OidcLogin)
+		auth.GET("/oidc/callback", h.OidcCallback)
+	}
+	public := router.Group("public", h.rateLimit)
+	{
+		public.GET("/events", h.GetPublicEvents)
+		public.GET("/events/:slug", h.GetPublicEvent)
+		public.GET("/booking/:slug", h.GetBookingPage)
+		public.GET("/booking/:slug/slots", h.GetBookingSlots)
+		public.POST("/booking/:slug", h.BookSlot)
 	}
 	api := router.Group("api", h.userIdentity)
 	{
-		events := api.Group("events")
+		mfa := api.Group("mfa", h.sessionOnly)
+		{
+			mfa.POST("/enroll", h.EnrollMfa)
+			mfa.POST("/confirm", h.ConfirmMfa)
+			mfa.POST("/disable", h.DisableMfa)
+		}
+
+		tokens := api.Group("tokens", h.sessionOnly)
+		{
+			tokens.GET("/", h.GetAccessTokens)
+			tokens.POST("/", h.CreateAccessToken)
+			tokens.DELETE("/:id", h.RevokeAccessToken)
+		}
+
+		organizations := api.Group("organizations", h.sessionOnly)
+		{
+			organizations.GET("/", h.GetOrganizations)
+			organizations.POST("/", h.CreateOrganization)
+			organizations.POST("/:id/switch", h.SwitchOrganization)
+			organizations.GET("/:id/members", h.GetOrganizationMembers)
+			organizations.POST("/:id/members", h.AddOrganizationMember)
+			organizations.DELETE("/:id/members/:userId", h.RemoveOrganizationMember)
+		}
+
+		groups := api.Group("groups", h.sessionOnly)
+		{
+			groups.GET("/", h.GetGroups)
+			groups.POST("/", h.CreateGroup)
+			groups.GET("/:id/members", h.GetGroupMembers)
+			groups.POST("/:id/members", h.AddGroupMember)
+			groups.DELETE("/:id/members/:userId", h.RemoveGroupMember)
+		}
+
+		resources := api.Group("resources", h.sessionOnly)
+		{
+			resources.GET("/", h.GetResources)
+			resources.POST("/", h.CreateResource)
+			resources.GET("/utilization", h.GetResourceUtilization)
+			resources.POST("/:id", h.UpdateResource)
+			resources.DELETE("/:id", h.DeleteResource)
+			resources.GET("/:id/availability", h.GetResourceAvailability)
+		}
+
+		tags := api.Group("tags", h.sessionOnly)
+		{
+			tags.GET("/", h.GetTags)
+			tags.POST("/", h.CreateTag)
+			tags.POST("/:id", h.UpdateTag)
+			tags.DELETE("/:id", h.DeleteTag)
+		}
+
+		customFields := api.Group("custom-fields", h.sessionOnly)
+		{
+			customFields.GET("/", h.GetCustomFields)
+			customFields.POST("/", h.CreateCustomField)
+			customFields.POST("/:id", h.UpdateCustomField)
+			customFields.DELETE("/:id", h.DeleteCustomField)
+		}
+
+		delegations := api.Group("delegations", h.sessionOnly)
+		{
+			delegations.GET("/", h.GetDelegations)
+			delegations.POST("/", h.SaveDelegation)
+			delegations.DELETE("/:id", h.DeleteDelegation)
+		}
+
+		api.GET("/audit", h.sessionOnly, h.GetAuditLog)
+
+		availability := api.Group("users/me/availability", h.sessionOnly)
+		{
+			availability.GET("/", h.GetAvailability)
+			availability.POST("/", h.SaveAvailability)
+			availability.POST("/out-of-office", h.AddOutOfOffice)
+			availability.DELETE("/out-of-office/:id", h.DeleteOutOfOffice)
+		}
+
+		reminders := api.Group("users/me/reminders", h.sessionOnly)
+		{
+			reminders.GET("/", h.GetReminderDefaults)
+			reminders.POST("/", h.SaveReminderDefaults)
+		}
+
+		digest := api.Group("users/me/digest", h.sessionOnly)
+		{
+			digest.GET("/", h.GetDigest)
+			digest.POST("/", h.SaveDigest)
+		}
+
+		notifications := api.Group("users/me/notifications", h.sessionOnly)
+		{
+			notifications.GET("/", h.GetNotificationPreferences)
+			notifications.POST("/", h.SaveNotificationPreferences)
+		}
+
+		read := h.requireScope(domain.SCOPE_EVENTS_READ)
+		write := h.requireScope(domain.SCOPE_EVENTS_WRITE)
+
+		calendars := api.Group("calendars", h.delegation)
+		{
+			calendars.GET("/", read, h.GetCalendars)
+			calendars.POST("/", write, h.CreateCalendar)
+			calendars.GET("/:id", read, h.GetCalendar)
+			calendars.POST("/:id", write, h.UpdateCalendar)
+			calendars.DELETE("/:id", write, h.DeleteCalendar)
+		}
+
+		api.POST("/freebusy", h.delegation, read, h.GetFreeBusy)
+		api.POST("/scheduling/suggest", h.delegation, read, h.SuggestSlots)
+
+		bookingPages := api.Group("booking-pages", h.delegation)
+		{
+			bookingPages.GET("/", read, h.GetBookingPages)
+			bookingPages.POST("/", write, h.CreateBookingPage)
+			bookingPages.GET("/:id", read, h.GetBookingPageById)
+			bookingPages.POST("/:id", write, h.UpdateBookingPage)
+			bookingPages.DELETE("/:id", write, h.DeleteBookingPage)
+		}
+
+		events := api.Group("events", h.delegation)
+		{
+			events.GET("/", read, h.GetAll)
+			events.POST("/", write, h.Create)
+			events.GET("/conflicts", read, h.GetConflicts)
+			events.GET("/nearby", read, h.GetNearby)
+			events.POST("/:id", write, h.Update)
+			events.GET("/:id", read, h.GetById)
+			events.DELETE("/:id", write, h.Delete)
+			events.POST("/:id/publish", write, h.PublishEvent)
+			events.POST("/:id/cancel", write, h.CancelEvent)
+			events.POST("/:id/reopen", write, h.ReopenEvent)
+			events.GET("/:id/grants", read, h.GetEventGrants)
+			events.POST("/:id/grants", write, h.SaveEventGrant)
+			events.DELETE("/:id/grants/:grantId", write, h.DeleteEventGrant)
+		}
+
+		admin := api.Group("admin", h.sessionOnly)
 		{
-			events.GET("/", h.GetAll)
-			events.POST("/", h.Create)
-			events.POST("/:id", h.Update)
-			events.GET("/:id", h.GetById)
-			events.DELETE("/:id", h.Delete)
+			admin.GET("/users", h.requirePermission(domain.PERMISSION_USERS_READ), h.AdminGetUsers)
+			admin.GET("/events", h.requirePermission(domain.PERMISSION_EVENTS_READ_ANY), h.AdminGetEvents)
+			admin.POST("/users/:id/role", h.requirePermission(domain.PERMISSION_USERS_MANAGE), h.AdminSetRole)
+			admin.POST("/users/:id/mfa-required", h.requirePermission(domain.PERMISSION_USERS_MANAGE), h.AdminSetMfaRequired)
 		}
 	}
 
-	return router
+	return router, nil
 }
 
 func (h *Handler) getUrlParam(ctx *gin.Context, param string) (int, error) {
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/pkg/logger"
+)
+
+// @Summary     JWKS
+// @Tags        Auth
+// @Description Public keys to verify access tokens, includes retired keys which are still valid
+// @ID          jwks
+// @Produce     json
+// @Success     200     {object} signing.JWKSet
+// @Failure     500     {object} ErrorResponse
+// @Router      /.well-known/jwks.json [get]
+func (h *Handler) JWKS(ctx *gin.Context) {
+	result, err := h.services.SigningKeys.JWKS()
+	if err != nil {
+		logger.LogHandlerIssue("jwks", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/salesforceanton/events-api/pkg/signing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_jwks(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockSigningKeys)
+
+	tests := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *service_mocks.MockSigningKeys) {
+				r.EXPECT().JWKS().Return(signing.JWKSet{Keys: []signing.JWK{
+					{Kty: "OKP", Kid: "kid1", Use: "sig", Alg: signing.ALG_EDDSA, Crv: "Ed25519", X: "key"},
+				}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"keys":[{"kty":"OKP","kid":"kid1","use":"sig","alg":"EdDSA","crv":"Ed25519","x":"key"}]}`,
+		},
+		{
+			name: "Service Error",
+			mockBehavior: func(r *service_mocks.MockSigningKeys) {
+				r.EXPECT().JWKS().Return(signing.JWKSet{}, errors.New("Something went wrong"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"Something went wrong"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			signingKeys := service_mocks.NewMockSigningKeys(c)
+			test.mockBehavior(signingKeys)
+
+			services := &service.Service{SigningKeys: signingKeys}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/.well-known/jwks.json", handler.JWKS)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type MfaChallengeInput struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type MfaEnrollChallengeInput struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+}
+
+type MfaCodeInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// @Summary     Login MFA step
+// @Tags        Auth
+// @Description Exchange MFA challenge token and verification or recovery code for access token
+// @ID          sign-in-mfa
+// @Accept      json
+// @Produce     json
+// @Param       input   body     MfaChallengeInput true "Challenge and code"
+// @Success     200     {string} string            "token"
+// @Failure     400,401 {object} ErrorResponse
+// @Failure     429     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /auth/sign-in/mfa [post]
+func (h *Handler) SignInMfa(ctx *gin.Context) {
+	var request MfaChallengeInput
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("sign-in-mfa", err)
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	username, err := h.services.Authorization.GetMfaChallengeUsername(request.ChallengeToken)
+	if err != nil {
+		logger.LogHandlerIssue("sign-in-mfa", err)
+		NewErrorResponse(ctx, mfaErrorStatus(err), err.Error())
+		return
+	}
+
+	ip := ctx.ClientIP()
+	if !h.loginAllowed(ctx, username, ip) {
+		return
+	}
+
+	token, err := h.services.Authorization.VerifyMfaChallenge(request.ChallengeToken, request.Code)
+	if errors.Is(err, service.ErrInvalidMfaCode) {
+		if err := h.services.LoginGuard.RegisterFailure(username, ip); err != nil {
+			logger.LogHandlerIssue("sign-in-mfa", err)
+		}
+	}
+	if err != nil {
+		logger.LogHandlerIssue("sign-in-mfa", err)
+		NewErrorResponse(ctx, mfaErrorStatus(err), err.Error())
+		return
+	}
+
+	if err := h.services.LoginGuard.RegisterSuccess(username, ip); err != nil {
+		logger.LogHandlerIssue("sign-in-mfa", err)
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{
+		"token": token,
+	})
+}
+
+// @Summary     MFA enrollment on login
+// @Tags        Auth
+// @Description Start MFA enrollment with challenge token for accounts which are required to use MFA
+// @ID          mfa-enroll-challenge
+// @Accept      json
+// @Produce     json
+// @Param       input   body     MfaEnrollChallengeInput true "Challenge"
+// @Success     200     {object} domain.MfaEnrollment
+// @Failure     400,401 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /auth/mfa/enroll [post]
+func (h *Handler) EnrollMfaChallenge(ctx *gin.Context) {
+	var request MfaEnrollChallengeInput
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("mfa-enroll-challenge", err)
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	result, err := h.services.Authorization.EnrollMfaWithChallenge(request.ChallengeToken)
+	if err != nil {
+		logger.LogHandlerIssue("mfa-enroll-challenge", err)
+		NewErrorResponse(ctx, mfaErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     MFA enrollment
+// @Tags        MFA
+// @Description Generate TOTP secret, otpauth URI and recovery codes for current User
+// @ID          mfa-enroll
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} domain.MfaEnrollment
+// @Failure     400,409 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/mfa/enroll [post]
+func (h *Handler) EnrollMfa(ctx *gin.Context) {
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Authorization.EnrollMfa(userId)
+	if err != nil {
+		logger.LogHandlerIssue("mfa-enroll", err)
+		NewErrorResponse(ctx, mfaErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     MFA confirmation
+// @Tags        MFA
+// @Description Enable MFA for current User with the first code from authenticator app
+// @ID          mfa-confirm
+// @Accept      json
+// @Produce     json
+// @Param       input   body     MfaCodeInput true "Verification code"
+// @Success     200
+// @Failure     400,409 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/mfa/confirm [post]
+func (h *Handler) ConfirmMfa(ctx *gin.Context) {
+	var request MfaCodeInput
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("mfa-confirm", err)
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.services.Authorization.ConfirmMfa(userId, request.Code); err != nil {
+		logger.LogHandlerIssue("mfa-confirm", err)
+		NewErrorResponse(ctx, mfaErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": "MFA has been enabled successfully",
+	})
+}
+
+// @Summary     MFA disabling
+// @Tags        MFA
+// @Description Disable MFA for current User with verification or recovery code
+// @ID          mfa-disable
+// @Accept      json
+// @Produce     json
+// @Param       input   body     MfaCodeInput true "Verification code"
+// @Success     200
+// @Failure     400,409 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/mfa/disable [post]
+func (h *Handler) DisableMfa(ctx *gin.Context) {
+	var request MfaCodeInput
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("mfa-disable", err)
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.services.Authorization.DisableMfa(userId, request.Code); err != nil {
+		logger.LogHandlerIssue("mfa-disable", err)
+		NewErrorResponse(ctx, mfaErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": "MFA has been disabled successfully",
+	})
+}
+
+func mfaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidMfaCode), errors.Is(err, service.ErrInvalidChallenge):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrMfaAlreadyEnabled),
+		errors.Is(err, service.ErrMfaNotEnabled),
+		errors.Is(err, service.ErrMfaNotEnrolled),
+		errors.Is(err, service.ErrMfaRequired):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_signInMfa(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, challenge, code string)
+
+	tests := []struct {
+		name                 string
+		challenge            string
+		code                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			challenge: "challenge_token",
+			code:      "123456",
+			inputBody: `{"challengeToken": "challenge_token", "code": "123456"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, challenge, code string) {
+				r.EXPECT().GetMfaChallengeUsername(challenge).Return("username", nil)
+				g.EXPECT().Check("username", testClientIp).Return(time.Duration(0), nil)
+				r.EXPECT().VerifyMfaChallenge(challenge, code).Return("test_token", nil)
+				g.EXPECT().RegisterSuccess("username", testClientIp).Return(nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"token":"test_token"}`,
+		},
+		{
+			name:      "Invalid Code",
+			challenge: "challenge_token",
+			code:      "000000",
+			inputBody: `{"challengeToken": "challenge_token", "code": "000000"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, challenge, code string) {
+				r.EXPECT().GetMfaChallengeUsername(challenge).Return("username", nil)
+				g.EXPECT().Check("username", testClientIp).Return(time.Duration(0), nil)
+				r.EXPECT().VerifyMfaChallenge(challenge, code).Return("", service.ErrInvalidMfaCode)
+				g.EXPECT().RegisterFailure("username", testClientIp).Return(nil)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"Verification code is invalid"}`,
+		},
+		{
+			name:      "Account Locked",
+			challenge: "challenge_token",
+			code:      "123456",
+			inputBody: `{"challengeToken": "challenge_token", "code": "123456"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, challenge, code string) {
+				r.EXPECT().GetMfaChallengeUsername(challenge).Return("username", nil)
+				g.EXPECT().Check("username", testClientIp).Return(15*time.Minute, nil)
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedResponseBody: `{"message":"Too many failed sign-in attempts, try again later"}`,
+		},
+		{
+			name:      "Invalid Challenge",
+			challenge: "expired_token",
+			code:      "123456",
+			inputBody: `{"challengeToken": "expired_token", "code": "123456"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, challenge, code string) {
+				r.EXPECT().GetMfaChallengeUsername(challenge).Return("", service.ErrInvalidChallenge)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"MFA challenge is invalid or expired"}`,
+		},
+		{
+			name:                 "Invalid request",
+			inputBody:            `{"challengeToken": "challenge_token"}`,
+			mockBehavior:         func(r *service_mocks.MockAuthorization, g *service_mocks.MockLoginGuard, challenge, code string) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			authService := service_mocks.NewMockAuthorization(c)
+			loginGuard := service_mocks.NewMockLoginGuard(c)
+			test.mockBehavior(authService, loginGuard, test.challenge, test.code)
+
+			services := &service.Service{Authorization: authService, LoginGuard: loginGuard}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/sign-in/mfa", handler.SignInMfa)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/sign-in/mfa", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_disableMfa(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAuthorization, userId int, code string)
+
+	tests := []struct {
+		name                 string
+		userId               int
+		code                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			userId:    1,
+			code:      "123456",
+			inputBody: `{"code": "123456"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, userId int, code string) {
+				r.EXPECT().DisableMfa(userId, code).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Status":"MFA has been disabled successfully"}`,
+		},
+		{
+			name:      "MFA Is Enforced",
+			userId:    1,
+			code:      "123456",
+			inputBody: `{"code": "123456"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization, userId int, code string) {
+				r.EXPECT().DisableMfa(userId, code).Return(service.ErrMfaRequired)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"MFA is required for this account and can not be disabled"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			authService := service_mocks.NewMockAuthorization(c)
+			test.mockBehavior(authService, test.userId, test.code)
+
+			services := &service.Service{Authorization: authService}
+			handler := Handler{services}
+
+			// Init Endpoint
+			gin.SetMode(gin.TestMode)
+
+			// Create mock context with user-id
+			resp := httptest.NewRecorder()
+			ctx, r := gin.CreateTestContext(resp)
+
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+			})
+
+			// Configure router
+			r.POST("/mfa/disable", handler.DisableMfa)
+
+			// Do request
+			ctx.Request, _ = http.NewRequest(http.MethodPost, "/mfa/disable", bytes.NewBufferString(test.inputBody))
+			r.ServeHTTP(resp, ctx.Request)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -3,16 +3,25 @@ package handler
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
 	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
 )
 
 const (
-	AUTH_HEADER = "Authorization"
-	USER_CTX    = "user_id"
+	AUTH_HEADER          = "Authorization"
+	ON_BEHALF_OF_HEADER  = "X-On-Behalf-Of"
+	USER_CTX             = "user_id"
+	SCOPES_CTX           = "scopes"
+	ORG_CTX              = "organization_id"
+	PRINCIPAL_CTX        = "principal_id"
+	DELEGATED_ACCESS_CTX = "delegated_access"
 )
 
 func (h *Handler) userIdentity(ctx *gin.Context) {
@@ -30,14 +39,127 @@ func (h *Handler) userIdentity(ctx *gin.Context) {
 		return
 	}
 
-	userId, err := h.services.Authorization.ParseToken(headerParts[1])
+	if service.IsAccessToken(headerParts[1]) {
+		accessToken, err := h.services.AccessTokens.Authenticate(headerParts[1])
+		if err != nil {
+			logger.LogHandlerIssue("user-identity", err)
+			NewErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		ctx.Set(USER_CTX, accessToken.UserId)
+		ctx.Set(ORG_CTX, accessToken.OrganizationId)
+		ctx.Set(SCOPES_CTX, accessToken.Scopes)
+		return
+	}
+
+	actor, err := h.services.Authorization.ParseToken(headerParts[1])
 	if err != nil {
 		logger.LogHandlerIssue("user-identity", errors.New(fmt.Sprintf("Access Token is invalid: %s", err.Error())))
 		NewErrorResponse(ctx, http.StatusInternalServerError, fmt.Sprintf("Access Token is invalid: %s", err.Error()))
 		return
 	}
 
-	ctx.Set(USER_CTX, userId)
+	ctx.Set(USER_CTX, actor.UserId)
+	ctx.Set(ORG_CTX, actor.OrganizationId)
+}
+
+// Requests authenticated with personal access token need the scope, session tokens have full access
+func (h *Handler) requireScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		scopes, ok := ctx.Get(SCOPES_CTX)
+		if !ok {
+			return
+		}
+
+		for _, granted := range scopes.([]string) {
+			if granted == scope {
+				return
+			}
+		}
+
+		logger.LogHandlerIssue("require-scope", fmt.Errorf("Access token does not have scope: %s", scope))
+		NewErrorResponse(ctx, http.StatusForbidden, fmt.Sprintf("Access token does not have required scope: %s", scope))
+	}
+}
+
+// Routes which need permission of user role, e.g. administration
+func (h *Handler) requirePermission(permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId, err := h.getUserContext(ctx)
+		if err != nil {
+			return
+		}
+
+		allowed, err := h.services.Policy.HasPermission(userId, permission)
+		if err != nil {
+			logger.LogHandlerIssue("require-permission", err)
+			NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if !allowed {
+			logger.LogHandlerIssue("require-permission", fmt.Errorf("User does not have permission: %s", permission))
+			NewErrorResponse(ctx, http.StatusForbidden, fmt.Sprintf("User does not have required permission: %s", permission))
+		}
+	}
+}
+
+// Delegate works with calendar of the principal given in header, delegation is checked for every request
+func (h *Handler) delegation(ctx *gin.Context) {
+	header := ctx.GetHeader(ON_BEHALF_OF_HEADER)
+	if header == "" {
+		return
+	}
+
+	principalId, err := strconv.Atoi(header)
+	if err != nil {
+		logger.LogHandlerIssue("delegation", fmt.Errorf("%s Header is invalid", ON_BEHALF_OF_HEADER))
+		NewErrorResponse(ctx, http.StatusBadRequest, fmt.Sprintf("%s Header is invalid", ON_BEHALF_OF_HEADER))
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		return
+	}
+
+	if principalId == actor.UserId {
+		return
+	}
+
+	access, err := h.services.Delegations.Access(actor, principalId)
+	if errors.Is(err, service.ErrDelegationNotFound) {
+		logger.LogHandlerIssue("delegation", err)
+		NewErrorResponse(ctx, http.StatusForbidden, fmt.Sprintf("Calendar of user [id]:%d is not delegated", principalId))
+		return
+	}
+	if err != nil {
+		logger.LogHandlerIssue("delegation", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.Set(PRINCIPAL_CTX, principalId)
+	ctx.Set(DELEGATED_ACCESS_CTX, access)
+}
+
+// Public routes are limited per client IP, rejected requests get Retry-After header
+func (h *Handler) rateLimit(ctx *gin.Context) {
+	retryAfter := h.services.RateLimit.Allow(ctx.ClientIP())
+	if retryAfter > 0 {
+		logger.LogHandlerIssue("rate-limit", fmt.Errorf("Rate limit exceeded for client: %s", ctx.ClientIP()))
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		NewErrorResponse(ctx, http.StatusTooManyRequests, "Too many requests, try again later")
+	}
+}
+
+// Routes which are not available with personal access tokens, e.g. security settings
+func (h *Handler) sessionOnly(ctx *gin.Context) {
+	if _, ok := ctx.Get(SCOPES_CTX); ok {
+		logger.LogHandlerIssue("session-only", errors.New("Personal access token is used for session-only route"))
+		NewErrorResponse(ctx, http.StatusForbidden, "Route is not available for personal access tokens")
+	}
 }
 
 func (h *Handler) getUserContext(ctx *gin.Context) (int, error) {
@@ -50,3 +172,26 @@ func (h *Handler) getUserContext(ctx *gin.Context) (int, error) {
 
 	return userId.(int), nil
 }
+
+// User with active organization of the request
+func (h *Handler) getActor(ctx *gin.Context) (domain.Actor, error) {
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		return domain.Actor{}, err
+	}
+
+	organizationId, ok := ctx.Get(ORG_CTX)
+	if !ok {
+		logger.LogHandlerIssue("api/events", errors.New("Organization id is not found"))
+		NewErrorResponse(ctx, http.StatusInternalServerError, "Organization id is not found")
+		return domain.Actor{}, errors.New("Organization id is not found")
+	}
+
+	actor := domain.Actor{UserId: userId, OrganizationId: organizationId.(int)}
+	if principalId, ok := ctx.Get(PRINCIPAL_CTX); ok {
+		actor.PrincipalId = principalId.(int)
+		actor.DelegatedAccess = ctx.GetString(DELEGATED_ACCESS_CTX)
+	}
+
+	return actor, nil
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_userIdentity(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(a *service_mocks.MockAuthorization, t *service_mocks.MockAccessTokens, token string)
+
+	tests := []struct {
+		name                 string
+		headerValue          string
+		token                string
+		requiredScope        string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:          "Session Token",
+			headerValue:   "Bearer session_token",
+			token:         "session_token",
+			requiredScope: domain.SCOPE_EVENTS_WRITE,
+			mockBehavior: func(a *service_mocks.MockAuthorization, t *service_mocks.MockAccessTokens, token string) {
+				a.EXPECT().ParseToken(token).Return(domain.Actor{UserId: 1, OrganizationId: 1}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1:1",
+		},
+		{
+			name:          "Access Token With Scope",
+			headerValue:   "Bearer eapi_token",
+			token:         "eapi_token",
+			requiredScope: domain.SCOPE_EVENTS_READ,
+			mockBehavior: func(a *service_mocks.MockAuthorization, t *service_mocks.MockAccessTokens, token string) {
+				t.EXPECT().Authenticate(token).Return(
+					domain.AccessToken{UserId: 2, OrganizationId: 3, Scopes: []string{domain.SCOPE_EVENTS_READ}}, nil,
+				)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "2:3",
+		},
+		{
+			name:          "Access Token Without Scope",
+			headerValue:   "Bearer eapi_token",
+			token:         "eapi_token",
+			requiredScope: domain.SCOPE_EVENTS_WRITE,
+			mockBehavior: func(a *service_mocks.MockAuthorization, t *service_mocks.MockAccessTokens, token string) {
+				t.EXPECT().Authenticate(token).Return(
+					domain.AccessToken{UserId: 2, Scopes: []string{domain.SCOPE_EVENTS_READ}}, nil,
+				)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"Access token does not have required scope: events:write"}`,
+		},
+		{
+			name:          "Revoked Access Token",
+			headerValue:   "Bearer eapi_token",
+			token:         "eapi_token",
+			requiredScope: domain.SCOPE_EVENTS_READ,
+			mockBehavior: func(a *service_mocks.MockAuthorization, t *service_mocks.MockAccessTokens, token string) {
+				t.EXPECT().Authenticate(token).Return(domain.AccessToken{}, service.ErrInvalidAccessToken)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"Personal access token is invalid, expired or revoked"}`,
+		},
+		{
+			name:          "Invalid Session Token",
+			headerValue:   "Bearer session_token",
+			token:         "session_token",
+			requiredScope: domain.SCOPE_EVENTS_READ,
+			mockBehavior: func(a *service_mocks.MockAuthorization, t *service_mocks.MockAccessTokens, token string) {
+				a.EXPECT().ParseToken(token).Return(domain.Actor{}, errors.New("Error with parsing Access Token"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"Access Token is invalid: Error with parsing Access Token"}`,
+		},
+		{
+			name:                 "Empty Header",
+			mockBehavior:         func(a *service_mocks.MockAuthorization, t *service_mocks.MockAccessTokens, token string) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"Authorization Header is empty"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			authService := service_mocks.NewMockAuthorization(c)
+			accessTokens := service_mocks.NewMockAccessTokens(c)
+			test.mockBehavior(authService, accessTokens, test.token)
+
+			services := &service.Service{Authorization: authService, AccessTokens: accessTokens}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/identity", handler.userIdentity, handler.requireScope(test.requiredScope), func(ctx *gin.Context) {
+				actor, _ := handler.getActor(ctx)
+				ctx.String(http.StatusOK, "%d:%d", actor.UserId, actor.OrganizationId)
+			})
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/identity", nil)
+			if test.headerValue != "" {
+				req.Header.Set(AUTH_HEADER, test.headerValue)
+			}
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_rateLimit(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(l *service_mocks.MockRateLimit, clientIp string)
+
+	tests := []struct {
+		name                 string
+		trustedProxies       []string
+		forwardedFor         string
+		clientIp             string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:         "Spoofed Forwarded Header",
+			forwardedFor: "203.0.113.7",
+			clientIp:     testClientIp,
+			mockBehavior: func(l *service_mocks.MockRateLimit, clientIp string) {
+				l.EXPECT().Allow(clientIp).Return(time.Second)
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedResponseBody: `{"message":"Too many requests, try again later"}`,
+		},
+		{
+			name:           "Trusted Proxy",
+			trustedProxies: []string{testClientIp},
+			forwardedFor:   "203.0.113.7",
+			clientIp:       "203.0.113.7",
+			mockBehavior: func(l *service_mocks.MockRateLimit, clientIp string) {
+				l.EXPECT().Allow(clientIp).Return(time.Second)
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedResponseBody: `{"message":"Too many requests, try again later"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			rateLimit := service_mocks.NewMockRateLimit(c)
+			test.mockBehavior(rateLimit, test.clientIp)
+
+			services := &service.Service{RateLimit: rateLimit}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r, err := handler.InitRoutes(test.trustedProxies)
+			assert.NoError(t, err)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/public/events", nil)
+			req.RemoteAddr = testClientIp + ":51234"
+			req.Header.Set("X-Forwarded-For", test.forwardedFor)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+// @Summary     Get notification preferences
+// @Tags        Notifications
+// @Description Get email notification preferences of current User about changed and cancelled events
+// @ID          get-notification-preferences
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} domain.NotificationPreferences
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/users/me/notifications [get]
+func (h *Handler) GetNotificationPreferences(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Notifications.GetPreferences(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-notification-preferences", err)
+		NewErrorResponse(ctx, notificationsErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Save notification preferences
+// @Tags        Notifications
+// @Description Choose whether current User gets invitations and updates of events and cancellations of events by email
+// @ID          save-notification-preferences
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.NotificationPreferences true "Request"
+// @Success     200     {object} domain.NotificationPreferences
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/users/me/notifications [post]
+func (h *Handler) SaveNotificationPreferences(ctx *gin.Context) {
+	var request domain.NotificationPreferences
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("save-notification-preferences", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Notifications.SavePreferences(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("save-notification-preferences", err)
+		NewErrorResponse(ctx, notificationsErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func notificationsErrorStatus(err error) int {
+	if errors.Is(err, service.ErrUserNotFound) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
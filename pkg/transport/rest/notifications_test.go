@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_saveNotificationPreferences(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockNotifications, request domain.NotificationPreferences)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.NotificationPreferences
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"eventUpdates":false,"eventCancellations":true}`,
+			request:   domain.NotificationPreferences{EventUpdates: false, EventCancellations: true},
+			mockBehavior: func(r *service_mocks.MockNotifications, request domain.NotificationPreferences) {
+				r.EXPECT().SavePreferences(testActor, request).Return(
+					domain.NotificationPreferences{UserId: 1, EventUpdates: false, EventCancellations: true}, nil,
+				)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"eventUpdates":false,"eventCancellations":true}`,
+		},
+		{
+			name:                 "Invalid Body",
+			inputBody:            `{"eventUpdates":"no"}`,
+			mockBehavior:         func(r *service_mocks.MockNotifications, request domain.NotificationPreferences) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+		{
+			name:      "Service Failure",
+			inputBody: `{"eventUpdates":true,"eventCancellations":true}`,
+			request:   domain.NotificationPreferences{EventUpdates: true, EventCancellations: true},
+			mockBehavior: func(r *service_mocks.MockNotifications, request domain.NotificationPreferences) {
+				r.EXPECT().SavePreferences(testActor, request).Return(
+					domain.NotificationPreferences{}, errors.New("Something went wrong"),
+				)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"Something went wrong"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			notifications := service_mocks.NewMockNotifications(c)
+			test.mockBehavior(notifications, test.request)
+
+			services := &service.Service{Notifications: notifications}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/notifications", handler.SaveNotificationPreferences)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/notifications", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/oidc"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+// Login state is bound to the browser with cookie, so callback started in another browser is rejected
+const OIDC_STATE_COOKIE = "oidc_state"
+
+// @Summary     OpenID Connect login
+// @Tags        Auth
+// @Description Redirect to external identity provider to start sign-in
+// @ID          oidc-login
+// @Success     302
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /auth/oidc/login [get]
+func (h *Handler) OidcLogin(ctx *gin.Context) {
+	url, state, err := h.services.Oidc.LoginUrl()
+	if err != nil {
+		logger.LogHandlerIssue("oidc-login", err)
+		NewErrorResponse(ctx, oidcErrorStatus(err), err.Error())
+		return
+	}
+
+	setOidcStateCookie(ctx, oidcStateHash(state), int(service.OIDC_LOGIN_STATE_TTL.Seconds()))
+	ctx.Redirect(http.StatusFound, url)
+}
+
+// @Summary     OpenID Connect callback
+// @Tags        Auth
+// @Description Complete sign-in with authorization code returned by identity provider. Users with MFA receive challenge token for the second step
+// @ID          oidc-callback
+// @Produce     json
+// @Param       code    query    string true "Authorization code"
+// @Param       state   query    string true "Login state"
+// @Success     200     {object} domain.SignInResult
+// @Failure     400,401 {object} ErrorResponse
+// @Failure     403,409 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /auth/oidc/callback [get]
+func (h *Handler) OidcCallback(ctx *gin.Context) {
+	if providerError := ctx.Query("error"); providerError != "" {
+		message := ctx.Query("error_description")
+		if message == "" {
+			message = providerError
+		}
+		NewErrorResponse(ctx, http.StatusBadRequest, message)
+		return
+	}
+
+	code, state := ctx.Query("code"), ctx.Query("state")
+	if code == "" || state == "" {
+		NewErrorResponse(ctx, http.StatusBadRequest, "Code and state are required")
+		return
+	}
+
+	cookie, _ := ctx.Cookie(OIDC_STATE_COOKIE)
+	setOidcStateCookie(ctx, "", -1)
+	if subtle.ConstantTimeCompare([]byte(cookie), []byte(oidcStateHash(state))) != 1 {
+		logger.LogHandlerIssue("oidc-callback", errors.New("Login state does not match state cookie"))
+		NewErrorResponse(ctx, http.StatusBadRequest, service.ErrOidcInvalidState.Error())
+		return
+	}
+
+	result, err := h.services.Oidc.Callback(code, state)
+	if err != nil {
+		logger.LogHandlerIssue("oidc-callback", err)
+		NewErrorResponse(ctx, oidcErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+// Lax mode is required as provider redirects back with top-level cross-site navigation
+func setOidcStateCookie(ctx *gin.Context, value string, maxAge int) {
+	secure := ctx.Request.TLS != nil || ctx.GetHeader("X-Forwarded-Proto") == "https"
+
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(OIDC_STATE_COOKIE, value, maxAge, "/auth/oidc", "", secure, true)
+}
+
+func oidcStateHash(state string) string {
+	hash := sha256.Sum256([]byte(state))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func oidcErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrOidcNotConfigured):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrOidcInvalidState):
+		return http.StatusBadRequest
+	case errors.Is(err, oidc.ErrInvalidIDToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrOidcNotProvisioned):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrOidcAccountConflict), errors.Is(err, service.ErrOidcUsernameTaken):
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_oidcLogin(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockOidc)
+
+	tests := []struct {
+		name               string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+		expectedLocation   string
+		expectedCookie     string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *service_mocks.MockOidc) {
+				r.EXPECT().LoginUrl().Return("https://idp.example.com/authorize?state=state", "state", nil)
+			},
+			expectedStatusCode: http.StatusFound,
+			expectedLocation:   "https://idp.example.com/authorize?state=state",
+			expectedCookie:     oidcStateHash("state"),
+		},
+		{
+			name: "Not Configured",
+			mockBehavior: func(r *service_mocks.MockOidc) {
+				r.EXPECT().LoginUrl().Return("", "", service.ErrOidcNotConfigured)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			oidc := service_mocks.NewMockOidc(c)
+			test.mockBehavior(oidc)
+
+			services := &service.Service{Oidc: oidc}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/oidc/login", handler.OidcLogin)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/oidc/login", nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedLocation, resp.Header().Get("Location"))
+
+			var stateCookie string
+			for _, cookie := range resp.Result().Cookies() {
+				if cookie.Name == OIDC_STATE_COOKIE {
+					assert.True(t, cookie.HttpOnly)
+					assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+					stateCookie = cookie.Value
+				}
+			}
+			assert.Equal(t, test.expectedCookie, stateCookie)
+		})
+	}
+}
+
+func TestHandler_oidcCallback(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockOidc)
+
+	tests := []struct {
+		name                 string
+		query                string
+		stateCookie          string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			query:       "?code=code&state=state",
+			stateCookie: oidcStateHash("state"),
+			mockBehavior: func(r *service_mocks.MockOidc) {
+				r.EXPECT().Callback("code", "state").Return(domain.SignInResult{Token: "token"}, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"token":"token"}`,
+		},
+		{
+			name:                 "Provider Error",
+			query:                "?error=access_denied&error_description=User+denied+access&state=state",
+			mockBehavior:         func(r *service_mocks.MockOidc) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"User denied access"}`,
+		},
+		{
+			name:                 "Missing Code",
+			query:                "?state=state",
+			mockBehavior:         func(r *service_mocks.MockOidc) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Code and state are required"}`,
+		},
+		{
+			name:                 "Missing State Cookie",
+			query:                "?code=code&state=state",
+			mockBehavior:         func(r *service_mocks.MockOidc) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Login state is invalid or expired"}`,
+		},
+		{
+			name:                 "State Cookie Mismatch",
+			query:                "?code=code&state=state",
+			stateCookie:          oidcStateHash("another_state"),
+			mockBehavior:         func(r *service_mocks.MockOidc) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Login state is invalid or expired"}`,
+		},
+		{
+			name:        "Invalid State",
+			query:       "?code=code&state=state",
+			stateCookie: oidcStateHash("state"),
+			mockBehavior: func(r *service_mocks.MockOidc) {
+				r.EXPECT().Callback("code", "state").Return(domain.SignInResult{}, service.ErrOidcInvalidState)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Login state is invalid or expired"}`,
+		},
+		{
+			name:        "Account Conflict",
+			query:       "?code=code&state=state",
+			stateCookie: oidcStateHash("state"),
+			mockBehavior: func(r *service_mocks.MockOidc) {
+				r.EXPECT().Callback("code", "state").Return(domain.SignInResult{}, service.ErrOidcAccountConflict)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"Account with this email already exists and provider has not verified the email"}`,
+		},
+		{
+			name:        "Username Taken",
+			query:       "?code=code&state=state",
+			stateCookie: oidcStateHash("state"),
+			mockBehavior: func(r *service_mocks.MockOidc) {
+				r.EXPECT().Callback("code", "state").Return(domain.SignInResult{}, service.ErrOidcUsernameTaken)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"Username from identity provider is already taken"}`,
+		},
+		{
+			name:        "Service Error",
+			query:       "?code=code&state=state",
+			stateCookie: oidcStateHash("state"),
+			mockBehavior: func(r *service_mocks.MockOidc) {
+				r.EXPECT().Callback("code", "state").Return(domain.SignInResult{}, errors.New("Something went wrong"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"Something went wrong"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			oidc := service_mocks.NewMockOidc(c)
+			test.mockBehavior(oidc)
+
+			services := &service.Service{Oidc: oidc}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/oidc/callback", handler.OidcCallback)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/oidc/callback"+test.query, nil)
+			if test.stateCookie != "" {
+				req.AddCookie(&http.Cookie{Name: OIDC_STATE_COOKIE, Value: test.stateCookie})
+			}
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type OrganizationsResponse struct {
+	Data []domain.Organization
+}
+
+type MembersResponse struct {
+	Data []domain.OrganizationMember
+}
+
+// @Summary     Get organizations
+// @Tags        Organizations
+// @Description Get organizations of current User with User role in each of them
+// @ID          get-organizations
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} OrganizationsResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/organizations/ [get]
+func (h *Handler) GetOrganizations(ctx *gin.Context) {
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Organizations.GetAll(userId)
+	if err != nil {
+		logger.LogHandlerIssue("get-organizations", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, OrganizationsResponse{result})
+}
+
+// @Summary     Create organization
+// @Tags        Organizations
+// @Description Create organization with current User as owner
+// @ID          create-organization
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.CreateOrganizationRequest true "Request"
+// @Success     201
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/organizations/ [post]
+func (h *Handler) CreateOrganization(ctx *gin.Context) {
+	var request domain.CreateOrganizationRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("create-organization", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Organizations.Create(userId, request)
+	if err != nil {
+		logger.LogHandlerIssue("create-organization", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{
+		"id": result,
+	})
+}
+
+// @Summary     Switch organization
+// @Tags        Organizations
+// @Description Issue access token with defined organization as active one
+// @ID          switch-organization
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Organization Id"
+// @Success     200     {object} domain.SignInResult
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/organizations/{id}/switch [post]
+func (h *Handler) SwitchOrganization(ctx *gin.Context) {
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	organizationId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("switch-organization", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	token, err := h.services.Authorization.SwitchOrganization(userId, organizationId)
+	if err != nil {
+		logger.LogHandlerIssue("switch-organization", err)
+		NewErrorResponse(ctx, organizationErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domain.SignInResult{Token: token})
+}
+
+// @Summary     Get members
+// @Tags        Organizations
+// @Description Get members of organization which current User belongs to
+// @ID          get-organization-members
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Organization Id"
+// @Success     200     {object} MembersResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/organizations/{id}/members [get]
+func (h *Handler) GetOrganizationMembers(ctx *gin.Context) {
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	organizationId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("get-organization-members", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.Organizations.GetMembers(userId, organizationId)
+	if err != nil {
+		logger.LogHandlerIssue("get-organization-members", err)
+		NewErrorResponse(ctx, organizationErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, MembersResponse{result})
+}
+
+// @Summary     Add member
+// @Tags        Organizations
+// @Description Add registered User to organization or change role of existing member (owners and admins only)
+// @ID          add-organization-member
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int                     true "Organization Id"
+// @Param       input   body     domain.AddMemberRequest true "Request"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/organizations/{id}/members [post]
+func (h *Handler) AddOrganizationMember(ctx *gin.Context) {
+	var request domain.AddMemberRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("add-organization-member", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	organizationId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("add-organization-member", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	if err := h.services.Organizations.AddMember(userId, organizationId, request); err != nil {
+		logger.LogHandlerIssue("add-organization-member", err)
+		NewErrorResponse(ctx, organizationErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Member has been saved to organization [id]:%d successfully", organizationId),
+	})
+}
+
+// @Summary     Remove member
+// @Tags        Organizations
+// @Description Remove member from organization, members can also leave organization themselves
+// @ID          remove-organization-member
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Organization Id"
+// @Param       userId  path     int          true "User Id"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/organizations/{id}/members/{userId} [delete]
+func (h *Handler) RemoveOrganizationMember(ctx *gin.Context) {
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	organizationId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("remove-organization-member", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	memberId, err := h.getUrlParam(ctx, "userId")
+	if err != nil {
+		logger.LogHandlerIssue("remove-organization-member", errors.New("Invalid param in url: [userId]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [userId]")
+		return
+	}
+
+	if err := h.services.Organizations.RemoveMember(userId, organizationId, memberId); err != nil {
+		logger.LogHandlerIssue("remove-organization-member", err)
+		NewErrorResponse(ctx, organizationErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Member [id]:%d has been removed from organization [id]:%d", memberId, organizationId),
+	})
+}
+
+func organizationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUnknownOrgRole), errors.Is(err, service.ErrOwnerRemoval):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrOrganizationNotFound),
+		errors.Is(err, service.ErrMemberNotFound),
+		errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
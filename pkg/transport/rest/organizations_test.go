@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_switchOrganization(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAuthorization)
+
+	tests := []struct {
+		name                 string
+		organizationId       string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:           "Ok",
+			organizationId: "2",
+			mockBehavior: func(r *service_mocks.MockAuthorization) {
+				r.EXPECT().SwitchOrganization(1, 2).Return("token", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"token":"token"}`,
+		},
+		{
+			name:           "Not A Member",
+			organizationId: "3",
+			mockBehavior: func(r *service_mocks.MockAuthorization) {
+				r.EXPECT().SwitchOrganization(1, 3).Return("", service.ErrOrganizationNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"Organization is not found"}`,
+		},
+		{
+			name:                 "Invalid Id",
+			organizationId:       "abc",
+			mockBehavior:         func(r *service_mocks.MockAuthorization) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Invalid param in url: [id]"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := service_mocks.NewMockAuthorization(c)
+			test.mockBehavior(auth)
+
+			services := &service.Service{Authorization: auth}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+			})
+			r.POST("/organizations/:id/switch", handler.SwitchOrganization)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/organizations/"+test.organizationId+"/switch", nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_addOrganizationMember(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockOrganizations)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"email":"colleague@example.com","role":"admin"}`,
+			mockBehavior: func(r *service_mocks.MockOrganizations) {
+				r.EXPECT().AddMember(1, 2, domain.AddMemberRequest{Email: "colleague@example.com", Role: domain.ORG_ROLE_ADMIN}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Status":"Member has been saved to organization [id]:2 successfully"}`,
+		},
+		{
+			name:      "Not A Manager",
+			inputBody: `{"email":"colleague@example.com"}`,
+			mockBehavior: func(r *service_mocks.MockOrganizations) {
+				r.EXPECT().AddMember(1, 2, domain.AddMemberRequest{Email: "colleague@example.com"}).Return(service.ErrForbidden)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"Not enough permissions for this operation"}`,
+		},
+		{
+			name:      "Unknown User",
+			inputBody: `{"email":"nobody@example.com"}`,
+			mockBehavior: func(r *service_mocks.MockOrganizations) {
+				r.EXPECT().AddMember(1, 2, domain.AddMemberRequest{Email: "nobody@example.com"}).Return(service.ErrUserNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"User is not found"}`,
+		},
+		{
+			name:      "Service Error",
+			inputBody: `{"email":"colleague@example.com"}`,
+			mockBehavior: func(r *service_mocks.MockOrganizations) {
+				r.EXPECT().AddMember(1, 2, domain.AddMemberRequest{Email: "colleague@example.com"}).Return(errors.New("Something went wrong"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"Something went wrong"}`,
+		},
+		{
+			name:                 "Invalid Email",
+			inputBody:            `{"email":"colleague"}`,
+			mockBehavior:         func(r *service_mocks.MockOrganizations) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			organizations := service_mocks.NewMockOrganizations(c)
+			test.mockBehavior(organizations)
+
+			services := &service.Service{Organizations: organizations}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+			})
+			r.POST("/organizations/:id/members", handler.AddOrganizationMember)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/organizations/2/members", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type PublicEventsResponse struct {
+	Data []domain.PublicEvent
+}
+
+// @Summary     Get public events
+// @Tags        Public
+// @Description Get upcoming public events, authentication is not required
+// @ID          get-public-events
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} PublicEventsResponse
+// @Failure     429     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /public/events [get]
+func (h *Handler) GetPublicEvents(ctx *gin.Context) {
+	result, err := h.services.PublicEvents.GetAll()
+	if err != nil {
+		logger.LogHandlerIssue("get-public-events", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, PublicEventsResponse{result})
+}
+
+// @Summary     Get public event
+// @Tags        Public
+// @Description Get public or unlisted event by slug, authentication is not required
+// @ID          get-public-event
+// @Accept      json
+// @Produce     json
+// @Param       slug    path     string       true "Event Slug"
+// @Success     200     {object} domain.PublicEvent
+// @Failure     404,429 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /public/events/{slug} [get]
+func (h *Handler) GetPublicEvent(ctx *gin.Context) {
+	result, err := h.services.PublicEvents.GetBySlug(ctx.Param("slug"))
+	if errors.Is(err, service.ErrEventNotFound) {
+		logger.LogHandlerIssue("get-public-event", err)
+		NewErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		logger.LogHandlerIssue("get-public-event", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
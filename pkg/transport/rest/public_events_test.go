@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_getPublicEvent(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(e *service_mocks.MockPublicEvents, l *service_mocks.MockRateLimit, slug string)
+
+	tests := []struct {
+		name                 string
+		slug                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedRetryAfter   string
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			slug: "go-meetup-1a2b3c4d",
+			mockBehavior: func(e *service_mocks.MockPublicEvents, l *service_mocks.MockRateLimit, slug string) {
+				l.EXPECT().Allow(gomock.Any()).Return(time.Duration(0))
+				e.EXPECT().GetBySlug(slug).Return(domain.PublicEvent{
+					Slug:          slug,
+					Title:         "Go meetup",
+					StartDatetime: "2024-05-01T18:00:00Z",
+					TimezoneId:    "Europe/Berlin",
+					Description:   "Talks and pizza",
+					Status:        domain.EVENT_STATUS_PUBLISHED,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"slug":"go-meetup-1a2b3c4d","title":"Go meetup","startDatetime":"2024-05-01T18:00:00Z",` +
+				`"timezoneId":"Europe/Berlin","description":"Talks and pizza","status":"published"}`,
+		},
+		{
+			name: "Cancelled Event",
+			slug: "go-meetup-1a2b3c4d",
+			mockBehavior: func(e *service_mocks.MockPublicEvents, l *service_mocks.MockRateLimit, slug string) {
+				l.EXPECT().Allow(gomock.Any()).Return(time.Duration(0))
+				e.EXPECT().GetBySlug(slug).Return(domain.PublicEvent{
+					Slug:               slug,
+					Title:              "Go meetup",
+					StartDatetime:      "2024-05-01T18:00:00Z",
+					TimezoneId:         "Europe/Berlin",
+					Status:             domain.EVENT_STATUS_CANCELLED,
+					CancellationReason: "Speaker is sick",
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"slug":"go-meetup-1a2b3c4d","title":"Go meetup","startDatetime":"2024-05-01T18:00:00Z",` +
+				`"timezoneId":"Europe/Berlin","description":"","status":"cancelled","cancellationReason":"Speaker is sick"}`,
+		},
+		{
+			name: "Private Event",
+			slug: "team-sync-1a2b3c4d",
+			mockBehavior: func(e *service_mocks.MockPublicEvents, l *service_mocks.MockRateLimit, slug string) {
+				l.EXPECT().Allow(gomock.Any()).Return(time.Duration(0))
+				e.EXPECT().GetBySlug(slug).Return(domain.PublicEvent{}, service.ErrEventNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"Event is not found"}`,
+		},
+		{
+			name: "Rate Limited",
+			slug: "go-meetup-1a2b3c4d",
+			mockBehavior: func(e *service_mocks.MockPublicEvents, l *service_mocks.MockRateLimit, slug string) {
+				l.EXPECT().Allow(gomock.Any()).Return(1500 * time.Millisecond)
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedRetryAfter:   "2",
+			expectedResponseBody: `{"message":"Too many requests, try again later"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			publicEvents := service_mocks.NewMockPublicEvents(c)
+			rateLimit := service_mocks.NewMockRateLimit(c)
+			test.mockBehavior(publicEvents, rateLimit, test.slug)
+
+			services := &service.Service{PublicEvents: publicEvents, RateLimit: rateLimit}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/public/events/:slug", handler.rateLimit, handler.GetPublicEvent)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/public/events/"+test.slug, nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedRetryAfter, resp.Header().Get("Retry-After"))
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+)
+
+// @Summary     Get default reminders
+// @Tags        Reminders
+// @Description Get default reminders of current User in minutes before start, they are used for events without own reminders
+// @ID          get-reminder-defaults
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} domain.ReminderDefaults
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/users/me/reminders [get]
+func (h *Handler) GetReminderDefaults(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Reminders.GetDefaults(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-reminder-defaults", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Save default reminders
+// @Tags        Reminders
+// @Description Replace default reminders of current User, at most 5 reminders from 0 to 10080 minutes before start
+// @ID          save-reminder-defaults
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.ReminderDefaults true "Request"
+// @Success     200     {object} domain.ReminderDefaults
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/users/me/reminders [post]
+func (h *Handler) SaveReminderDefaults(ctx *gin.Context) {
+	var request domain.ReminderDefaults
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("save-reminder-defaults", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Reminders.SaveDefaults(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("save-reminder-defaults", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
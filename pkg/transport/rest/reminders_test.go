@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_saveReminderDefaults(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockReminders, request domain.ReminderDefaults)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.ReminderDefaults
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"minutes":[60,15,15]}`,
+			request:   domain.ReminderDefaults{Minutes: []int{60, 15, 15}},
+			mockBehavior: func(r *service_mocks.MockReminders, request domain.ReminderDefaults) {
+				r.EXPECT().SaveDefaults(testActor, request).Return(domain.ReminderDefaults{Minutes: []int{15, 60}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"minutes":[15,60]}`,
+		},
+		{
+			name:                 "Too Early",
+			inputBody:            `{"minutes":[10081]}`,
+			mockBehavior:         func(r *service_mocks.MockReminders, request domain.ReminderDefaults) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+		{
+			name:                 "Too Many",
+			inputBody:            `{"minutes":[1,2,3,4,5,6]}`,
+			mockBehavior:         func(r *service_mocks.MockReminders, request domain.ReminderDefaults) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+		{
+			name:      "Service Failure",
+			inputBody: `{"minutes":[]}`,
+			request:   domain.ReminderDefaults{Minutes: []int{}},
+			mockBehavior: func(r *service_mocks.MockReminders, request domain.ReminderDefaults) {
+				r.EXPECT().SaveDefaults(testActor, request).Return(domain.ReminderDefaults{}, errors.New("Something went wrong"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"Something went wrong"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			reminders := service_mocks.NewMockReminders(c)
+			test.mockBehavior(reminders, test.request)
+
+			services := &service.Service{Reminders: reminders}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/reminders", handler.SaveReminderDefaults)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/reminders", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
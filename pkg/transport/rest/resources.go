@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type ResourcesResponse struct {
+	Data []domain.Resource
+}
+
+type ResourceUtilizationResponse struct {
+	Data []domain.ResourceUtilization
+}
+
+// @Summary     Get resources
+// @Tags        Resources
+// @Description Get meeting rooms and equipment of active organization
+// @ID          get-resources
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} ResourcesResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/resources/ [get]
+func (h *Handler) GetResources(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Resources.GetAll(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-resources", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ResourcesResponse{result})
+}
+
+// @Summary     Create resource
+// @Tags        Resources
+// @Description Create resource in active organization (organization owners and admins only)
+// @ID          create-resource
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.SaveResourceRequest true "Request"
+// @Success     201
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/resources/ [post]
+func (h *Handler) CreateResource(ctx *gin.Context) {
+	var request domain.SaveResourceRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("create-resource", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Resources.Create(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("create-resource", err)
+		NewErrorResponse(ctx, resourceErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{
+		"id": result,
+	})
+}
+
+// @Summary     Update resource
+// @Tags        Resources
+// @Description Update resource of active organization (organization owners and admins only)
+// @ID          update-resource
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int                        true "Resource Id"
+// @Param       input   body     domain.SaveResourceRequest true "Request"
+// @Success     200     {object} domain.Resource
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/resources/{id} [post]
+func (h *Handler) UpdateResource(ctx *gin.Context) {
+	var request domain.SaveResourceRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("update-resource", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resourceId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("update-resource", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.Resources.Update(actor, resourceId, request)
+	if err != nil {
+		logger.LogHandlerIssue("update-resource", err)
+		NewErrorResponse(ctx, resourceErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Delete resource
+// @Tags        Resources
+// @Description Delete resource of active organization with its reservations (organization owners and admins only)
+// @ID          delete-resource
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Resource Id"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/resources/{id} [delete]
+func (h *Handler) DeleteResource(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resourceId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("delete-resource", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	if err := h.services.Resources.Delete(actor, resourceId); err != nil {
+		logger.LogHandlerIssue("delete-resource", err)
+		NewErrorResponse(ctx, resourceErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Resource record [id]:%d has been deleted successfully", resourceId),
+	})
+}
+
+// @Summary     Get resource availability
+// @Tags        Resources
+// @Description Get reserved intervals of the resource in the time range, up to 93 days
+// @ID          get-resource-availability
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Resource Id"
+// @Param       from    query    string       true "Range start, RFC3339"
+// @Param       to      query    string       true "Range end, RFC3339"
+// @Success     200     {object} domain.ResourceAvailability
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/resources/{id}/availability [get]
+func (h *Handler) GetResourceAvailability(ctx *gin.Context) {
+	var filter domain.TimeRangeFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		logger.LogHandlerIssue("get-resource-availability", errors.New("Invalid query params"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid query params")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resourceId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("get-resource-availability", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.Resources.GetAvailability(actor, resourceId, filter)
+	if err != nil {
+		logger.LogHandlerIssue("get-resource-availability", err)
+		NewErrorResponse(ctx, resourceErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Get resource utilization
+// @Tags        Resources
+// @Description Get reserved minutes and share of the time range for each resource of active organization
+// @ID          get-resource-utilization
+// @Accept      json
+// @Produce     json
+// @Param       from    query    string       true "Range start, RFC3339"
+// @Param       to      query    string       true "Range end, RFC3339"
+// @Success     200     {object} ResourceUtilizationResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/resources/utilization [get]
+func (h *Handler) GetResourceUtilization(ctx *gin.Context) {
+	var filter domain.TimeRangeFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		logger.LogHandlerIssue("get-resource-utilization", errors.New("Invalid query params"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid query params")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Resources.GetUtilization(actor, filter)
+	if err != nil {
+		logger.LogHandlerIssue("get-resource-utilization", err)
+		NewErrorResponse(ctx, resourceErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ResourceUtilizationResponse{result})
+}
+
+func resourceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrResourceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidTimeRange):
+		return http.StatusBadRequest
+	}
+
+	return organizationErrorStatus(err)
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createResource(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockResources, request domain.SaveResourceRequest)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.SaveResourceRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"name":"Room A","capacity":8,"location":"2nd floor"}`,
+			request:   domain.SaveResourceRequest{Name: "Room A", Capacity: 8, Location: "2nd floor"},
+			mockBehavior: func(r *service_mocks.MockResources, request domain.SaveResourceRequest) {
+				r.EXPECT().Create(testActor, request).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:      "Not Manager",
+			inputBody: `{"name":"Projector"}`,
+			request:   domain.SaveResourceRequest{Name: "Projector"},
+			mockBehavior: func(r *service_mocks.MockResources, request domain.SaveResourceRequest) {
+				r.EXPECT().Create(testActor, request).Return(0, service.ErrForbidden)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"Not enough permissions for this operation"}`,
+		},
+		{
+			name:                 "Negative Capacity",
+			inputBody:            `{"name":"Room A","capacity":-1}`,
+			mockBehavior:         func(r *service_mocks.MockResources, request domain.SaveResourceRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			resources := service_mocks.NewMockResources(c)
+			test.mockBehavior(resources, test.request)
+
+			services := &service.Service{Resources: resources}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/resources", handler.CreateResource)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/resources", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_getResourceAvailability(t *testing.T) {
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockResources)
+
+	tests := []struct {
+		name                 string
+		resourceId           string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Ok",
+			resourceId: "2",
+			query:      "?from=2024-03-04T00:00:00Z&to=2024-03-05T00:00:00Z",
+			mockBehavior: func(r *service_mocks.MockResources) {
+				r.EXPECT().GetAvailability(testActor, 2, domain.TimeRangeFilter{From: from, To: to}).Return(
+					domain.ResourceAvailability{
+						ResourceId: 2,
+						Busy:       []domain.BusyInterval{{Start: from.Add(9 * time.Hour), End: from.Add(10 * time.Hour)}},
+					}, nil,
+				)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"resourceId":2,"busy":[{"start":"2024-03-04T09:00:00Z",` +
+				`"end":"2024-03-04T10:00:00Z"}]}`,
+		},
+		{
+			name:       "Not Found",
+			resourceId: "3",
+			query:      "?from=2024-03-04T00:00:00Z&to=2024-03-05T00:00:00Z",
+			mockBehavior: func(r *service_mocks.MockResources) {
+				r.EXPECT().GetAvailability(testActor, 3, domain.TimeRangeFilter{From: from, To: to}).Return(
+					domain.ResourceAvailability{}, service.ErrResourceNotFound,
+				)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"Resource is not found"}`,
+		},
+		{
+			name:                 "Missing Range",
+			resourceId:           "2",
+			mockBehavior:         func(r *service_mocks.MockResources) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Invalid query params"}`,
+		},
+		{
+			name:                 "Invalid Id",
+			resourceId:           "abc",
+			query:                "?from=2024-03-04T00:00:00Z&to=2024-03-05T00:00:00Z",
+			mockBehavior:         func(r *service_mocks.MockResources) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Invalid param in url: [id]"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			resources := service_mocks.NewMockResources(c)
+			test.mockBehavior(resources)
+
+			services := &service.Service{Resources: resources}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.GET("/resources/:id/availability", handler.GetResourceAvailability)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/resources/"+test.resourceId+"/availability"+test.query, nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_getResourceUtilization(t *testing.T) {
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockResources)
+
+	tests := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			query: "?from=2024-03-04T00:00:00Z&to=2024-03-05T00:00:00Z",
+			mockBehavior: func(r *service_mocks.MockResources) {
+				r.EXPECT().GetUtilization(testActor, domain.TimeRangeFilter{From: from, To: to}).Return(
+					[]domain.ResourceUtilization{{ResourceId: 2, Name: "Room A", ReservedMinutes: 360, Utilization: 0.25}}, nil,
+				)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"Data":[{"resourceId":2,"name":"Room A","reservedMinutes":360,` +
+				`"utilization":0.25}]}`,
+		},
+		{
+			name:  "Invalid Range",
+			query: "?from=2024-03-05T00:00:00Z&to=2024-03-04T00:00:00Z",
+			mockBehavior: func(r *service_mocks.MockResources) {
+				r.EXPECT().GetUtilization(testActor, domain.TimeRangeFilter{From: to, To: from}).Return(
+					nil, service.ErrInvalidTimeRange,
+				)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Time range is invalid, to should be after from and not later than 93 days"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			resources := service_mocks.NewMockResources(c)
+			test.mockBehavior(resources)
+
+			services := &service.Service{Resources: resources}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.GET("/resources/utilization", handler.GetResourceUtilization)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/resources/utilization"+test.query, nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type SuggestedSlotsResponse struct {
+	Data []domain.SuggestedSlot
+}
+
+// @Summary     Suggest meeting slots
+// @Tags        Scheduling
+// @Description Get ranked slots in working hours when required participants are free, slots with more free optional participants go first
+// @ID          suggest-slots
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.SuggestSlotsRequest true "Request"
+// @Success     200     {object} SuggestedSlotsResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/scheduling/suggest [post]
+func (h *Handler) SuggestSlots(ctx *gin.Context) {
+	var request domain.SuggestSlotsRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("suggest-slots", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Scheduling.SuggestSlots(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("suggest-slots", err)
+		NewErrorResponse(ctx, schedulingErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuggestedSlotsResponse{result})
+}
+
+func schedulingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUnknownTimezone),
+		errors.Is(err, service.ErrInvalidWorkingHours),
+		errors.Is(err, service.ErrUnknownRequiredUser):
+		return http.StatusBadRequest
+	}
+
+	return freeBusyErrorStatus(err)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_suggestSlots(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockScheduling, request domain.SuggestSlotsRequest)
+
+	timeMin := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	timeMax := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+	request := domain.SuggestSlotsRequest{
+		ParticipantIds:  []int{2, 3},
+		RequiredIds:     []int{2},
+		DurationMinutes: 30,
+		TimeMin:         timeMin,
+		TimeMax:         timeMax,
+		TimezoneId:      "UTC",
+		WorkingHours:    domain.WorkingHours{Start: "10:00", End: "16:00"},
+	}
+	inputBody := `{"participantIds":[2,3],"requiredIds":[2],"durationMinutes":30,` +
+		`"timeMin":"2024-05-01T00:00:00Z","timeMax":"2024-05-03T00:00:00Z","timezoneId":"UTC",` +
+		`"workingHours":{"start":"10:00","end":"16:00"}}`
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.SuggestSlotsRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: inputBody,
+			request:   request,
+			mockBehavior: func(r *service_mocks.MockScheduling, request domain.SuggestSlotsRequest) {
+				start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+				r.EXPECT().SuggestSlots(testActor, request).Return([]domain.SuggestedSlot{
+					{Start: start, End: start.Add(30 * time.Minute), UnavailableIds: []int{}},
+					{Start: start.Add(time.Hour), End: start.Add(90 * time.Minute), UnavailableIds: []int{3}},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"Data":[{"start":"2024-05-01T10:00:00Z","end":"2024-05-01T10:30:00Z","unavailableIds":[]},` +
+				`{"start":"2024-05-01T11:00:00Z","end":"2024-05-01T11:30:00Z","unavailableIds":[3]}]}`,
+		},
+		{
+			name:      "Invalid Working Hours",
+			inputBody: inputBody,
+			request:   request,
+			mockBehavior: func(r *service_mocks.MockScheduling, request domain.SuggestSlotsRequest) {
+				r.EXPECT().SuggestSlots(testActor, request).Return(nil, service.ErrInvalidWorkingHours)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Working hours are invalid, use HH:MM and end after start"}`,
+		},
+		{
+			name:      "Not a Member",
+			inputBody: inputBody,
+			request:   request,
+			mockBehavior: func(r *service_mocks.MockScheduling, request domain.SuggestSlotsRequest) {
+				r.EXPECT().SuggestSlots(testActor, request).Return(nil, service.ErrUserNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"User is not found"}`,
+		},
+		{
+			name:                 "Invalid Request",
+			inputBody:            `{"participantIds":[2],"durationMinutes":2,"timeMin":"2024-05-01T00:00:00Z","timeMax":"2024-05-03T00:00:00Z"}`,
+			mockBehavior:         func(r *service_mocks.MockScheduling, request domain.SuggestSlotsRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			scheduling := service_mocks.NewMockScheduling(c)
+			test.mockBehavior(scheduling, test.request)
+
+			services := &service.Service{Scheduling: scheduling}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/scheduling/suggest", handler.SuggestSlots)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/scheduling/suggest", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
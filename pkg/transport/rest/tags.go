@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type TagsResponse struct {
+	Data []domain.Tag
+}
+
+// @Summary     Get tags
+// @Tags        Tags
+// @Description Get tags of active organization and personal tags of current User
+// @ID          get-tags
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} TagsResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/tags/ [get]
+func (h *Handler) GetTags(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Tags.GetAll(actor)
+	if err != nil {
+		logger.LogHandlerIssue("get-tags", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TagsResponse{result})
+}
+
+// @Summary     Create tag
+// @Tags        Tags
+// @Description Create personal tag or tag of active organization (organization owners and admins only)
+// @ID          create-tag
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.SaveTagRequest true "Request"
+// @Success     201
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     409     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/tags/ [post]
+func (h *Handler) CreateTag(ctx *gin.Context) {
+	var request domain.SaveTagRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("create-tag", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.Tags.Create(actor, request)
+	if err != nil {
+		logger.LogHandlerIssue("create-tag", err)
+		NewErrorResponse(ctx, tagErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{
+		"id": result,
+	})
+}
+
+// @Summary     Update tag
+// @Tags        Tags
+// @Description Rename tag, tags of active organization are renamed by organization owners and admins only
+// @ID          update-tag
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int                   true "Tag Id"
+// @Param       input   body     domain.SaveTagRequest true "Request"
+// @Success     200     {object} domain.Tag
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404,409 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/tags/{id} [post]
+func (h *Handler) UpdateTag(ctx *gin.Context) {
+	var request domain.SaveTagRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("update-tag", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	tagId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("update-tag", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	result, err := h.services.Tags.Update(actor, tagId, request)
+	if err != nil {
+		logger.LogHandlerIssue("update-tag", err)
+		NewErrorResponse(ctx, tagErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary     Delete tag
+// @Tags        Tags
+// @Description Delete tag and remove it from events,
+// @Description tags of active organization are deleted by organization owners and admins only
+// @ID          delete-tag
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Tag Id"
+// @Success     200
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     404     {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/tags/{id} [delete]
+func (h *Handler) DeleteTag(ctx *gin.Context) {
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	tagId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("delete-tag", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	if err := h.services.Tags.Delete(actor, tagId); err != nil {
+		logger.LogHandlerIssue("delete-tag", err)
+		NewErrorResponse(ctx, tagErrorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Tag [id]:%d has been deleted successfully", tagId),
+	})
+}
+
+func tagErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTagNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrTagExists):
+		return http.StatusConflict
+	}
+
+	return organizationErrorStatus(err)
+}
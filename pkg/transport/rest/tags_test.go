@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/service"
+	service_mocks "github.com/salesforceanton/events-api/pkg/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createTag(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTags, request domain.SaveTagRequest)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		request              domain.SaveTagRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"name":"onboarding"}`,
+			request:   domain.SaveTagRequest{Name: "onboarding"},
+			mockBehavior: func(r *service_mocks.MockTags, request domain.SaveTagRequest) {
+				r.EXPECT().Create(testActor, request).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:      "Organization Tag Not Manager",
+			inputBody: `{"name":"all-hands","scope":"organization"}`,
+			request:   domain.SaveTagRequest{Name: "all-hands", Scope: domain.TAG_SCOPE_ORGANIZATION},
+			mockBehavior: func(r *service_mocks.MockTags, request domain.SaveTagRequest) {
+				r.EXPECT().Create(testActor, request).Return(0, service.ErrForbidden)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"Not enough permissions for this operation"}`,
+		},
+		{
+			name:      "Duplicate Name",
+			inputBody: `{"name":"onboarding"}`,
+			request:   domain.SaveTagRequest{Name: "onboarding"},
+			mockBehavior: func(r *service_mocks.MockTags, request domain.SaveTagRequest) {
+				r.EXPECT().Create(testActor, request).Return(0, service.ErrTagExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"Tag with this name already exists"}`,
+		},
+		{
+			name:                 "Unknown Scope",
+			inputBody:            `{"name":"onboarding","scope":"team"}`,
+			mockBehavior:         func(r *service_mocks.MockTags, request domain.SaveTagRequest) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Request is invalid type"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			tags := service_mocks.NewMockTags(c)
+			test.mockBehavior(tags, test.request)
+
+			services := &service.Service{Tags: tags}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.POST("/tags", handler.CreateTag)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/tags", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteTag(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTags)
+
+	tests := []struct {
+		name                 string
+		tagId                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			tagId: "2",
+			mockBehavior: func(r *service_mocks.MockTags) {
+				r.EXPECT().Delete(testActor, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"Status":"Tag [id]:2 has been deleted successfully"}`,
+		},
+		{
+			name:  "Not Found",
+			tagId: "3",
+			mockBehavior: func(r *service_mocks.MockTags) {
+				r.EXPECT().Delete(testActor, 3).Return(service.ErrTagNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"Tag is not found"}`,
+		},
+		{
+			name:                 "Invalid Id",
+			tagId:                "abc",
+			mockBehavior:         func(r *service_mocks.MockTags) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Invalid param in url: [id]"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			tags := service_mocks.NewMockTags(c)
+			test.mockBehavior(tags)
+
+			services := &service.Service{Tags: tags}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Set(USER_CTX, 1)
+				ctx.Set(ORG_CTX, 1)
+			})
+			r.DELETE("/tags/:id", handler.DeleteTag)
+
+			// Create Request and empty Response
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/tags/"+test.tagId, nil)
+
+			// Make Request
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedResponseBody, resp.Body.String())
+		})
+	}
+}
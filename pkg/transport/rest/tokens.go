@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/salesforceanton/events-api/domain"
+	"github.com/salesforceanton/events-api/pkg/logger"
+	"github.com/salesforceanton/events-api/pkg/service"
+)
+
+type AccessTokensResponse struct {
+	Data []domain.AccessToken
+}
+
+// @Summary     Get access tokens
+// @Tags        Access Tokens
+// @Description Get active personal access tokens of current User
+// @ID          get-access-tokens
+// @Accept      json
+// @Produce     json
+// @Success     200     {object} AccessTokensResponse
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/tokens/ [get]
+func (h *Handler) GetAccessTokens(ctx *gin.Context) {
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.AccessTokens.GetAll(userId)
+	if err != nil {
+		logger.LogHandlerIssue("get-access-tokens", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, AccessTokensResponse{result})
+}
+
+// @Summary     Create access token
+// @Tags        Access Tokens
+// @Description Create personal access token with defined scopes for active organization, token value is returned only once
+// @ID          create-access-token
+// @Accept      json
+// @Produce     json
+// @Param       input   body     domain.CreateAccessTokenRequest true "Request"
+// @Success     201     {object} domain.CreatedAccessToken
+// @Failure     400,403 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/tokens/ [post]
+func (h *Handler) CreateAccessToken(ctx *gin.Context) {
+	var request domain.CreateAccessTokenRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		logger.LogHandlerIssue("create-access-token", errors.New("Request is invalid type"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Request is invalid type")
+		return
+	}
+
+	actor, err := h.getActor(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.services.AccessTokens.Create(actor, request)
+	if errors.Is(err, service.ErrUnknownScope) {
+		NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		logger.LogHandlerIssue("create-access-token", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+// @Summary     Revoke access token
+// @Tags        Access Tokens
+// @Description Revoke personal access token of current User
+// @ID          revoke-access-token
+// @Accept      json
+// @Produce     json
+// @Param       id      path     int          true "Token Id"
+// @Success     200
+// @Failure     400,404 {object} ErrorResponse
+// @Failure     500     {object} ErrorResponse
+// @Router      /api/tokens/{id} [delete]
+func (h *Handler) RevokeAccessToken(ctx *gin.Context) {
+	userId, err := h.getUserContext(ctx)
+	if err != nil {
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	tokenId, err := h.getUrlParam(ctx, "id")
+	if err != nil {
+		logger.LogHandlerIssue("revoke-access-token", errors.New("Invalid param in url: [id]"))
+		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid param in url: [id]")
+		return
+	}
+
+	err = h.services.AccessTokens.Revoke(userId, tokenId)
+	if errors.Is(err, service.ErrAccessTokenNotFound) {
+		NewErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		logger.LogHandlerIssue("revoke-access-token", err)
+		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"Status": fmt.Sprintf("Access token [id]:%d has been revoked successfully", tokenId),
+	})
+}
@@ -0,0 +1,11 @@
+ALTER TABLE users ADD COLUMN email_verified boolean not null default false;
+
+CREATE TABLE user_tokens
+(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    purpose varchar(32) not null,
+    token_hash varchar(64) not null unique,
+    expires_at timestamptz not null,
+    used_at timestamptz
+);
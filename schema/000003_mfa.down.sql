@@ -0,0 +1,6 @@
+DROP TABLE mfa_recovery_codes;
+
+ALTER TABLE users DROP COLUMN mfa_last_step;
+ALTER TABLE users DROP COLUMN mfa_secret;
+ALTER TABLE users DROP COLUMN mfa_required;
+ALTER TABLE users DROP COLUMN mfa_enabled;
@@ -0,0 +1,12 @@
+ALTER TABLE users ADD COLUMN mfa_enabled boolean not null default false;
+ALTER TABLE users ADD COLUMN mfa_required boolean not null default false;
+ALTER TABLE users ADD COLUMN mfa_secret varchar(64);
+ALTER TABLE users ADD COLUMN mfa_last_step bigint not null default 0;
+
+CREATE TABLE mfa_recovery_codes
+(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    code_hash varchar(64) not null,
+    used_at timestamptz
+);
@@ -0,0 +1,13 @@
+CREATE TABLE access_tokens
+(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    name varchar(255) not null,
+    token_prefix varchar(16) not null,
+    token_hash varchar(64) not null unique,
+    scopes text[] not null,
+    created_at timestamptz not null default now(),
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    revoked_at timestamptz
+);
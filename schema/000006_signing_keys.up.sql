@@ -0,0 +1,10 @@
+CREATE TABLE signing_keys
+(
+    kid varchar(32) not null primary key,
+    algorithm varchar(16) not null,
+    private_key bytea not null,
+    public_key bytea not null,
+    created_at timestamptz not null default now(),
+    retired_at timestamptz,
+    expires_at timestamptz
+);
@@ -0,0 +1,18 @@
+CREATE TABLE oidc_login_states
+(
+    state varchar(64) not null primary key,
+    nonce varchar(64) not null,
+    code_verifier varchar(128) not null,
+    expires_at timestamptz not null
+);
+
+CREATE TABLE user_identities
+(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    issuer varchar(255) not null,
+    subject varchar(255) not null,
+    email varchar(255),
+    created_at timestamptz not null default now(),
+    unique (issuer, subject)
+);
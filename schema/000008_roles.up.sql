@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN role varchar(32) not null default 'user'
+    CHECK (role IN ('user', 'moderator', 'admin'));
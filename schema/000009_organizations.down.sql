@@ -0,0 +1,7 @@
+ALTER TABLE access_tokens DROP COLUMN organization_id;
+
+ALTER TABLE events DROP COLUMN organization_id;
+
+DROP TABLE organization_members;
+
+DROP TABLE organizations;
@@ -0,0 +1,29 @@
+CREATE TABLE organizations
+(
+    id serial not null unique,
+    name varchar(255) not null,
+    created_by int references users(id) on delete set null,
+    created_at timestamptz not null default now()
+);
+
+CREATE TABLE organization_members
+(
+    organization_id int references organizations(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade not null,
+    role varchar(32) not null default 'member' CHECK (role IN ('owner', 'admin', 'member')),
+    created_at timestamptz not null default now(),
+    primary key (organization_id, user_id)
+);
+
+-- Every existing user gets personal workspace which owns existing events and tokens of the user
+INSERT INTO organizations (name, created_by) SELECT username, id FROM users ORDER BY id;
+INSERT INTO organization_members (organization_id, user_id, role) SELECT id, created_by, 'owner' FROM organizations;
+
+ALTER TABLE events ADD COLUMN organization_id int references organizations(id) on delete cascade;
+UPDATE events SET organization_id = o.id FROM organizations o WHERE o.created_by = events.organizerId;
+ALTER TABLE events ALTER COLUMN organization_id SET NOT NULL;
+CREATE INDEX events_organization_idx ON events (organization_id);
+
+ALTER TABLE access_tokens ADD COLUMN organization_id int references organizations(id) on delete cascade;
+UPDATE access_tokens SET organization_id = o.id FROM organizations o WHERE o.created_by = access_tokens.user_id;
+ALTER TABLE access_tokens ALTER COLUMN organization_id SET NOT NULL;
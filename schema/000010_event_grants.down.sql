@@ -0,0 +1,5 @@
+DROP TABLE event_grants;
+
+DROP TABLE group_members;
+
+DROP TABLE groups;
@@ -0,0 +1,29 @@
+CREATE TABLE groups
+(
+    id serial not null unique,
+    organization_id int references organizations(id) on delete cascade not null,
+    name varchar(255) not null,
+    created_at timestamptz not null default now()
+);
+
+CREATE TABLE group_members
+(
+    group_id int references groups(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade not null,
+    primary key (group_id, user_id)
+);
+
+CREATE TABLE event_grants
+(
+    id serial not null unique,
+    event_id int references events(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade,
+    group_id int references groups(id) on delete cascade,
+    permission varchar(32) not null CHECK (permission IN ('viewer', 'editor', 'co-organizer')),
+    created_at timestamptz not null default now(),
+    CHECK ((user_id IS NULL) <> (group_id IS NULL))
+);
+
+CREATE UNIQUE INDEX event_grants_user_idx ON event_grants (event_id, user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX event_grants_group_idx ON event_grants (event_id, group_id) WHERE group_id IS NOT NULL;
+CREATE INDEX group_members_user_idx ON group_members (user_id);
@@ -0,0 +1,26 @@
+CREATE TABLE calendar_delegations
+(
+    id serial not null unique,
+    organization_id int references organizations(id) on delete cascade not null,
+    principal_id int references users(id) on delete cascade not null,
+    delegate_id int references users(id) on delete cascade not null,
+    access varchar(16) not null CHECK (access IN ('read', 'full')),
+    created_at timestamptz not null default now(),
+    unique (organization_id, principal_id, delegate_id),
+    CHECK (principal_id <> delegate_id)
+);
+
+CREATE TABLE audit_log
+(
+    id bigserial not null unique,
+    organization_id int references organizations(id) on delete cascade not null,
+    actor_id int references users(id) on delete set null,
+    principal_id int references users(id) on delete set null,
+    action varchar(64) not null,
+    event_id int,
+    outcome varchar(255) not null,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX audit_log_actor_idx ON audit_log (organization_id, actor_id);
+CREATE INDEX audit_log_principal_idx ON audit_log (organization_id, principal_id);
@@ -0,0 +1,3 @@
+ALTER TABLE events DROP COLUMN calendar_id;
+
+DROP TABLE calendars;
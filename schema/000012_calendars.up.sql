@@ -0,0 +1,31 @@
+CREATE TABLE calendars
+(
+    id serial not null unique,
+    organization_id int references organizations(id) on delete cascade not null,
+    owner_id int references users(id) on delete cascade not null,
+    name varchar(255) not null,
+    color varchar(7) not null default '#3174ad',
+    timezone_id varchar(64) not null default '',
+    is_default boolean not null default false,
+    created_at timestamptz not null default now()
+);
+
+-- Every user has one default calendar per organization, it is used for events created without calendar
+CREATE UNIQUE INDEX calendars_default_idx ON calendars (organization_id, owner_id) WHERE is_default;
+CREATE INDEX calendars_owner_idx ON calendars (organization_id, owner_id);
+
+INSERT INTO calendars (organization_id, owner_id, name, is_default)
+SELECT organization_id, user_id, 'Personal', true FROM organization_members;
+
+-- Organizers who already left organization keep their events
+INSERT INTO calendars (organization_id, owner_id, name, is_default)
+SELECT DISTINCT e.organization_id, e.organizerId, 'Personal', true FROM events e
+WHERE NOT EXISTS (
+    SELECT 1 FROM calendars c WHERE c.organization_id = e.organization_id AND c.owner_id = e.organizerId
+);
+
+ALTER TABLE events ADD COLUMN calendar_id int references calendars(id) on delete cascade;
+UPDATE events SET calendar_id = c.id FROM calendars c
+WHERE c.organization_id = events.organization_id AND c.owner_id = events.organizerId AND c.is_default;
+ALTER TABLE events ALTER COLUMN calendar_id SET NOT NULL;
+CREATE INDEX events_calendar_idx ON events (calendar_id);
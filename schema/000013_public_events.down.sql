@@ -0,0 +1,2 @@
+ALTER TABLE events DROP COLUMN slug;
+ALTER TABLE events DROP COLUMN visibility;
@@ -0,0 +1,6 @@
+ALTER TABLE events ADD COLUMN visibility varchar(16) not null default 'private'
+    CHECK (visibility IN ('private', 'unlisted', 'public'));
+-- Slug is assigned when event is published for the first time and is kept, so shared links stay valid
+ALTER TABLE events ADD COLUMN slug varchar(128) unique;
+
+CREATE INDEX events_public_idx ON events (startDatetime) WHERE visibility = 'public';
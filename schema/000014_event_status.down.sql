@@ -0,0 +1,3 @@
+ALTER TABLE events DROP COLUMN cancelled_at;
+ALTER TABLE events DROP COLUMN cancellation_reason;
+ALTER TABLE events DROP COLUMN status;
@@ -0,0 +1,5 @@
+-- Existing events are already announced
+ALTER TABLE events ADD COLUMN status varchar(16) not null default 'published'
+    CHECK (status IN ('draft', 'published', 'cancelled'));
+ALTER TABLE events ADD COLUMN cancellation_reason varchar(255);
+ALTER TABLE events ADD COLUMN cancelled_at timestamptz;
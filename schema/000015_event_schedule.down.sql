@@ -0,0 +1,5 @@
+DROP TABLE event_attendees;
+
+ALTER TABLE events DROP COLUMN ends_at;
+ALTER TABLE events DROP COLUMN starts_at;
+ALTER TABLE events DROP COLUMN endDatetime;
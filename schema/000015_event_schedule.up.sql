@@ -0,0 +1,26 @@
+-- Events get end time, start and end are wall time in event timezone like before.
+-- Instants of start and end are kept to find overlapping events of different timezones
+ALTER TABLE events ADD COLUMN endDatetime timestamp;
+ALTER TABLE events ADD COLUMN starts_at timestamptz;
+ALTER TABLE events ADD COLUMN ends_at timestamptz;
+
+-- Timezone is validated for new events, events with unknown timezone are treated as UTC ones
+UPDATE events SET timezoneId = 'UTC' WHERE timezoneId NOT IN (SELECT name FROM pg_timezone_names);
+UPDATE events SET endDatetime = startDatetime + interval '1 hour';
+UPDATE events SET starts_at = startDatetime AT TIME ZONE timezoneId, ends_at = endDatetime AT TIME ZONE timezoneId;
+
+ALTER TABLE events ALTER COLUMN endDatetime SET NOT NULL;
+ALTER TABLE events ALTER COLUMN starts_at SET NOT NULL;
+ALTER TABLE events ALTER COLUMN ends_at SET NOT NULL;
+ALTER TABLE events ADD CONSTRAINT events_time_range_check CHECK (ends_at > starts_at);
+CREATE INDEX events_schedule_idx ON events (organization_id, starts_at, ends_at);
+
+CREATE TABLE event_attendees
+(
+    event_id int references events(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade not null,
+    created_at timestamptz not null default now(),
+    primary key (event_id, user_id)
+);
+
+CREATE INDEX event_attendees_user_idx ON event_attendees (user_id);
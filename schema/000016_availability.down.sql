@@ -0,0 +1,3 @@
+DROP TABLE out_of_office;
+DROP TABLE user_working_hours;
+ALTER TABLE users DROP COLUMN timezone_id;
@@ -0,0 +1,23 @@
+-- Working hours are wall time in timezone of the user, weekday is 0 for Sunday
+ALTER TABLE users ADD COLUMN timezone_id varchar(64) not null default 'UTC';
+
+CREATE TABLE user_working_hours
+(
+    user_id int references users(id) on delete cascade not null,
+    weekday smallint not null check (weekday BETWEEN 0 AND 6),
+    start_time time not null,
+    end_time time not null check (end_time > start_time),
+    primary key (user_id, weekday)
+);
+
+CREATE TABLE out_of_office
+(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    starts_at timestamptz not null,
+    ends_at timestamptz not null check (ends_at > starts_at),
+    reason varchar(255) not null default '',
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX out_of_office_user_idx ON out_of_office (user_id, starts_at, ends_at);
@@ -0,0 +1,3 @@
+DROP TABLE bookings;
+DROP TABLE booking_page_windows;
+DROP TABLE booking_pages;
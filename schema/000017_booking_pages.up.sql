@@ -0,0 +1,44 @@
+-- Booking page of a host, external guests book slots in availability windows of the page
+CREATE TABLE booking_pages
+(
+    id serial not null unique,
+    organization_id int references organizations(id) on delete cascade not null,
+    host_id int references users(id) on delete cascade not null,
+    calendar_id int references calendars(id) on delete cascade not null,
+    slug varchar(80) not null unique,
+    title varchar(255) not null,
+    description text not null default '',
+    duration_minutes int not null check (duration_minutes > 0),
+    buffer_before_minutes int not null default 0 check (buffer_before_minutes >= 0),
+    buffer_after_minutes int not null default 0 check (buffer_after_minutes >= 0),
+    max_per_day int not null default 0 check (max_per_day >= 0),
+    timezone_id varchar(64) not null default 'UTC',
+    is_active boolean not null default true,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX booking_pages_host_idx ON booking_pages (organization_id, host_id);
+
+-- Availability windows are wall time in timezone of the page, weekday is 0 for Sunday
+CREATE TABLE booking_page_windows
+(
+    booking_page_id int references booking_pages(id) on delete cascade not null,
+    weekday smallint not null check (weekday BETWEEN 0 AND 6),
+    start_time time not null,
+    end_time time not null check (end_time > start_time),
+    primary key (booking_page_id, weekday, start_time)
+);
+
+CREATE TABLE bookings
+(
+    id serial not null unique,
+    booking_page_id int references booking_pages(id) on delete cascade not null,
+    event_id int references events(id) on delete cascade not null unique,
+    guest_name varchar(255) not null,
+    guest_email varchar(255) not null,
+    starts_at timestamptz not null,
+    ends_at timestamptz not null,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX bookings_page_idx ON bookings (booking_page_id, starts_at);
@@ -0,0 +1,28 @@
+-- Equality on int in exclusion constraint needs btree_gist
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE resources
+(
+    id serial not null unique,
+    organization_id int references organizations(id) on delete cascade not null,
+    name varchar(255) not null,
+    capacity int not null default 0 check (capacity >= 0),
+    location varchar(255) not null default '',
+    created_at timestamptz not null default now(),
+    unique (organization_id, name)
+);
+
+-- Reservation keeps time of the event, reservations of cancelled events are released.
+-- Overlapping reservations of a resource are rejected by db, so concurrent requests can not double book it
+CREATE TABLE event_resources
+(
+    event_id int references events(id) on delete cascade not null,
+    resource_id int references resources(id) on delete cascade not null,
+    starts_at timestamptz not null,
+    ends_at timestamptz not null,
+    released boolean not null default false,
+    primary key (event_id, resource_id),
+    constraint event_resources_overlap_excl exclude using gist (
+        resource_id WITH =, tstzrange(starts_at, ends_at) WITH &&
+    ) WHERE (NOT released)
+);
@@ -0,0 +1,6 @@
+DROP INDEX events_coordinates_idx;
+ALTER TABLE events DROP CONSTRAINT events_coordinates_check;
+ALTER TABLE events DROP COLUMN online_url;
+ALTER TABLE events DROP COLUMN longitude;
+ALTER TABLE events DROP COLUMN latitude;
+ALTER TABLE events DROP COLUMN address;
@@ -0,0 +1,13 @@
+-- Location of the event, coordinates are WGS84 degrees. Online events have url only
+ALTER TABLE events ADD COLUMN address varchar(500) not null default '';
+ALTER TABLE events ADD COLUMN latitude double precision;
+ALTER TABLE events ADD COLUMN longitude double precision;
+ALTER TABLE events ADD COLUMN online_url varchar(2048) not null default '';
+
+ALTER TABLE events ADD CONSTRAINT events_coordinates_check CHECK (
+    (latitude IS NULL AND longitude IS NULL) OR
+    (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180)
+);
+
+-- Nearby search filters by bounding box before computing distance
+CREATE INDEX events_coordinates_idx ON events (latitude, longitude) WHERE latitude IS NOT NULL;
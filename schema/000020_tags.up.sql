@@ -0,0 +1,22 @@
+-- Tags of the organization are shared by its members, personal tags have owner
+CREATE TABLE tags
+(
+    id serial not null unique,
+    organization_id int references organizations(id) on delete cascade not null,
+    owner_id int references users(id) on delete cascade,
+    name varchar(64) not null,
+    created_at timestamptz not null default now()
+);
+
+-- Names are unique in each vocabulary regardless of case
+CREATE UNIQUE INDEX tags_organization_name_idx ON tags (organization_id, lower(name)) WHERE owner_id IS NULL;
+CREATE UNIQUE INDEX tags_owner_name_idx ON tags (organization_id, owner_id, lower(name)) WHERE owner_id IS NOT NULL;
+
+CREATE TABLE event_tags
+(
+    event_id int references events(id) on delete cascade not null,
+    tag_id int references tags(id) on delete cascade not null,
+    primary key (event_id, tag_id)
+);
+
+CREATE INDEX event_tags_tag_idx ON event_tags (tag_id);
@@ -0,0 +1,3 @@
+DROP INDEX events_custom_fields_idx;
+ALTER TABLE events DROP COLUMN custom_fields;
+DROP TABLE custom_fields;
@@ -0,0 +1,18 @@
+-- Schema of extra event data defined by organization, values are stored in events by field key
+CREATE TABLE custom_fields
+(
+    id serial not null unique,
+    organization_id int references organizations(id) on delete cascade not null,
+    key varchar(64) not null,
+    label varchar(255) not null default '',
+    type varchar(16) not null check (type IN ('string', 'number', 'boolean', 'enum')),
+    required boolean not null default false,
+    options jsonb not null default '[]',
+    created_at timestamptz not null default now(),
+    unique (organization_id, key)
+);
+
+ALTER TABLE events ADD COLUMN custom_fields jsonb not null default '{}';
+
+-- Events are filtered by values with containment operator
+CREATE INDEX events_custom_fields_idx ON events USING gin (custom_fields jsonb_path_ops);
@@ -0,0 +1,3 @@
+DROP TABLE reminder_jobs;
+DROP TABLE event_reminders;
+DROP TABLE user_reminders;
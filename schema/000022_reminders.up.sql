@@ -0,0 +1,35 @@
+-- Default reminders of the user in minutes before start, they are used for events without own reminders
+CREATE TABLE user_reminders
+(
+    user_id int references users(id) on delete cascade not null,
+    minutes_before int not null check (minutes_before BETWEEN 0 AND 10080),
+    primary key (user_id, minutes_before)
+);
+
+-- Reminders set by organizer are sent to every participant of the event
+CREATE TABLE event_reminders
+(
+    event_id int references events(id) on delete cascade not null,
+    minutes_before int not null check (minutes_before BETWEEN 0 AND 10080),
+    primary key (event_id, minutes_before)
+);
+
+-- Due reminders are materialized as jobs. A job is claimed by one server with row lock and is marked sent
+-- in the same transaction, moved events get new jobs and jobs of the previous time are skipped
+CREATE TABLE reminder_jobs
+(
+    id bigserial not null unique,
+    event_id int references events(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade not null,
+    minutes_before int not null,
+    starts_at timestamptz not null,
+    remind_at timestamptz not null,
+    status varchar(16) not null default 'pending' check (status IN ('pending', 'sent', 'skipped', 'failed')),
+    attempts int not null default 0,
+    last_error text not null default '',
+    sent_at timestamptz,
+    created_at timestamptz not null default now(),
+    unique (event_id, user_id, minutes_before, starts_at)
+);
+
+CREATE INDEX reminder_jobs_due_idx ON reminder_jobs (remind_at) WHERE status = 'pending';
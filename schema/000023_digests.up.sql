@@ -0,0 +1,13 @@
+-- Agenda digest is sent daily or weekly at wall time in timezone of the user, weekday is 0 for Sunday.
+-- Users without preferences do not get digests
+CREATE TABLE digest_preferences
+(
+    user_id int references users(id) on delete cascade not null primary key,
+    frequency varchar(16) not null check (frequency IN ('daily', 'weekly')),
+    send_time time not null,
+    weekday smallint not null default 1 check (weekday BETWEEN 0 AND 6),
+    next_send_at timestamptz not null,
+    last_sent_at timestamptz
+);
+
+CREATE INDEX digest_preferences_due_idx ON digest_preferences (next_send_at);
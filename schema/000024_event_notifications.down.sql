@@ -0,0 +1,2 @@
+ALTER TABLE events DROP COLUMN sequence;
+DROP TABLE notification_preferences;
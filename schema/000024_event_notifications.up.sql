@@ -0,0 +1,10 @@
+-- Attendees are notified about changed and cancelled events unless they opt out
+CREATE TABLE notification_preferences
+(
+    user_id int references users(id) on delete cascade not null primary key,
+    event_updates boolean not null default true,
+    event_cancellations boolean not null default true
+);
+
+-- Revision of the event in calendar messages, clients apply only messages with the same or greater sequence
+ALTER TABLE events ADD COLUMN sequence int not null default 0;
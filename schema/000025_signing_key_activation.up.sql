@@ -0,0 +1,5 @@
+-- Next key is published ahead of its activation, so verifiers which cache JWKS know it before tokens are signed with it
+ALTER TABLE signing_keys ADD COLUMN activates_at timestamptz;
+UPDATE signing_keys SET activates_at = created_at;
+ALTER TABLE signing_keys ALTER COLUMN activates_at SET NOT NULL;
+ALTER TABLE signing_keys ALTER COLUMN activates_at SET DEFAULT now();
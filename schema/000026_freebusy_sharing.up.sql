@@ -0,0 +1,2 @@
+-- Users can hide their busy time from other members, free/busy then marks them as private
+ALTER TABLE users ADD COLUMN freebusy_shared boolean not null default true;
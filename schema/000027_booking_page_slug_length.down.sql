@@ -0,0 +1 @@
+ALTER TABLE booking_pages ALTER COLUMN slug TYPE varchar(80);
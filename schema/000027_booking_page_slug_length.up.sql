@@ -0,0 +1,2 @@
+-- Slug has 128-bit random suffix, the same length as event slugs is allowed
+ALTER TABLE booking_pages ALTER COLUMN slug TYPE varchar(128);